@@ -201,6 +201,35 @@ func (bracket *Bracket) GetMatchup(round, group int) (*Matchup, error) {
 	return nil, fmt.Errorf("bracket does not contain matchup for key %+v", matchupKey)
 }
 
+// Returns true if any match has been played in a matchup that takes an alliance from the given one, either directly or
+// by way of further matchups. Such matches would be invalidated by a change to the outcome of the given matchup.
+func (bracket *Bracket) HasPlayedDependentMatches(database *model.Database, round, group int) (bool, error) {
+	matchup, err := bracket.GetMatchup(round, group)
+	if err != nil {
+		return false, err
+	}
+	for _, dependentMatchup := range bracket.GetAllMatchups() {
+		if dependentMatchup.redAllianceSourceMatchup != matchup &&
+			dependentMatchup.blueAllianceSourceMatchup != matchup {
+			continue
+		}
+		matches, err := database.GetMatchesByElimRoundGroup(dependentMatchup.Round, dependentMatchup.Group)
+		if err != nil {
+			return false, err
+		}
+		for _, match := range matches {
+			if match.IsComplete() {
+				return true, nil
+			}
+		}
+		hasPlayed, err := bracket.HasPlayedDependentMatches(database, dependentMatchup.Round, dependentMatchup.Group)
+		if err != nil || hasPlayed {
+			return hasPlayed, err
+		}
+	}
+	return false, nil
+}
+
 // Traverses the bracket to update the state of each matchup based on match results, counting wins and creating or
 // deleting matches as required.
 func (bracket *Bracket) Update(database *model.Database, startTime *time.Time) error {
@@ -210,6 +210,35 @@ func TestBracketGetMatchup(t *testing.T) {
 	assert.Nil(t, matchup)
 }
 
+func TestBracketHasPlayedDependentMatches(t *testing.T) {
+	database := setupTestDb(t)
+
+	tournament.CreateTestAlliances(database, 4)
+	bracket, err := NewSingleEliminationBracket(4)
+	assert.Nil(t, err)
+	assert.Nil(t, bracket.Update(database, &dummyStartTime))
+	scoreMatch(database, "SF1-1", game.RedWonMatch)
+	scoreMatch(database, "SF1-2", game.RedWonMatch)
+	scoreMatch(database, "SF2-1", game.BlueWonMatch)
+	scoreMatch(database, "SF2-2", game.BlueWonMatch)
+	assert.Nil(t, bracket.Update(database, &dummyStartTime))
+
+	hasPlayed, err := bracket.HasPlayedDependentMatches(database, 3, 1)
+	assert.Nil(t, err)
+	assert.False(t, hasPlayed)
+
+	scoreMatch(database, "F-1", game.RedWonMatch)
+	hasPlayed, err = bracket.HasPlayedDependentMatches(database, 3, 1)
+	assert.Nil(t, err)
+	assert.True(t, hasPlayed)
+	hasPlayed, err = bracket.HasPlayedDependentMatches(database, 4, 1)
+	assert.Nil(t, err)
+	assert.False(t, hasPlayed)
+
+	_, err = bracket.HasPlayedDependentMatches(database, 2, 1)
+	assert.NotNil(t, err)
+}
+
 func TestBracketLevelOrderTraversal(t *testing.T) {
 	database := setupTestDb(t)
 	tournament.CreateTestAlliances(database, 8)
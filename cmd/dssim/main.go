@@ -0,0 +1,105 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Command-line tool that emulates the driver stations of the given teams, for testing the field without robots.
+//
+// Example: go run ./cmd/dssim -teams 254,1114,2056,1678,118,1987 -flap-period 20s
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/FRCTeam1987/crimson-arena/dssim"
+	"github.com/FRCTeam1987/crimson-arena/network"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func main() {
+	defaultConfig := dssim.DefaultConfig(0)
+	server := flag.String("server", network.ServerIpAddress, "Address of the FMS")
+	teams := flag.String("teams", "", "Comma-separated list of team numbers to emulate driver stations for")
+	battery := flag.Float64("battery", defaultConfig.BatteryVoltage, "Robot battery voltage while disabled")
+	batterySag := flag.Float64("battery-sag", 0, "Voltage by which the battery sags while the robot is enabled")
+	tripTime := flag.Int("trip-time", defaultConfig.TripTimeMs, "Driver station to robot trip time in milliseconds")
+	tripTimeJitter := flag.Int("trip-time-jitter", 0, "Maximum random milliseconds added to the trip time")
+	flapPeriod := flag.Duration("flap-period", 0, "How often the robot link drops out, or 0 to keep it up")
+	flapDuration := flag.Duration("flap-duration", 500*time.Millisecond, "How long the robot link drops out for")
+	teamsPerAlliance := flag.Int("teams-per-alliance", 3, "Number of teams per alliance at the event")
+	statusInterval := flag.Duration("status-interval", 5*time.Second, "How often to print the status, or 0 to never")
+	flag.Parse()
+
+	var configs []dssim.Config
+	for _, team := range strings.Split(*teams, ",") {
+		teamId, err := strconv.Atoi(strings.TrimSpace(team))
+		if err != nil || teamId <= 0 {
+			fmt.Fprintf(os.Stderr, "Invalid team number '%s'; -teams must be a comma-separated list.\n", team)
+			os.Exit(2)
+		}
+		configs = append(configs, dssim.Config{
+			TeamId:            teamId,
+			BatteryVoltage:    *battery,
+			BatterySagVoltage: *batterySag,
+			TripTimeMs:        *tripTime,
+			TripTimeJitterMs:  *tripTimeJitter,
+			LinkFlapPeriod:    *flapPeriod,
+			LinkFlapDuration:  *flapDuration,
+		})
+	}
+
+	sim := dssim.NewSimulator(*server, configs...)
+	sim.TeamsPerAlliance = *teamsPerAlliance
+	if err := sim.Start(); err != nil {
+		log.Fatalln(err)
+	}
+	log.Printf("Emulating %d driver stations against the FMS at %s.", len(configs), *server)
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	var statusTicks <-chan time.Time
+	if *statusInterval > 0 {
+		ticker := time.NewTicker(*statusInterval)
+		defer ticker.Stop()
+		statusTicks = ticker.C
+	}
+	for {
+		select {
+		case <-interrupt:
+			log.Println("Stopping driver stations.")
+			sim.Stop()
+			return
+		case <-statusTicks:
+			for _, status := range sim.Statuses() {
+				log.Println(formatStatus(status))
+			}
+		}
+	}
+}
+
+// Returns a one-line summary of the state of a simulated driver station.
+func formatStatus(status dssim.Status) string {
+	if !status.Connected {
+		return fmt.Sprintf("Team %d: not connected", status.TeamId)
+	}
+	mode := "disabled"
+	if status.Estop {
+		mode = "e-stopped"
+	} else if status.Enabled && status.Auto {
+		mode = "auto enabled"
+	} else if status.Enabled {
+		mode = "teleop enabled"
+	}
+	station := status.AllianceStation
+	if status.WrongStation {
+		station += " (wrong station)"
+	}
+	return fmt.Sprintf(
+		"Team %d: %s, robot linked %t, %.1f V, %d ms, %d missed packets, %s, %d s remaining, %d control packets",
+		status.TeamId, station, status.RobotLinked, status.BatteryVoltage, status.TripTimeMs, status.MissedPacketCount,
+		mode, status.SecondsRemaining, status.ControlPacketCount,
+	)
+}
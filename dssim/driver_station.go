@@ -0,0 +1,337 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Emulation of a single team's Driver Station, speaking the same protocol to the FMS as the real one.
+
+package dssim
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// Interval at which UDP status packets are sent to the FMS; TCP status packets are sent every statusPacketsPerTcp.
+	statusPeriodMs      = 100
+	statusPacketsPerTcp = 10
+	reconnectDelaySec   = 1
+	handshakeTimeoutSec = 2
+)
+
+// Behavior of a simulated driver station and its robot.
+type Config struct {
+	TeamId int
+	// Battery voltage reported while the robot is disabled, and how far it sags while the robot is enabled.
+	BatteryVoltage    float64
+	BatterySagVoltage float64
+	// Driver station to robot trip time, and the maximum random amount added to it on each status packet.
+	TripTimeMs       int
+	TripTimeJitterMs int
+	// How often the robot link drops out, and for how long; a zero period keeps the link up.
+	LinkFlapPeriod   time.Duration
+	LinkFlapDuration time.Duration
+}
+
+// Snapshot of the state of a simulated driver station.
+type Status struct {
+	TeamId    int
+	Connected bool
+	// Station assigned by the FMS, and whether the FMS reported the driver station as plugged into the wrong one. With
+	// four teams per alliance, the third and fourth stations of an alliance share a position in the protocol, so the
+	// FMS doesn't say which of the two a driver station in that position is in; the station is then reported as e.g.
+	// "R3/R4".
+	AllianceStation string
+	WrongStation    bool
+	RobotLinked     bool
+	BatteryVoltage  float64
+	TripTimeMs      int
+	// Number of status packets the robot has missed, which is counted while the link is dropped out.
+	MissedPacketCount int
+	// Contents of the last control packet received from the FMS, and the number received in total.
+	Auto               bool
+	Enabled            bool
+	Estop              bool
+	SecondsRemaining   int
+	ControlPacketCount int
+	GameData           string
+}
+
+type DriverStation struct {
+	Config
+	mutex           sync.Mutex
+	status          Status
+	stationPosition byte
+	connectedTime   time.Time
+	tcpConn         net.Conn
+	udpConn         net.Conn
+	packetCount     int
+	lastError       string
+}
+
+// Returns the configuration of a healthy robot with the given team number.
+func DefaultConfig(teamId int) Config {
+	return Config{TeamId: teamId, BatteryVoltage: 12.5, TripTimeMs: 5}
+}
+
+func newDriverStation(config Config) *DriverStation {
+	return &DriverStation{Config: config, status: Status{TeamId: config.TeamId}}
+}
+
+// Returns a snapshot of the current state of the driver station.
+func (ds *DriverStation) Status() Status {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	return ds.status
+}
+
+// Loops until the simulator is stopped, connecting to the FMS and retrying whenever the connection is lost or refused.
+func (ds *DriverStation) run(sim *Simulator) {
+	defer sim.waitGroup.Done()
+	for {
+		if err := ds.connect(sim); err != nil {
+			// Only log changes in the error, since the FMS refuses teams that aren't in the current match every time.
+			if err.Error() != ds.lastError {
+				log.Printf("Team %d: %v", ds.TeamId, err)
+				ds.lastError = err.Error()
+			}
+		} else {
+			ds.lastError = ""
+			ds.serve(sim)
+			ds.disconnect()
+		}
+
+		select {
+		case <-sim.stop:
+			return
+		case <-time.After(time.Second * reconnectDelaySec):
+		}
+	}
+}
+
+// Opens the connections to the FMS and performs the handshake in which the FMS assigns the alliance station.
+func (ds *DriverStation) connect(sim *Simulator) error {
+	tcpConn, err := net.DialTimeout(
+		"tcp4", net.JoinHostPort(sim.ServerAddress, strconv.Itoa(sim.ServerTcpPort)), time.Second*handshakeTimeoutSec,
+	)
+	if err != nil {
+		return err
+	}
+	initialPacket := [5]byte{0, 3, 24, byte(ds.TeamId >> 8), byte(ds.TeamId & 0xff)}
+	if _, err = tcpConn.Write(initialPacket[:]); err != nil {
+		tcpConn.Close()
+		return err
+	}
+
+	// The FMS closes the connection without replying if the team isn't in the current match.
+	var assignmentPacket [5]byte
+	tcpConn.SetReadDeadline(time.Now().Add(time.Second * handshakeTimeoutSec))
+	if _, err = io.ReadFull(tcpConn, assignmentPacket[:]); err != nil {
+		tcpConn.Close()
+		return fmt.Errorf("Connection refused by FMS; is the team in the current match?")
+	}
+	tcpConn.SetReadDeadline(time.Time{})
+	if assignmentPacket[2] != 25 {
+		tcpConn.Close()
+		return fmt.Errorf("Invalid station assignment packet received: %v", assignmentPacket)
+	}
+
+	udpConn, err := net.Dial("udp4", net.JoinHostPort(sim.ServerAddress, strconv.Itoa(sim.ServerUdpPort)))
+	if err != nil {
+		tcpConn.Close()
+		return err
+	}
+
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	ds.tcpConn = tcpConn
+	ds.udpConn = udpConn
+	ds.connectedTime = time.Now()
+	ds.stationPosition = assignmentPacket[3]
+	ds.status.Connected = true
+	ds.status.AllianceStation = stationName(assignmentPacket[3], sim.TeamsPerAlliance)
+	ds.status.WrongStation = assignmentPacket[4] != 0
+	log.Printf("Team %d: Connected in station %s.", ds.TeamId, ds.status.AllianceStation)
+	return nil
+}
+
+// Sends status packets to the FMS and consumes the packets it sends over TCP, until the connection is lost or the
+// simulator is stopped.
+func (ds *DriverStation) serve(sim *Simulator) {
+	tcpClosed := make(chan struct{})
+	go ds.readTcpPackets(tcpClosed)
+
+	ticker := time.NewTicker(time.Millisecond * statusPeriodMs)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-sim.stop:
+			return
+		case <-tcpClosed:
+			log.Printf("Team %d: Connection to FMS lost.", ds.TeamId)
+			return
+		case <-ticker.C:
+			if err := ds.sendStatusPackets(); err != nil {
+				log.Printf("Team %d: Error sending status: %v", ds.TeamId, err)
+				return
+			}
+		}
+	}
+}
+
+func (ds *DriverStation) disconnect() {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	ds.tcpConn.Close()
+	ds.udpConn.Close()
+	ds.status.Connected = false
+	ds.status.AllianceStation = ""
+	ds.status.WrongStation = false
+	ds.status.RobotLinked = false
+}
+
+// Reads packets sent by the FMS over TCP until the connection is closed, keeping any game data that is sent.
+func (ds *DriverStation) readTcpPackets(tcpClosed chan struct{}) {
+	defer close(tcpClosed)
+	var header [2]byte
+	for {
+		if _, err := io.ReadFull(ds.tcpConn, header[:]); err != nil {
+			return
+		}
+		packet := make([]byte, int(header[0])<<8+int(header[1]))
+		if _, err := io.ReadFull(ds.tcpConn, packet); err != nil {
+			return
+		}
+		if len(packet) >= 2 && packet[0] == 28 {
+			// Game data packet.
+			dataSize := min(int(packet[1]), len(packet)-2)
+			ds.mutex.Lock()
+			ds.status.GameData = string(packet[2 : 2+dataSize])
+			ds.mutex.Unlock()
+		}
+	}
+}
+
+// Updates the simulated robot state and sends it to the FMS over UDP, and periodically over TCP.
+func (ds *DriverStation) sendStatusPackets() error {
+	ds.mutex.Lock()
+	ds.updateRobot()
+	udpPacket := ds.encodeUdpStatusPacket()
+	var tcpPacket []byte
+	if ds.packetCount%statusPacketsPerTcp == 0 {
+		tcpPacket = ds.encodeTcpStatusPacket()
+	}
+	ds.packetCount++
+	ds.mutex.Unlock()
+
+	if _, err := ds.udpConn.Write(udpPacket[:]); err != nil {
+		return err
+	}
+	if tcpPacket != nil {
+		if _, err := ds.tcpConn.Write(tcpPacket); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Works out the robot link, battery voltage and trip time for the next status packet. Must be called with the mutex
+// held.
+func (ds *DriverStation) updateRobot() {
+	ds.status.RobotLinked = true
+	if ds.LinkFlapPeriod > 0 {
+		elapsed := time.Since(ds.connectedTime) % ds.LinkFlapPeriod
+		ds.status.RobotLinked = elapsed < ds.LinkFlapPeriod-ds.LinkFlapDuration
+	}
+	if !ds.status.RobotLinked {
+		ds.status.MissedPacketCount++
+	}
+
+	ds.status.BatteryVoltage = ds.BatteryVoltage
+	if ds.status.Enabled {
+		ds.status.BatteryVoltage -= ds.BatterySagVoltage
+	}
+	ds.status.TripTimeMs = ds.TripTimeMs
+	if ds.TripTimeJitterMs > 0 {
+		ds.status.TripTimeMs += rand.Intn(ds.TripTimeJitterMs + 1)
+	}
+}
+
+// Serializes the driver station and robot status into the packet sent to the FMS over UDP.
+func (ds *DriverStation) encodeUdpStatusPacket() [8]byte {
+	var packet [8]byte
+
+	// Packet number, stored big-endian in two bytes.
+	packet[0] = byte((ds.packetCount >> 8) & 0xff)
+	packet[1] = byte(ds.packetCount & 0xff)
+
+	// Protocol version.
+	packet[2] = 0
+
+	// Status byte, which always reports the radio as linked.
+	packet[3] = 0x10
+	if ds.status.RobotLinked {
+		packet[3] |= 0x20
+		if ds.status.Auto {
+			packet[3] |= 0x02
+		}
+		if ds.status.Enabled {
+			packet[3] |= 0x04
+		}
+		if ds.status.Estop {
+			packet[3] |= 0x80
+		}
+	}
+
+	// Team number, stored big-endian in two bytes.
+	packet[4] = byte(ds.TeamId >> 8)
+	packet[5] = byte(ds.TeamId & 0xff)
+
+	// Battery voltage, stored as volts * 256.
+	if ds.status.RobotLinked {
+		voltage := max(ds.status.BatteryVoltage, 0)
+		packet[6] = byte(voltage)
+		packet[7] = byte((voltage - float64(int(voltage))) * 256)
+	}
+
+	return packet
+}
+
+// Serializes the trip time and missed packet count into the robot status packet sent to the FMS over TCP.
+func (ds *DriverStation) encodeTcpStatusPacket() []byte {
+	packet := make([]byte, 38)
+	packet[0] = 0  // Packet size
+	packet[1] = 36 // Packet size
+	packet[2] = 22 // Packet type
+	packet[3] = byte(min(ds.status.TripTimeMs*2, 255))
+	packet[4] = byte(ds.status.MissedPacketCount & 0xff)
+	return packet
+}
+
+// Updates the driver station with the contents of a control packet received from the FMS.
+func (ds *DriverStation) handleControlPacket(packet []byte) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	ds.status.Auto = packet[3]&0x02 != 0
+	ds.status.Enabled = packet[3]&0x04 != 0
+	ds.status.Estop = packet[3]&0x80 != 0
+	ds.status.SecondsRemaining = int(packet[20])<<8 + int(packet[21])
+	ds.status.ControlPacketCount++
+}
+
+// Returns the name of the alliance station with the given position in the driver station protocol, or of both
+// stations if it is the position shared by the third and fourth stations at an event with four teams per alliance.
+func stationName(position byte, teamsPerAlliance int) string {
+	alliance, station := "R", int(position)+1
+	if position >= 3 {
+		alliance, station = "B", int(position)-2
+	}
+	if station == 3 && teamsPerAlliance > 3 {
+		return fmt.Sprintf("%s3/%s4", alliance, alliance)
+	}
+	return fmt.Sprintf("%s%d", alliance, station)
+}
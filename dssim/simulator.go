@@ -0,0 +1,117 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Simulator running any number of emulated driver stations against an FMS, for testing the field without robots.
+
+package dssim
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"sync"
+)
+
+// Ports used by the driver station protocol.
+const (
+	fmsTcpPort       = 1750
+	fmsUdpPort       = 1160
+	dsControlUdpPort = 1121
+	controlPacketLen = 22
+)
+
+type Simulator struct {
+	ServerAddress string
+	ServerTcpPort int
+	ServerUdpPort int
+	// Port on which control packets from the FMS are received, shared by all the driver stations since the FMS sends
+	// them to the same port on each; zero picks any free port.
+	ControlUdpPort int
+	// Number of teams per alliance at the event, which determines whether the third station position is shared with
+	// the fourth station.
+	TeamsPerAlliance int
+	DriverStations   []*DriverStation
+	controlConn      *net.UDPConn
+	stop             chan struct{}
+	waitGroup        sync.WaitGroup
+}
+
+// Creates a simulator with a driver station for each of the given configurations, which will connect to the FMS at
+// the given address on the standard ports.
+func NewSimulator(serverAddress string, configs ...Config) *Simulator {
+	sim := &Simulator{
+		ServerAddress:    serverAddress,
+		ServerTcpPort:    fmsTcpPort,
+		ServerUdpPort:    fmsUdpPort,
+		ControlUdpPort:   dsControlUdpPort,
+		TeamsPerAlliance: 3,
+	}
+	for _, config := range configs {
+		sim.DriverStations = append(sim.DriverStations, newDriverStation(config))
+	}
+	return sim
+}
+
+// Starts listening for control packets and connecting each driver station to the FMS, in separate goroutines.
+func (sim *Simulator) Start() error {
+	controlConn, err := net.ListenUDP("udp4", &net.UDPAddr{Port: sim.ControlUdpPort})
+	if err != nil {
+		return fmt.Errorf("Error opening driver station control UDP socket: %v", err)
+	}
+	sim.controlConn = controlConn
+	sim.ControlUdpPort = controlConn.LocalAddr().(*net.UDPAddr).Port
+	sim.stop = make(chan struct{})
+
+	go sim.listenForControlPackets()
+	for _, ds := range sim.DriverStations {
+		sim.waitGroup.Add(1)
+		go ds.run(sim)
+	}
+	return nil
+}
+
+// Disconnects all the driver stations and waits for them to finish.
+func (sim *Simulator) Stop() {
+	close(sim.stop)
+	sim.controlConn.Close()
+	sim.waitGroup.Wait()
+}
+
+// Returns a snapshot of the state of each driver station, in the order in which they were configured.
+func (sim *Simulator) Statuses() []Status {
+	statuses := make([]Status, len(sim.DriverStations))
+	for i, ds := range sim.DriverStations {
+		statuses[i] = ds.Status()
+	}
+	return statuses
+}
+
+// Loops until the simulator is stopped, passing each control packet on to the driver stations in the station it is
+// addressed to.
+func (sim *Simulator) listenForControlPackets() {
+	var packet [64]byte
+	for {
+		length, err := sim.controlConn.Read(packet[:])
+		if err != nil {
+			select {
+			case <-sim.stop:
+			default:
+				log.Printf("Error reading control packet: %v", err)
+			}
+			return
+		}
+		if length < controlPacketLen {
+			continue
+		}
+
+		// The FMS addresses the packet to a station rather than a team, and the fourth station on each alliance shares
+		// its position with the third, so the packet goes to every connected driver station in that position.
+		for _, ds := range sim.DriverStations {
+			ds.mutex.Lock()
+			inStation := ds.status.Connected && ds.stationPosition == packet[5]
+			ds.mutex.Unlock()
+			if inStation {
+				ds.handleControlPacket(packet[:length])
+			}
+		}
+	}
+}
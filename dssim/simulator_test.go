@@ -0,0 +1,189 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package dssim
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestSimulator(t *testing.T) {
+	tcpListener, err := net.Listen("tcp4", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer tcpListener.Close()
+	udpListener, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	assert.Nil(t, err)
+	defer udpListener.Close()
+
+	config := Config{TeamId: 254, BatteryVoltage: 12.5, BatterySagVoltage: 1, TripTimeMs: 7}
+	sim := NewSimulator("127.0.0.1", config)
+	sim.ServerTcpPort = tcpListener.Addr().(*net.TCPAddr).Port
+	sim.ServerUdpPort = udpListener.LocalAddr().(*net.UDPAddr).Port
+	sim.ControlUdpPort = 0
+	assert.Nil(t, sim.Start())
+	defer sim.Stop()
+
+	// Check the handshake, assigning the driver station to B2.
+	tcpListener.(*net.TCPListener).SetDeadline(time.Now().Add(time.Second))
+	tcpConn, err := tcpListener.Accept()
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer tcpConn.Close()
+	var initialPacket [5]byte
+	_, err = io.ReadFull(tcpConn, initialPacket[:])
+	assert.Nil(t, err)
+	assert.Equal(t, [5]byte{0, 3, 24, 0, 254}, initialPacket)
+	tcpConn.Write([]byte{0, 3, 25, 4, 0})
+
+	// Check the UDP status packets.
+	udpListener.SetReadDeadline(time.Now().Add(time.Second))
+	var udpPacket [50]byte
+	_, err = udpListener.Read(udpPacket[:])
+	assert.Nil(t, err)
+	assert.Equal(t, byte(0x30), udpPacket[3])
+	assert.Equal(t, 254, int(udpPacket[4])<<8+int(udpPacket[5]))
+	assert.Equal(t, 12.5, float64(udpPacket[6])+float64(udpPacket[7])/256)
+
+	// Check the TCP status packet.
+	var tcpPacket [38]byte
+	tcpConn.SetReadDeadline(time.Now().Add(time.Second * 2))
+	_, err = io.ReadFull(tcpConn, tcpPacket[:])
+	assert.Nil(t, err)
+	assert.Equal(t, byte(22), tcpPacket[2])
+	assert.Equal(t, 7, int(tcpPacket[3])/2)
+
+	status := sim.Statuses()[0]
+	assert.True(t, status.Connected)
+	assert.Equal(t, "B2", status.AllianceStation)
+	assert.False(t, status.WrongStation)
+
+	// Check that control packets and game data from the FMS are consumed.
+	controlConn, err := net.Dial("udp4", fmt.Sprintf("127.0.0.1:%d", sim.ControlUdpPort))
+	assert.Nil(t, err)
+	defer controlConn.Close()
+	var controlPacket [22]byte
+	controlPacket[3] = 0x04
+	controlPacket[5] = 4
+	controlPacket[21] = 135
+	controlConn.Write(controlPacket[:])
+	tcpConn.Write([]byte{0, 3, 28, 1, 'L'})
+	assert.Eventually(t, func() bool {
+		status := sim.Statuses()[0]
+		return status.ControlPacketCount == 1 && status.GameData == "L"
+	}, time.Second, 10*time.Millisecond)
+	status = sim.Statuses()[0]
+	assert.True(t, status.Enabled)
+	assert.False(t, status.Auto)
+	assert.Equal(t, 135, status.SecondsRemaining)
+	assert.Eventually(t, func() bool {
+		return sim.Statuses()[0].BatteryVoltage == 11.5
+	}, time.Second, 10*time.Millisecond)
+
+	// Check that a control packet for another station is ignored.
+	controlPacket[5] = 1
+	controlConn.Write(controlPacket[:])
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 1, sim.Statuses()[0].ControlPacketCount)
+
+	// Check that the driver station notices the connection being dropped.
+	tcpConn.Close()
+	assert.Eventually(t, func() bool {
+		return !sim.Statuses()[0].Connected
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSimulatorFourTeamAlliance(t *testing.T) {
+	tcpListener, err := net.Listen("tcp4", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer tcpListener.Close()
+	udpListener, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	assert.Nil(t, err)
+	defer udpListener.Close()
+
+	sim := NewSimulator("127.0.0.1", DefaultConfig(254), DefaultConfig(1114), DefaultConfig(2056))
+	sim.ServerTcpPort = tcpListener.Addr().(*net.TCPAddr).Port
+	sim.ServerUdpPort = udpListener.LocalAddr().(*net.UDPAddr).Port
+	sim.ControlUdpPort = 0
+	sim.TeamsPerAlliance = 4
+	assert.Nil(t, sim.Start())
+	defer sim.Stop()
+
+	// Assign the teams to R2, R3 and R4, the last two of which share a position.
+	positions := map[int]byte{254: 1, 1114: 2, 2056: 2}
+	for range positions {
+		tcpListener.(*net.TCPListener).SetDeadline(time.Now().Add(time.Second))
+		tcpConn, err := tcpListener.Accept()
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer tcpConn.Close()
+		var initialPacket [5]byte
+		_, err = io.ReadFull(tcpConn, initialPacket[:])
+		assert.Nil(t, err)
+		teamId := int(initialPacket[3])<<8 + int(initialPacket[4])
+		tcpConn.Write([]byte{0, 3, 25, positions[teamId], 0})
+	}
+	assert.Eventually(t, func() bool {
+		for _, status := range sim.Statuses() {
+			if !status.Connected {
+				return false
+			}
+		}
+		return true
+	}, time.Second, 10*time.Millisecond)
+	statuses := sim.Statuses()
+	assert.Equal(t, "R2", statuses[0].AllianceStation)
+	assert.Equal(t, "R3/R4", statuses[1].AllianceStation)
+	assert.Equal(t, "R3/R4", statuses[2].AllianceStation)
+
+	// Check that a control packet for the shared position reaches both driver stations in it, and only those.
+	controlConn, err := net.Dial("udp4", fmt.Sprintf("127.0.0.1:%d", sim.ControlUdpPort))
+	assert.Nil(t, err)
+	defer controlConn.Close()
+	var controlPacket [22]byte
+	controlPacket[5] = 2
+	controlConn.Write(controlPacket[:])
+	assert.Eventually(t, func() bool {
+		statuses := sim.Statuses()
+		return statuses[1].ControlPacketCount == 1 && statuses[2].ControlPacketCount == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 0, sim.Statuses()[0].ControlPacketCount)
+}
+
+func TestSimulatorLinkFlapping(t *testing.T) {
+	ds := newDriverStation(
+		Config{TeamId: 1987, BatteryVoltage: 12, LinkFlapPeriod: time.Second, LinkFlapDuration: 300 * time.Millisecond},
+	)
+	ds.connectedTime = time.Now().Add(-500 * time.Millisecond)
+	ds.updateRobot()
+	assert.True(t, ds.status.RobotLinked)
+	assert.Equal(t, 0, ds.status.MissedPacketCount)
+	packet := ds.encodeUdpStatusPacket()
+	assert.Equal(t, byte(0x30), packet[3])
+	assert.Equal(t, byte(12), packet[6])
+
+	ds.connectedTime = time.Now().Add(-1800 * time.Millisecond)
+	ds.updateRobot()
+	assert.False(t, ds.status.RobotLinked)
+	assert.Equal(t, 1, ds.status.MissedPacketCount)
+	packet = ds.encodeUdpStatusPacket()
+	assert.Equal(t, byte(0x10), packet[3])
+	assert.Equal(t, byte(0), packet[6])
+	assert.Equal(t, byte(1), ds.encodeTcpStatusPacket()[4])
+}
+
+func TestStationName(t *testing.T) {
+	assert.Equal(t, "R1", stationName(0, 3))
+	assert.Equal(t, "R3", stationName(2, 3))
+	assert.Equal(t, "B1", stationName(3, 3))
+	assert.Equal(t, "B3", stationName(5, 3))
+
+	assert.Equal(t, "R2", stationName(1, 4))
+	assert.Equal(t, "R3/R4", stationName(2, 4))
+	assert.Equal(t, "B3/B4", stationName(5, 4))
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/FRCTeam1987/crimson-arena/partner"
 	"github.com/FRCTeam1987/crimson-arena/plc"
 	"log"
+	"reflect"
+	"sync"
 	"time"
 )
 
@@ -29,6 +31,10 @@ const (
 	MaxMatchGapMin           = 20
 )
 
+// Guards the game package's globals holding the game definition and event-wide match timing, which are shared by the
+// arenas of all the fields.
+var gameSettingsMutex sync.Mutex
+
 // Progression of match states.
 type MatchState int
 
@@ -42,6 +48,7 @@ const (
 	PostMatch
 	TimeoutActive
 	PostTimeout
+	FieldFault
 )
 
 type Arena struct {
@@ -58,13 +65,25 @@ type Arena struct {
 	AllianceStations map[string]*AllianceStation
 	Displays         map[string]*Display
 	ArenaNotifiers
+	ScoringPanelRegistry
 	MatchState
-	lastMatchState             MatchState
+	lastMatchState   MatchState
+	matchPeriodIndex int
+	// Timing of the current match, which is frozen when the match starts.
+	MatchTiming                game.Timing
+	matchSounds                []*game.MatchSound
+	gameDataPeriodIndex        int
+	gameDataSent               bool
 	CurrentMatch               *model.Match
 	MatchStartTime             time.Time
 	LastMatchTimeSec           float64
 	RedScore                   *game.Score
 	BlueScore                  *game.Score
+	RedCards                   map[string]string
+	BlueCards                  map[string]string
+	HeadRefApproval            *HeadRefApproval
+	HeadRefReturnReason        string
+	ScoreTimeline              []game.ScoreTimelineSample
 	lastDsPacketTime           time.Time
 	lastPeriodicTaskTime       time.Time
 	EventStatus                EventStatus
@@ -74,6 +93,7 @@ type Arena struct {
 	SavedMatch                 *model.Match
 	SavedMatchResult           *model.MatchResult
 	SavedRankings              game.Rankings
+	SavedTeamContributions     map[int]game.TeamContribution
 	AllianceStationDisplayMode string
 	AllianceSelectionAlliances []model.Alliance
 	PlayoffBracket             *bracket.Bracket
@@ -82,6 +102,31 @@ type Arena struct {
 	MuteMatchSounds            bool
 	matchAborted               bool
 	soundsPlayed               map[*game.MatchSound]struct{}
+	// Play number that the current match's result will be given once committed, worked out when the match is loaded so
+	// that recording each score change doesn't need to look it up.
+	PendingPlayNumber int
+	// State journaled before the last shutdown that is awaiting a decision on whether to restore it.
+	RecoveredJournal *model.ArenaJournal
+	lastJournalTime  time.Time
+	lastJournalJson  []byte
+	// Match time at which the clock was frozen for a field fault, the state to return to when resuming, and the time
+	// at which the resumption countdown ends (zero until a resumption is requested).
+	fieldFaultMatchTimeSec     float64
+	fieldFaultPriorState       MatchState
+	fieldFaultResumeTime       time.Time
+	fieldFaultLastCountdownSec int
+	// Field run by this arena when the server runs more than one, or nil if it runs only a single field.
+	Field *model.Field
+	// Number of teams on each alliance, which is taken from the event settings whenever a match is loaded.
+	TeamsPerAlliance int
+	// IDs of the readiness rules that the FTA has overridden for the current match.
+	readinessOverrides map[string]bool
+	// Driver station connection problems raised during the current match, and the ones that are still ongoing, keyed
+	// by station and alert type.
+	DsAlerts       []DriverStationAlert
+	dsAlertsActive map[string]bool
+	// Guards the connection history of the alliance stations, which is read from outside the arena loop.
+	dsHistoryMutex sync.Mutex
 }
 
 type AllianceStation struct {
@@ -91,34 +136,63 @@ type AllianceStation struct {
 	Estop    bool
 	Bypass   bool
 	Team     *model.Team
+	// Recent connection status samples, oldest first, which are kept out of the arena status messages for brevity.
+	dsHistory []DriverStationSample
 }
 
 // Creates the arena and sets it to its initial state.
 func NewArena(dbPath string) (*Arena, error) {
-	arena := new(Arena)
-	arena.configureNotifiers()
+	database, err := model.OpenDatabase(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	return newArena(database, nil)
+}
 
-	var err error
-	arena.Database, err = model.OpenDatabase(dbPath)
+// Opens the database at the given path and creates an arena sharing it for each of the fields configured in it, or a
+// single arena if there are none.
+func NewArenas(dbPath string) ([]*Arena, error) {
+	database, err := model.OpenDatabase(dbPath)
 	if err != nil {
 		return nil, err
 	}
-	err = arena.LoadSettings()
+	fields, err := database.GetAllFields()
 	if err != nil {
 		return nil, err
 	}
+	if len(fields) == 0 {
+		arena, err := newArena(database, nil)
+		if err != nil {
+			return nil, err
+		}
+		return []*Arena{arena}, nil
+	}
 
-	arena.AllianceStations = make(map[string]*AllianceStation)
-	arena.AllianceStations["R1"] = new(AllianceStation)
-	arena.AllianceStations["R2"] = new(AllianceStation)
-	arena.AllianceStations["R3"] = new(AllianceStation)
-	arena.AllianceStations["B1"] = new(AllianceStation)
-	arena.AllianceStations["B2"] = new(AllianceStation)
-	arena.AllianceStations["B3"] = new(AllianceStation)
+	arenas := make([]*Arena, len(fields))
+	for i := range fields {
+		if arenas[i], err = newArena(database, &fields[i]); err != nil {
+			return nil, err
+		}
+	}
+	return arenas, nil
+}
+
+func newArena(database *model.Database, field *model.Field) (*Arena, error) {
+	arena := new(Arena)
+	arena.configureNotifiers()
+	arena.Database = database
+	arena.Field = field
+
+	err := arena.LoadSettings()
+	if err != nil {
+		return nil, err
+	}
 
 	arena.Displays = make(map[string]*Display)
 
-	// Load empty match as current.
+	arena.ScoringPanelRegistry.initialize()
+
+	// Load empty match as current, which also sets up the alliance stations.
 	arena.MatchState = PreMatch
 	arena.LoadTestMatch()
 	arena.LastMatchTimeSec = 0
@@ -136,6 +210,11 @@ func NewArena(dbPath string) (*Arena, error) {
 	// Initialize SCC information
 	arena.Scc = NewSCC(arena)
 
+	// Hold on to any state that was journaled when the arena last stopped so that it can be offered for restoring.
+	if err = arena.loadRecoveredJournal(); err != nil {
+		return nil, err
+	}
+
 	return arena, nil
 }
 
@@ -148,13 +227,26 @@ func (arena *Arena) LoadSettings() error {
 	arena.EventSettings = settings
 
 	// Initialize the components that depend on settings.
-	arena.accessPoint.SetSettings(settings.ApAddress, settings.ApUsername, settings.ApPassword,
-		settings.ApTeamChannel, settings.ApAdminChannel, settings.ApAdminWpaKey, settings.NetworkSecurityEnabled)
-	arena.accessPoint2.SetSettings(settings.Ap2Address, settings.Ap2Username, settings.Ap2Password,
-		settings.Ap2TeamChannel, 0, "", settings.NetworkSecurityEnabled)
-	arena.networkSwitch = network.NewSwitch(settings.SwitchAddress, settings.SwitchPassword)
+	if arena.Field != nil {
+		// Each field has its own network and PLC, and a second access point isn't supported.
+		arena.accessPoint.SetSettings(arena.Field.ApAddress, arena.Field.ApUsername, arena.Field.ApPassword,
+			arena.Field.ApTeamChannel, settings.ApAdminChannel, settings.ApAdminWpaKey, settings.NetworkSecurityEnabled)
+		arena.accessPoint2.SetSettings("", "", "", 0, 0, "", settings.NetworkSecurityEnabled)
+		arena.networkSwitch = network.NewSwitch(
+			arena.Field.SwitchAddress, arena.Field.SwitchPassword, arena.ServerIpAddress(),
+		)
+		arena.Plc.SetAddress(arena.Field.PlcAddress)
+	} else {
+		arena.accessPoint.SetSettings(settings.ApAddress, settings.ApUsername, settings.ApPassword,
+			settings.ApTeamChannel, settings.ApAdminChannel, settings.ApAdminWpaKey, settings.NetworkSecurityEnabled)
+		arena.accessPoint2.SetSettings(settings.Ap2Address, settings.Ap2Username, settings.Ap2Password,
+			settings.Ap2TeamChannel, 0, "", settings.NetworkSecurityEnabled)
+		arena.networkSwitch = network.NewSwitch(
+			settings.SwitchAddress, settings.SwitchPassword, arena.ServerIpAddress(),
+		)
+		arena.Plc.SetAddress(settings.PlcAddress)
+	}
 	arena.dnsMasq = network.NewDnsMasq()
-	arena.Plc.SetAddress(settings.PlcAddress)
 	arena.TbaClient = partner.NewTbaClient(settings.TbaEventCode, settings.TbaSecretId, settings.TbaSecret)
 
 	if arena.EventSettings.NetworkSecurityEnabled && arena.MatchState == PreMatch {
@@ -166,13 +258,21 @@ func (arena *Arena) LoadSettings() error {
 		}
 	}
 
-	game.MatchTiming.WarmupDurationSec = settings.WarmupDurationSec
-	game.MatchTiming.AutoDurationSec = settings.AutoDurationSec
-	game.MatchTiming.PauseDurationSec = settings.PauseDurationSec
-	game.MatchTiming.TeleopDurationSec = settings.TeleopDurationSec
-	game.MatchTiming.WarningRemainingDurationSec = settings.WarningRemainingDurationSec
-	game.UpdateMatchSounds()
-	arena.MatchTimingNotifier.Notify()
+	if err = loadGameSettings(settings); err != nil {
+		return err
+	}
+	arena.ScoringPanelRegistry.setNumRequiredPanels(settings.ScoringPanelsPerAlliance)
+	if arena.CurrentMatch != nil && arena.MatchState == PreMatch {
+		if err = arena.updateMatchTiming(); err != nil {
+			return err
+		}
+		if settings.TeamsPerAlliance != arena.TeamsPerAlliance {
+			// Reload the match to pick up the new set of alliance stations.
+			if err = arena.LoadMatch(arena.CurrentMatch); err != nil {
+				return err
+			}
+		}
+	}
 
 	// Reconstruct the playoff bracket in memory.
 	if err = arena.CreatePlayoffBracket(); err != nil {
@@ -185,6 +285,55 @@ func (arena *Arena) LoadSettings() error {
 	return nil
 }
 
+// Loads the game definition and event-wide match timing from the given settings into the game package's globals. All
+// fields share the same event settings, so these are the same for every arena; they are only written if they have
+// changed, so that arenas reloading their settings don't overwrite each other while a match is being run.
+func loadGameSettings(settings *model.EventSettings) error {
+	gameDefinition, err := settings.LoadGameDefinition()
+	if err != nil {
+		return err
+	}
+
+	gameSettingsMutex.Lock()
+	defer gameSettingsMutex.Unlock()
+	timing := game.MatchTiming
+	timing.WarmupDurationSec = settings.WarmupDurationSec
+	timing.AutoDurationSec = settings.AutoDurationSec
+	timing.PauseDurationSec = settings.PauseDurationSec
+	timing.TeleopDurationSec = settings.TeleopDurationSec
+	timing.WarningRemainingDurationSec = settings.WarningRemainingDurationSec
+	timing.UpdatePeriods(gameDefinition)
+	if reflect.DeepEqual(gameDefinition, game.CurrentGame) && reflect.DeepEqual(timing, game.MatchTiming) {
+		return nil
+	}
+	game.CurrentGame = gameDefinition
+	game.MatchTiming = timing
+	game.UpdateMatchSounds()
+	return nil
+}
+
+// Returns the ID of the field run by this arena, or 0 if the server runs only a single field.
+func (arena *Arena) FieldId() int {
+	if arena.Field == nil {
+		return 0
+	}
+	return arena.Field.Id
+}
+
+// Returns the address of this server on the arena's field network, to which driver stations connect.
+func (arena *Arena) ServerIpAddress() string {
+	if arena.Field == nil || arena.Field.ServerIpAddress == "" {
+		return network.ServerIpAddress
+	}
+	return arena.Field.ServerIpAddress
+}
+
+// Returns true if the blue alliance's robots connect to a second access point rather than the same one as the red
+// alliance's.
+func (arena *Arena) usesSecondAccessPoint() bool {
+	return arena.Field == nil && arena.EventSettings.Ap2TeamChannel != 0
+}
+
 // Constructs an empty playoff bracket in memory, based only on the number of alliances.
 func (arena *Arena) CreatePlayoffBracket() error {
 	var err error
@@ -207,7 +356,33 @@ func (arena *Arena) UpdatePlayoffBracket(startTime *time.Time) error {
 		return err
 	}
 	if len(alliances) > 0 {
-		return arena.PlayoffBracket.Update(arena.Database, startTime)
+		if err = arena.PlayoffBracket.Update(arena.Database, startTime); err != nil {
+			return err
+		}
+		return arena.assignPlayoffMatchFields()
+	}
+	return nil
+}
+
+// Puts any playoff matches that the bracket has created without a field onto one, alternating between the fields in
+// the order in which the matches are to be played the same way as the qualification schedule. Leaves the matches
+// unassigned if there is only a single field.
+func (arena *Arena) assignPlayoffMatchFields() error {
+	fields, err := arena.Database.GetAllFields()
+	if err != nil || len(fields) < 2 {
+		return err
+	}
+	matches, err := arena.Database.GetMatchesByType("elimination")
+	if err != nil {
+		return err
+	}
+	for i, match := range matches {
+		if match.FieldId == 0 {
+			match.FieldId = fields[i%len(fields)].Id
+			if err = arena.Database.UpdateMatch(&match); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -219,46 +394,52 @@ func (arena *Arena) LoadMatch(match *model.Match) error {
 	}
 
 	arena.CurrentMatch = match
-	err := arena.assignTeam(match.Red1, "R1")
-	if err != nil {
-		return err
-	}
-	err = arena.assignTeam(match.Red2, "R2")
-	if err != nil {
-		return err
-	}
-	err = arena.assignTeam(match.Red3, "R3")
-	if err != nil {
-		return err
-	}
-	err = arena.assignTeam(match.Blue1, "B1")
-	if err != nil {
-		return err
+	arena.PendingPlayNumber = 1
+	if match.Type != "test" {
+		previousMatchResult, err := arena.Database.GetMatchResultForMatch(match.Id)
+		if err != nil {
+			return err
+		}
+		if previousMatchResult != nil {
+			arena.PendingPlayNumber = previousMatchResult.PlayNumber + 1
+		}
 	}
-	err = arena.assignTeam(match.Blue2, "B2")
-	if err != nil {
-		return err
+	arena.readinessOverrides = make(map[string]bool)
+	arena.DsAlerts = nil
+	arena.dsAlertsActive = make(map[string]bool)
+	arena.setupAllianceStations()
+	for _, station := range model.AllianceStationNames(arena.TeamsPerAlliance) {
+		if err := arena.assignTeam(match.TeamIdForStation(station), station); err != nil {
+			return err
+		}
 	}
-	err = arena.assignTeam(match.Blue3, "B3")
-	if err != nil {
+
+	arena.setupNetwork(arena.getStationTeams())
+
+	if err := arena.updateMatchTiming(); err != nil {
 		return err
 	}
 
-	arena.setupNetwork([6]*model.Team{arena.AllianceStations["R1"].Team, arena.AllianceStations["R2"].Team,
-		arena.AllianceStations["R3"].Team, arena.AllianceStations["B1"].Team, arena.AllianceStations["B2"].Team,
-		arena.AllianceStations["B3"].Team})
-
 	// Reset the arena state and game scores.
 	arena.soundsPlayed = make(map[*game.MatchSound]struct{})
+	arena.gameDataSent = false
 	arena.RedScore = new(game.Score)
 	arena.BlueScore = new(game.Score)
+	arena.RedCards = make(map[string]string)
+	arena.BlueCards = make(map[string]string)
+	arena.ScoreTimeline = nil
 	arena.FieldVolunteers = false
 	arena.FieldReset = false
+	arena.ScoringPanelRegistry.resetScoreCommitted()
+	arena.resetHeadRefApproval()
 	arena.Plc.ResetMatch()
 
 	// Notify any listeners about the new match.
 	arena.MatchLoadNotifier.Notify()
 	arena.RealtimeScoreNotifier.Notify()
+	arena.ScoringStatusNotifier.Notify()
+	arena.HeadRefStatusNotifier.Notify()
+	arena.DsAlertsNotifier.Notify()
 	arena.AllianceStationDisplayMode = "match"
 	arena.AllianceStationDisplayModeNotifier.Notify()
 
@@ -270,7 +451,7 @@ func (arena *Arena) LoadTestMatch() error {
 	return arena.LoadMatch(&model.Match{Type: "test", DisplayName: "Test Match"})
 }
 
-// Loads the first unplayed match of the current match type.
+// Loads the first unplayed match or pending replay of the current match type.
 func (arena *Arena) LoadNextMatch() error {
 	nextMatch, err := arena.getNextMatch(false)
 	if err != nil {
@@ -291,23 +472,8 @@ func (arena *Arena) SubstituteTeam(teamId int, station string) error {
 	if err != nil {
 		return err
 	}
-	switch station {
-	case "R1":
-		arena.CurrentMatch.Red1 = teamId
-	case "R2":
-		arena.CurrentMatch.Red2 = teamId
-	case "R3":
-		arena.CurrentMatch.Red3 = teamId
-	case "B1":
-		arena.CurrentMatch.Blue1 = teamId
-	case "B2":
-		arena.CurrentMatch.Blue2 = teamId
-	case "B3":
-		arena.CurrentMatch.Blue3 = teamId
-	}
-	arena.setupNetwork([6]*model.Team{arena.AllianceStations["R1"].Team, arena.AllianceStations["R2"].Team,
-		arena.AllianceStations["R3"].Team, arena.AllianceStations["B1"].Team, arena.AllianceStations["B2"].Team,
-		arena.AllianceStations["B3"].Team})
+	arena.CurrentMatch.SetTeamIdForStation(station, teamId)
+	arena.setupNetwork(arena.getStationTeams())
 	arena.MatchLoadNotifier.Notify()
 
 	if arena.CurrentMatch.Type != "test" {
@@ -322,11 +488,20 @@ func (arena *Arena) StartMatch() error {
 	if err == nil {
 		// Save the match start time and game-specifc data to the database for posterity.
 		arena.CurrentMatch.StartedAt = time.Now()
+		arena.CurrentMatch.RedGameData, arena.CurrentMatch.BlueGameData = game.CurrentGame.GameData.Generate()
 		if arena.CurrentMatch.Type != "test" {
 			arena.Database.UpdateMatch(arena.CurrentMatch)
 		}
 		arena.updateCycleTime(arena.CurrentMatch.StartedAt)
 
+		// Take a fresh copy of the timing to pick up any profile changes since the match was loaded; it stays fixed
+		// from here until the next match is loaded.
+		if err = arena.updateMatchTiming(); err != nil {
+			return err
+		}
+		arena.soundsPlayed = make(map[*game.MatchSound]struct{})
+		arena.setupGameData()
+
 		// Save the missed packet count to subtract it from the running count.
 		for _, allianceStation := range arena.AllianceStations {
 			if allianceStation.DsConn != nil {
@@ -344,6 +519,12 @@ func (arena *Arena) StartMatch() error {
 			}
 		}
 
+		if arena.RecoveredJournal != nil {
+			// Starting a new match implies that the recovered state is no longer wanted; resume journaling.
+			log.Printf("Discarding recovered arena state from %v.", arena.RecoveredJournal.SavedAt)
+			arena.DiscardRecoveredJournal()
+		}
+
 		arena.MatchState = StartMatch
 	}
 	return err
@@ -357,7 +538,7 @@ func (arena *Arena) AbortMatch() error {
 
 	if arena.MatchState == TimeoutActive {
 		// Handle by advancing the timeout clock to the end and letting the regular logic deal with it.
-		arena.MatchStartTime = time.Now().Add(-time.Second * time.Duration(game.MatchTiming.TimeoutDurationSec))
+		arena.MatchStartTime = time.Now().Add(-time.Second * time.Duration(arena.MatchTiming.TimeoutDurationSec))
 		return nil
 	}
 
@@ -380,12 +561,9 @@ func (arena *Arena) ResetMatch() error {
 	}
 	arena.MatchState = PreMatch
 	arena.matchAborted = false
-	arena.AllianceStations["R1"].Bypass = false
-	arena.AllianceStations["R2"].Bypass = false
-	arena.AllianceStations["R3"].Bypass = false
-	arena.AllianceStations["B1"].Bypass = false
-	arena.AllianceStations["B2"].Bypass = false
-	arena.AllianceStations["B3"].Bypass = false
+	for _, allianceStation := range arena.AllianceStations {
+		allianceStation.Bypass = false
+	}
 	arena.MuteMatchSounds = false
 	return nil
 }
@@ -396,8 +574,8 @@ func (arena *Arena) StartTimeout(durationSec int) error {
 		return fmt.Errorf("Cannot start timeout while there is a match still in progress or with results pending.")
 	}
 
-	game.MatchTiming.TimeoutDurationSec = durationSec
-	game.UpdateMatchSounds()
+	arena.MatchTiming.TimeoutDurationSec = durationSec
+	arena.matchSounds = arena.MatchTiming.GetSounds()
 	arena.soundsPlayed = make(map[*game.MatchSound]struct{})
 	arena.MatchTimingNotifier.Notify()
 	arena.MatchState = TimeoutActive
@@ -432,6 +610,8 @@ func (arena *Arena) SetAllianceStationDisplayMode(mode string) {
 func (arena *Arena) MatchTimeSec() float64 {
 	if arena.MatchState == PreMatch || arena.MatchState == StartMatch || arena.MatchState == PostMatch {
 		return 0
+	} else if arena.MatchState == FieldFault {
+		return arena.fieldFaultMatchTimeSec
 	} else {
 		return time.Since(arena.MatchStartTime).Seconds()
 	}
@@ -452,65 +632,30 @@ func (arena *Arena) Update() {
 	case StartMatch:
 		arena.MatchStartTime = time.Now()
 		arena.LastMatchTimeSec = -1
-		auto = true
 		arena.AudienceDisplayMode = "match"
 		arena.AudienceDisplayModeNotifier.Notify()
 		arena.AllianceStationDisplayMode = "match"
 		arena.AllianceStationDisplayModeNotifier.Notify()
-		if game.MatchTiming.WarmupDurationSec > 0 {
-			arena.MatchState = WarmupPeriod
-			enabled = false
-			sendDsPacket = false
-		} else {
-			arena.MatchState = AutoPeriod
-			enabled = true
-			sendDsPacket = true
-		}
+		arena.enterMatchPeriod(0)
+		auto, enabled = arena.getMatchPeriodControl()
+		sendDsPacket = enabled
 		arena.Plc.ResetMatch()
 		arena.FieldLights.ResetWasAutoSet()
-	case WarmupPeriod:
-		auto = true
-		enabled = false
-		if matchTimeSec >= float64(game.MatchTiming.WarmupDurationSec) {
-			arena.MatchState = AutoPeriod
-			auto = true
-			enabled = true
-			sendDsPacket = true
-		}
-	case AutoPeriod:
-		auto = true
-		enabled = true
-		if matchTimeSec >= game.GetDurationToAutoEnd().Seconds() {
-			auto = false
-			sendDsPacket = true
-			if game.MatchTiming.PauseDurationSec > 0 {
-				arena.MatchState = PausePeriod
-				enabled = false
-			} else {
-				arena.MatchState = TeleopPeriod
-				enabled = true
-			}
-		}
-	case PausePeriod:
-		auto = false
-		enabled = false
-		if matchTimeSec >= game.GetDurationToTeleopStart().Seconds() {
-			arena.MatchState = TeleopPeriod
-			auto = false
-			enabled = true
+	case WarmupPeriod, AutoPeriod, PausePeriod, TeleopPeriod:
+		auto, enabled = arena.getMatchPeriodControl()
+		for arena.MatchState != PostMatch &&
+			matchTimeSec >= arena.MatchTiming.GetDurationToPeriodEnd(arena.matchPeriodIndex).Seconds() {
+			arena.enterMatchPeriod(arena.matchPeriodIndex + 1)
+			auto, enabled = arena.getMatchPeriodControl()
 			sendDsPacket = true
 
-			// For 2020, the score calculation might change at this point without input due to Stage 1 activation.
-			arena.RealtimeScoreNotifier.Notify()
+			if enabled && !auto {
+				// The score calculation might change at the start of teleop without input.
+				arena.RealtimeScoreNotifier.Notify()
+			}
 		}
-	case TeleopPeriod:
-		auto = false
-		enabled = true
-		if matchTimeSec >= game.GetDurationToTeleopEnd().Seconds() {
-			arena.MatchState = PostMatch
-			auto = false
-			enabled = false
-			sendDsPacket = true
+		arena.sampleScoreTimeline(matchTimeSec)
+		if arena.MatchState == PostMatch {
 			go func() {
 				// Leave the scores on the screen briefly at the end of the match.
 				time.Sleep(time.Second * matchEndScoreDwellSec)
@@ -525,8 +670,10 @@ func (arena *Arena) Update() {
 				arena.preLoadNextMatch()
 			}()
 		}
+	case FieldFault:
+		auto, enabled, sendDsPacket = arena.updateFieldFault()
 	case TimeoutActive:
-		if matchTimeSec >= float64(game.MatchTiming.TimeoutDurationSec) {
+		if matchTimeSec >= float64(arena.MatchTiming.TimeoutDurationSec) {
 			arena.MatchState = PostTimeout
 			go func() {
 				// Leave the timer on the screen briefly at the end of the timeout period.
@@ -538,7 +685,7 @@ func (arena *Arena) Update() {
 			}()
 		}
 	case PostTimeout:
-		if matchTimeSec >= float64(game.MatchTiming.TimeoutDurationSec+postTimeoutSec) {
+		if matchTimeSec >= float64(arena.MatchTiming.TimeoutDurationSec+postTimeoutSec) {
 			arena.MatchState = PreMatch
 		}
 	}
@@ -551,6 +698,7 @@ func (arena *Arena) Update() {
 	// Send a packet if at a period transition point or if it's been long enough since the last one.
 	if sendDsPacket || time.Since(arena.lastDsPacketTime).Seconds()*1000 >= dsPacketPeriodMs {
 		arena.sendDsPacket(auto, enabled)
+		arena.sampleDsHistory(matchTimeSec)
 		arena.ArenaStatusNotifier.Notify()
 	}
 
@@ -560,10 +708,148 @@ func (arena *Arena) Update() {
 	arena.handlePlcInput()
 	arena.handlePlcOutput()
 
+	arena.updateJournal()
+
 	arena.LastMatchTimeSec = matchTimeSec
 	arena.lastMatchState = arena.MatchState
 }
 
+// Sets the timing of the current match to the event default, overridden by the timing profile assigned to the match
+// type if there is one, and notifies the displays if it has changed.
+func (arena *Arena) updateMatchTiming() error {
+	gameSettingsMutex.Lock()
+	timing := game.MatchTiming
+	gameDefinition := game.CurrentGame
+	gameSettingsMutex.Unlock()
+	if profileId, ok := arena.EventSettings.TimingProfileIds[arena.CurrentMatch.Type]; ok && profileId > 0 {
+		timingProfile, err := arena.Database.GetTimingProfileById(profileId)
+		if err != nil {
+			return err
+		}
+		if timingProfile != nil {
+			timing = timingProfile.Apply(timing)
+		}
+	}
+	timing.UpdatePeriods(gameDefinition)
+
+	if !reflect.DeepEqual(timing, arena.MatchTiming) || arena.matchSounds == nil {
+		arena.MatchTiming = timing
+		arena.matchSounds = timing.GetSounds()
+		arena.MatchTimingNotifier.Notify()
+	}
+	return nil
+}
+
+// Moves the match into the period with the given index, or ends it if there are no periods left. The match state is
+// derived from the period's control mode so that consumers that only care about auto/teleop keep working for any
+// sequence of periods.
+func (arena *Arena) enterMatchPeriod(index int) {
+	arena.matchPeriodIndex = index
+	if index >= len(arena.MatchTiming.Periods) {
+		arena.MatchState = PostMatch
+		return
+	}
+
+	if index == arena.gameDataPeriodIndex {
+		arena.sendGameData()
+	}
+
+	period := arena.MatchTiming.Periods[index]
+	if period.Enabled {
+		if period.Auto {
+			arena.MatchState = AutoPeriod
+		} else {
+			arena.MatchState = TeleopPeriod
+		}
+	} else if arena.MatchTiming.GetNextEnabledPeriodIndex(0) < index {
+		arena.MatchState = PausePeriod
+	} else {
+		arena.MatchState = WarmupPeriod
+	}
+}
+
+// Determines when the current match's game data is to be sent, and clears any data left on the driver stations from
+// the previous match if it won't be sent right away.
+func (arena *Arena) setupGameData() {
+	arena.gameDataSent = false
+	arena.gameDataPeriodIndex = -1
+	if game.CurrentGame.GameData.Mode == game.NoGameData {
+		return
+	}
+	arena.gameDataPeriodIndex = game.CurrentGame.GameData.GetSendPeriodIndex(&arena.MatchTiming)
+	if arena.gameDataPeriodIndex > 0 {
+		for _, allianceStation := range arena.AllianceStations {
+			if allianceStation.DsConn != nil {
+				if err := allianceStation.DsConn.sendGameDataPacket(""); err != nil {
+					log.Printf("Failed to clear game data for Team %d: %v", allianceStation.DsConn.TeamId, err)
+				}
+			}
+		}
+	}
+}
+
+// Sends the current match's game data to all connected driver stations.
+func (arena *Arena) sendGameData() {
+	for station, allianceStation := range arena.AllianceStations {
+		if allianceStation.DsConn != nil {
+			arena.sendGameDataToDs(allianceStation.DsConn, station)
+		}
+	}
+	arena.gameDataSent = true
+}
+
+// Sends the game data for the alliance of the given station to the given driver station.
+func (arena *Arena) sendGameDataToDs(dsConn *DriverStationConnection, station string) {
+	gameData := arena.CurrentMatch.BlueGameData
+	if station[0] == 'R' {
+		gameData = arena.CurrentMatch.RedGameData
+	}
+	if err := dsConn.sendGameDataPacket(gameData); err != nil {
+		log.Printf("Failed to send game data to Team %d: %v", dsConn.TeamId, err)
+	}
+}
+
+// Returns the auto and enabled flags that the robots should be given for the current match period.
+func (arena *Arena) getMatchPeriodControl() (bool, bool) {
+	if arena.MatchState == PostMatch || arena.matchPeriodIndex >= len(arena.MatchTiming.Periods) {
+		return false, false
+	}
+	period := arena.MatchTiming.Periods[arena.matchPeriodIndex]
+	return period.Auto, period.Enabled
+}
+
+// Returns the match period that is currently in progress, or nil if the match isn't running.
+func (arena *Arena) CurrentMatchPeriod() *game.MatchPeriod {
+	switch arena.MatchState {
+	case WarmupPeriod, AutoPeriod, PausePeriod, TeleopPeriod:
+		if arena.matchPeriodIndex < len(arena.MatchTiming.Periods) {
+			return &arena.MatchTiming.Periods[arena.matchPeriodIndex]
+		}
+	}
+	return nil
+}
+
+// Returns the number of seconds remaining in the current match period if robots are enabled in it, or otherwise the
+// full length of the next period in which they will be, for display on the driver stations and timers.
+func (arena *Arena) getPeriodSecondsRemaining() int {
+	switch arena.MatchState {
+	case PreMatch, StartMatch, TimeoutActive, PostTimeout:
+		if index := arena.MatchTiming.GetNextEnabledPeriodIndex(0); index >= 0 {
+			return arena.MatchTiming.Periods[index].DurationSec
+		}
+	case WarmupPeriod, AutoPeriod, PausePeriod, TeleopPeriod, FieldFault:
+		matchTimeSec := arena.MatchTimeSec()
+		periodIndex := arena.MatchTiming.GetMatchPeriodIndex(matchTimeSec)
+		if periodIndex < len(arena.MatchTiming.Periods) && arena.MatchTiming.Periods[periodIndex].Enabled {
+			return int(arena.MatchTiming.GetDurationToPeriodEnd(periodIndex).Seconds()) - int(matchTimeSec)
+		}
+		if index := arena.MatchTiming.GetNextEnabledPeriodIndex(periodIndex); index >= 0 {
+			return arena.MatchTiming.Periods[index].DurationSec
+		}
+	}
+	return 0
+}
+
 // Loops indefinitely to track and update the arena components.
 func (arena *Arena) Run() {
 	// Start other loops in goroutines.
@@ -585,12 +871,44 @@ func (arena *Arena) Run() {
 
 // Calculates the red alliance score summary for the given realtime snapshot.
 func (arena *Arena) RedScoreSummary() *game.ScoreSummary {
-	return arena.RedScore.Summarize()
+	return arena.RedScore.Summarize(arena.BlueScore)
 }
 
 // Calculates the blue alliance score summary for the given realtime snapshot.
 func (arena *Arena) BlueScoreSummary() *game.ScoreSummary {
-	return arena.BlueScore.Summarize()
+	return arena.BlueScore.Summarize(arena.RedScore)
+}
+
+// Adds and removes alliance stations as needed to match the number of teams per alliance in the event settings,
+// keeping the state of the stations that remain.
+func (arena *Arena) setupAllianceStations() {
+	if arena.AllianceStations != nil && arena.TeamsPerAlliance == arena.EventSettings.TeamsPerAlliance {
+		return
+	}
+	arena.TeamsPerAlliance = arena.EventSettings.TeamsPerAlliance
+	allianceStations := make(map[string]*AllianceStation)
+	for _, station := range model.AllianceStationNames(arena.TeamsPerAlliance) {
+		if allianceStation, ok := arena.AllianceStations[station]; ok {
+			allianceStations[station] = allianceStation
+		} else {
+			allianceStations[station] = new(AllianceStation)
+		}
+	}
+	for station, allianceStation := range arena.AllianceStations {
+		if _, ok := allianceStations[station]; !ok && allianceStation.DsConn != nil {
+			allianceStation.DsConn.close()
+		}
+	}
+	arena.AllianceStations = allianceStations
+}
+
+// Returns the teams in each alliance station, in the same order as model.AllianceStationNames.
+func (arena *Arena) getStationTeams() []*model.Team {
+	var teams []*model.Team
+	for _, station := range model.AllianceStationNames(arena.TeamsPerAlliance) {
+		teams = append(teams, arena.AllianceStations[station].Team)
+	}
+	return teams
 }
 
 // Loads a team into an alliance station, cleaning up the previous team there if there is one.
@@ -636,12 +954,13 @@ func (arena *Arena) getNextMatch(excludeCurrent bool) (*model.Match, error) {
 		return nil, nil
 	}
 
-	matches, err := arena.Database.GetMatchesByType(arena.CurrentMatch.Type)
+	// Walk the queue rather than the schedule so that any replays are run in the slots they were queued into.
+	matches, _, err := arena.Database.GetMatchQueue(arena.CurrentMatch.Type)
 	if err != nil {
 		return nil, err
 	}
 	for _, match := range matches {
-		if !match.IsComplete() && !(excludeCurrent && match.Id == arena.CurrentMatch.Id) {
+		if match.IsOnField(arena.FieldId()) && !(excludeCurrent && match.Id == arena.CurrentMatch.Id) {
 			return &match, nil
 		}
 	}
@@ -665,9 +984,9 @@ func (arena *Arena) preLoadNextMatch() {
 		return
 	}
 
-	var teams [6]*model.Team
-	for i, teamId := range []int{nextMatch.Red1, nextMatch.Red2, nextMatch.Red3, nextMatch.Blue1, nextMatch.Blue2,
-		nextMatch.Blue3} {
+	teamIds := nextMatch.TeamIds(arena.EventSettings.TeamsPerAlliance)
+	teams := make([]*model.Team, len(teamIds))
+	for i, teamId := range teamIds {
 		if teamId == 0 {
 			continue
 		}
@@ -678,22 +997,21 @@ func (arena *Arena) preLoadNextMatch() {
 	arena.setupNetwork(teams)
 }
 
-// Asynchronously reconfigures the networking hardware for the new set of teams.
-func (arena *Arena) setupNetwork(teams [6]*model.Team) {
+// Asynchronously reconfigures the networking hardware for the new set of teams, which holds the red alliance's
+// stations followed by the blue alliance's.
+func (arena *Arena) setupNetwork(teams []*model.Team) {
 	if arena.EventSettings.NetworkSecurityEnabled {
-		if arena.EventSettings.Ap2TeamChannel == 0 {
+		if !arena.usesSecondAccessPoint() {
 			// Only one AP is being used.
-			if err := arena.accessPoint.ConfigureTeamWifi(teams); err != nil {
+			if err := arena.accessPoint.ConfigureTeamWifi(teams, ""); err != nil {
 				log.Printf("Failed to configure team WiFi: %s", err.Error())
 			}
 		} else {
 			// Two APs are being used. Configure the first for the red teams and the second for the blue teams.
-			if err := arena.accessPoint.ConfigureTeamWifi([6]*model.Team{teams[0], teams[1], teams[2], nil, nil,
-				nil}); err != nil {
+			if err := arena.accessPoint.ConfigureTeamWifi(teams, "red"); err != nil {
 				log.Printf("Failed to configure red alliance WiFi: %s", err.Error())
 			}
-			if err := arena.accessPoint2.ConfigureTeamWifi([6]*model.Team{nil, nil, nil, teams[3], teams[4],
-				teams[5]}); err != nil {
+			if err := arena.accessPoint2.ConfigureTeamWifi(teams, "blue"); err != nil {
 				log.Printf("Failed to configure blue alliance WiFi: %s", err.Error())
 			}
 		}
@@ -714,31 +1032,8 @@ func (arena *Arena) checkCanStartMatch() error {
 		return fmt.Errorf("Cannot start match while there is a match still in progress or with results pending.")
 	}
 
-	err := arena.checkAllianceStationsReady("R1", "R2", "R3", "B1", "B2", "B3")
-	if err != nil {
-		return err
-	}
-
-	err = arena.checkSccEstops()
-	if err != nil {
-		return err
-	}
-
-	if arena.Plc.IsEnabled() {
-		if !arena.Plc.IsHealthy {
-			return fmt.Errorf("Cannot start match while PLC is not healthy.")
-		}
-		if arena.Plc.GetFieldEstop() {
-			return fmt.Errorf("Cannot start match while field emergency stop is active.")
-		}
-		for name, status := range arena.Plc.GetArmorBlockStatuses() {
-			if !status {
-				return fmt.Errorf("Cannot start match while PLC ArmorBlock '%s' is not connected.", name)
-			}
-		}
-	}
-
-	return nil
+	_, err := arena.checkReadinessRules()
+	return err
 }
 
 func (arena *Arena) checkAllianceStationsReady(stations ...string) error {
@@ -814,19 +1109,16 @@ func (arena *Arena) handlePlcInput() {
 		arena.AbortMatch()
 	}
 	redEstops, blueEstops := arena.Plc.GetTeamEstops()
-	arena.handleEstop("R1", redEstops[0])
-	arena.handleEstop("R2", redEstops[1])
-	arena.handleEstop("R3", redEstops[2])
-	arena.handleEstop("B1", blueEstops[0])
-	arena.handleEstop("B2", blueEstops[1])
-	arena.handleEstop("B3", blueEstops[2])
 	redEthernets, blueEthernets := arena.Plc.GetEthernetConnected()
-	arena.AllianceStations["R1"].Ethernet = redEthernets[0]
-	arena.AllianceStations["R2"].Ethernet = redEthernets[1]
-	arena.AllianceStations["R3"].Ethernet = redEthernets[2]
-	arena.AllianceStations["B1"].Ethernet = blueEthernets[0]
-	arena.AllianceStations["B2"].Ethernet = blueEthernets[1]
-	arena.AllianceStations["B3"].Ethernet = blueEthernets[2]
+	// The PLC is only wired for the standard three stations per alliance.
+	for i := 0; i < len(redEstops) && i < arena.TeamsPerAlliance; i++ {
+		redStation := fmt.Sprintf("R%d", i+1)
+		blueStation := fmt.Sprintf("B%d", i+1)
+		arena.handleEstop(redStation, redEstops[i])
+		arena.handleEstop(blueStation, blueEstops[i])
+		arena.AllianceStations[redStation].Ethernet = redEthernets[i]
+		arena.AllianceStations[blueStation].Ethernet = blueEthernets[i]
+	}
 
 	if arena.MatchState == PreMatch || arena.MatchState == PostMatch || arena.MatchState == TimeoutActive ||
 		arena.MatchState == PostTimeout {
@@ -848,8 +1140,9 @@ func (arena *Arena) handlePlcOutput() {
 	case PostTimeout:
 		// Set the stack light state -- solid alliance color(s) if robots are not connected, solid orange if scores are
 		// not input, or blinking green if ready.
-		redAllianceReady := arena.checkAllianceStationsReady("R1", "R2", "R3") == nil
-		blueAllianceReady := arena.checkAllianceStationsReady("B1", "B2", "B3") == nil
+		stations := model.AllianceStationNames(arena.TeamsPerAlliance)
+		redAllianceReady := arena.checkAllianceStationsReady(stations[:arena.TeamsPerAlliance]...) == nil
+		blueAllianceReady := arena.checkAllianceStationsReady(stations[arena.TeamsPerAlliance:]...) == nil
 		greenStackLight := redAllianceReady && blueAllianceReady && arena.Plc.GetCycleState(2, 0, 2)
 		arena.Plc.SetStackLights(!redAllianceReady, !blueAllianceReady, false, greenStackLight)
 		arena.Plc.SetStackBuzzer(redAllianceReady && blueAllianceReady)
@@ -908,12 +1201,12 @@ func (arena *Arena) handleEstop(station string, state bool) {
 }
 
 func (arena *Arena) handleSounds(matchTimeSec float64) {
-	if arena.MatchState == PreMatch {
-		// Only apply this logic during a match.
+	if arena.MatchState == PreMatch || arena.MatchState == FieldFault {
+		// Only apply this logic while a match is running.
 		return
 	}
 
-	for _, sound := range game.MatchSounds {
+	for _, sound := range arena.matchSounds {
 		if sound.MatchTimeSec < 0 {
 			// Skip sounds with negative timestamps; they are meant to only be triggered explicitly.
 			continue
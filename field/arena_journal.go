@@ -0,0 +1,186 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Methods for journaling the in-memory arena state to the database and recovering it after a restart.
+
+package field
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"log"
+	"time"
+)
+
+// Maximum frequency with which the journal is rewritten while the arena state is changing within the same match state.
+const journalPeriodMs = 1000
+
+// Writes the arena state to the journal if the match state has changed, or if enough time has passed since the last
+// check and any of the journaled state has changed. Does nothing while there is recovered state awaiting a decision
+// on whether to restore it, so that it isn't overwritten.
+func (arena *Arena) updateJournal() {
+	if arena.RecoveredJournal != nil {
+		return
+	}
+	if arena.MatchState == arena.lastMatchState &&
+		time.Since(arena.lastJournalTime).Seconds()*1000 < journalPeriodMs {
+		return
+	}
+	arena.lastJournalTime = time.Now()
+
+	journal := arena.buildJournal()
+	journalJson, err := json.Marshal(journal)
+	if err != nil {
+		log.Printf("Failed to serialize arena journal: %v", err)
+		return
+	}
+	if string(journalJson) == string(arena.lastJournalJson) {
+		return
+	}
+	journal.SavedAt = time.Now()
+	if err = arena.Database.SaveArenaJournal(journal); err != nil {
+		log.Printf("Failed to write arena journal: %v", err)
+		return
+	}
+	arena.lastJournalJson = journalJson
+}
+
+// Returns a snapshot of the parts of the arena state that would be lost if the process died.
+func (arena *Arena) buildJournal() *model.ArenaJournal {
+	journal := model.ArenaJournal{
+		FieldId:                    arena.FieldId(),
+		CurrentMatch:               *arena.CurrentMatch,
+		MatchState:                 int(arena.MatchState),
+		MatchStartTime:             arena.MatchStartTime,
+		MatchTimeSec:               arena.MatchTimeSec(),
+		RedScore:                   arena.RedScore,
+		BlueScore:                  arena.BlueScore,
+		RedCards:                   arena.RedCards,
+		BlueCards:                  arena.BlueCards,
+		ScoreTimeline:              arena.ScoreTimeline,
+		Bypasses:                   make(map[string]bool),
+		SavedMatchId:               arena.SavedMatch.Id,
+		AllianceSelectionAlliances: arena.AllianceSelectionAlliances,
+	}
+	for station, allianceStation := range arena.AllianceStations {
+		journal.Bypasses[station] = allianceStation.Bypass
+	}
+	return &journal
+}
+
+// Loads the journal written before the last shutdown and holds on to it if it contains anything worth restoring.
+func (arena *Arena) loadRecoveredJournal() error {
+	journal, err := arena.Database.GetArenaJournal(arena.FieldId())
+	if err != nil {
+		return err
+	}
+	if journal != nil && (journalHasMatchInProgress(journal) || arena.journalHasAllianceSelectionInProgress(journal)) {
+		arena.RecoveredJournal = journal
+	}
+	return nil
+}
+
+// Returns true if the journal was written while a real match was running or had results that were not yet committed.
+func journalHasMatchInProgress(journal *model.ArenaJournal) bool {
+	if journal.CurrentMatch.Type == "test" {
+		return false
+	}
+	switch MatchState(journal.MatchState) {
+	case StartMatch, WarmupPeriod, AutoPeriod, PausePeriod, TeleopPeriod, FieldFault, PostMatch:
+		return true
+	}
+	return false
+}
+
+// Returns true if the journal was written while alliance selection was underway but before it was finalized.
+func (arena *Arena) journalHasAllianceSelectionInProgress(journal *model.ArenaJournal) bool {
+	if len(journal.AllianceSelectionAlliances) == 0 {
+		return false
+	}
+	alliances, err := arena.Database.GetAllAlliances()
+	return err == nil && len(alliances) == 0
+}
+
+// Returns a human-readable description of each piece of state in the recovered journal, for presenting to the user
+// when deciding whether to restore it.
+func (arena *Arena) RecoveredJournalSummary() []string {
+	journal := arena.RecoveredJournal
+	if journal == nil {
+		return nil
+	}
+	var summary []string
+	if journalHasMatchInProgress(journal) {
+		matchName := journal.CurrentMatch.TypePrefix() + journal.CurrentMatch.DisplayName
+		score := ""
+		if journal.RedScore != nil && journal.BlueScore != nil {
+			score = fmt.Sprintf(
+				" with a score of %d-%d (red-blue)",
+				journal.RedScore.Summarize(journal.BlueScore).Score,
+				journal.BlueScore.Summarize(journal.RedScore).Score,
+			)
+		}
+		if MatchState(journal.MatchState) == PostMatch {
+			summary = append(summary, fmt.Sprintf("Match %s had ended%s but was not committed.", matchName, score))
+		} else {
+			summary = append(
+				summary,
+				fmt.Sprintf("Match %s was interrupted %d seconds in%s.", matchName, int(journal.MatchTimeSec), score),
+			)
+		}
+	}
+	if arena.journalHasAllianceSelectionInProgress(journal) {
+		summary = append(summary, "Alliance selection was in progress.")
+	}
+	return summary
+}
+
+// Restores the state from the recovered journal. A match that was in progress or awaiting commit is reloaded in the
+// post-match state with the scores it had reached, so that it can be committed or discarded.
+func (arena *Arena) RestoreRecoveredJournal() error {
+	journal := arena.RecoveredJournal
+	if journal == nil {
+		return fmt.Errorf("There is no recovered arena state to restore.")
+	}
+	if arena.MatchState != PreMatch {
+		return fmt.Errorf("Cannot restore the arena state while there is a match still in progress or with results " +
+			"pending.")
+	}
+
+	if journalHasMatchInProgress(journal) {
+		match := journal.CurrentMatch
+		if err := arena.LoadMatch(&match); err != nil {
+			return err
+		}
+		for station, bypass := range journal.Bypasses {
+			if allianceStation, ok := arena.AllianceStations[station]; ok {
+				allianceStation.Bypass = bypass
+			}
+		}
+		if journal.RedScore != nil && journal.BlueScore != nil {
+			arena.RedScore = journal.RedScore
+			arena.BlueScore = journal.BlueScore
+		}
+		if journal.RedCards != nil && journal.BlueCards != nil {
+			arena.RedCards = journal.RedCards
+			arena.BlueCards = journal.BlueCards
+		}
+		arena.ScoreTimeline = journal.ScoreTimeline
+		arena.MatchStartTime = journal.MatchStartTime
+		arena.matchAborted = MatchState(journal.MatchState) != PostMatch
+		arena.MatchState = PostMatch
+		arena.RealtimeScoreNotifier.Notify()
+		arena.ScoringStatusNotifier.Notify()
+	}
+	if arena.journalHasAllianceSelectionInProgress(journal) && len(arena.AllianceSelectionAlliances) == 0 {
+		arena.AllianceSelectionAlliances = journal.AllianceSelectionAlliances
+		arena.AllianceSelectionNotifier.Notify()
+	}
+
+	arena.RecoveredJournal = nil
+	return nil
+}
+
+// Drops the recovered journal without restoring it, so that it is overwritten with the current state.
+func (arena *Arena) DiscardRecoveredJournal() {
+	arena.RecoveredJournal = nil
+}
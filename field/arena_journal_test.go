@@ -0,0 +1,102 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// Simulates the process dying by closing the database out from under the arena and starting a new one on top of it.
+func restartTestArena(t *testing.T, arena *Arena) *Arena {
+	assert.Nil(t, arena.Database.Close())
+	newArena, err := NewArena(arena.Database.Path)
+	assert.Nil(t, err)
+	return newArena
+}
+
+func TestArenaJournalRestoreMatch(t *testing.T) {
+	arena := setupTestArena(t)
+
+	match := model.Match{Type: "qualification", DisplayName: "7"}
+	arena.Database.CreateMatch(&match)
+	assert.Nil(t, arena.LoadMatch(&match))
+	for _, allianceStation := range arena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+	assert.Nil(t, arena.StartMatch())
+	arena.Update()
+	arena.MatchStartTime = time.Now().Add(-20 * time.Second)
+	arena.RedScore = game.TestScore1()
+	arena.BlueScore = game.TestScore2()
+	arena.RedCards = map[string]string{"254": "yellow"}
+	arena.AllianceStations["B2"].Bypass = false
+	arena.lastJournalTime = time.Time{}
+	arena.Update()
+	assert.Equal(t, TeleopPeriod, arena.MatchState)
+
+	arena = restartTestArena(t, arena)
+	assert.Equal(t, PreMatch, arena.MatchState)
+	if assert.NotNil(t, arena.RecoveredJournal) {
+		assert.Equal(t, match.Id, arena.RecoveredJournal.CurrentMatch.Id)
+		assert.Equal(t, int(TeleopPeriod), arena.RecoveredJournal.MatchState)
+	}
+	summary := arena.RecoveredJournalSummary()
+	if assert.Equal(t, 1, len(summary)) {
+		assert.Contains(t, summary[0], "Match Q7 was interrupted 20 seconds in")
+	}
+
+	// Check that the journal isn't overwritten while the decision to restore it is pending.
+	arena.lastJournalTime = time.Time{}
+	arena.Update()
+	journal, _ := arena.Database.GetArenaJournal(0)
+	assert.Equal(t, match.Id, journal.CurrentMatch.Id)
+
+	assert.Nil(t, arena.RestoreRecoveredJournal())
+	assert.Nil(t, arena.RecoveredJournal)
+	assert.Equal(t, PostMatch, arena.MatchState)
+	assert.Equal(t, match.Id, arena.CurrentMatch.Id)
+	assert.Equal(t, game.TestScore1(), arena.RedScore)
+	assert.Equal(t, game.TestScore2(), arena.BlueScore)
+	assert.Equal(t, "yellow", arena.RedCards["254"])
+	assert.True(t, arena.AllianceStations["R1"].Bypass)
+	assert.False(t, arena.AllianceStations["B2"].Bypass)
+	assert.True(t, arena.matchAborted)
+	assert.NotNil(t, arena.RestoreRecoveredJournal())
+
+	// Check that committing or discarding the match afterward clears the state that needs recovering.
+	assert.Nil(t, arena.ResetMatch())
+	assert.Nil(t, arena.LoadTestMatch())
+	arena.Update()
+	arena = restartTestArena(t, arena)
+	assert.Nil(t, arena.RecoveredJournal)
+}
+
+func TestArenaJournalRestoreAllianceSelection(t *testing.T) {
+	arena := setupTestArena(t)
+
+	arena.AllianceSelectionAlliances = []model.Alliance{{Id: 1, TeamIds: []int{254, 1114, 0}}}
+	arena.lastJournalTime = time.Time{}
+	arena.Update()
+
+	arena = restartTestArena(t, arena)
+	assert.Equal(t, []string{"Alliance selection was in progress."}, arena.RecoveredJournalSummary())
+	assert.Nil(t, arena.RestoreRecoveredJournal())
+	assert.Equal(t, PreMatch, arena.MatchState)
+	if assert.Equal(t, 1, len(arena.AllianceSelectionAlliances)) {
+		assert.Equal(t, []int{254, 1114, 0}, arena.AllianceSelectionAlliances[0].TeamIds)
+	}
+
+	// Check that a discarded journal is overwritten with the current state.
+	arena.Update()
+	arena = restartTestArena(t, arena)
+	assert.NotNil(t, arena.RecoveredJournal)
+	arena.DiscardRecoveredJournal()
+	arena.AllianceSelectionAlliances = nil
+	arena.Update()
+	arena = restartTestArena(t, arena)
+	assert.Nil(t, arena.RecoveredJournal)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/FRCTeam1987/crimson-arena/network"
 	"github.com/FRCTeam1987/crimson-arena/websocket"
 	"strconv"
+	"time"
 )
 
 type ArenaNotifiers struct {
@@ -20,6 +21,7 @@ type ArenaNotifiers struct {
 	ArenaStatusNotifier                *websocket.Notifier
 	AudienceDisplayModeNotifier        *websocket.Notifier
 	DisplayConfigurationNotifier       *websocket.Notifier
+	DsAlertsNotifier                   *websocket.Notifier
 	EventStatusNotifier                *websocket.Notifier
 	LowerThirdNotifier                 *websocket.Notifier
 	MatchLoadNotifier                  *websocket.Notifier
@@ -29,18 +31,25 @@ type ArenaNotifiers struct {
 	RealtimeScoreNotifier              *websocket.Notifier
 	ReloadDisplaysNotifier             *websocket.Notifier
 	ScorePostedNotifier                *websocket.Notifier
+	ScoringStatusNotifier              *websocket.Notifier
 	FieldLightsNotifier                *websocket.Notifier
 	SCCNotifier                        *websocket.Notifier
+	HeadRefStatusNotifier              *websocket.Notifier
 }
 
 type MatchTimeMessage struct {
 	MatchState
 	MatchTimeSec int
+	// Index into the match timing periods of the period in progress; only meaningful while a match is running.
+	MatchPeriodIndex int
+	// Seconds until a match paused for a field fault resumes, or 0 if it isn't paused or no resumption is pending.
+	ResumeCountdownSec int
 }
 
 type audienceAllianceScoreFields struct {
-	Score        *game.Score
-	ScoreSummary *game.ScoreSummary
+	Score         *game.Score
+	ScoreSummary  *game.ScoreSummary
+	ElementScores []game.ScoringElementScore
 }
 
 // Instantiates notifiers and configures their message producing methods.
@@ -53,6 +62,7 @@ func (arena *Arena) configureNotifiers() {
 		arena.generateAudienceDisplayModeMessage)
 	arena.DisplayConfigurationNotifier = websocket.NewNotifier("displayConfiguration",
 		arena.generateDisplayConfigurationMessage)
+	arena.DsAlertsNotifier = websocket.NewNotifier("dsAlerts", arena.generateDsAlertsMessage)
 	arena.EventStatusNotifier = websocket.NewNotifier("eventStatus", arena.generateEventStatusMessage)
 	arena.LowerThirdNotifier = websocket.NewNotifier("lowerThird", arena.generateLowerThirdMessage)
 	arena.MatchLoadNotifier = websocket.NewNotifier("matchLoad", arena.generateMatchLoadMessage)
@@ -62,8 +72,10 @@ func (arena *Arena) configureNotifiers() {
 	arena.RealtimeScoreNotifier = websocket.NewNotifier("realtimeScore", arena.generateRealtimeScoreMessage)
 	arena.ReloadDisplaysNotifier = websocket.NewNotifier("reload", nil)
 	arena.ScorePostedNotifier = websocket.NewNotifier("scorePosted", arena.generateScorePostedMessage)
+	arena.ScoringStatusNotifier = websocket.NewNotifier("scoringStatus", arena.generateScoringStatusMessage)
 	arena.FieldLightsNotifier = websocket.NewNotifier("fieldLights", arena.generateFieldLightsMessage)
 	arena.SCCNotifier = websocket.NewNotifier("sccstatus", arena.generateSCCStatusMessage)
+	arena.HeadRefStatusNotifier = websocket.NewNotifier("headRefStatus", arena.generateHeadRefStatusMessage)
 }
 
 func (arena *Arena) generateAllianceSelectionMessage() interface{} {
@@ -77,11 +89,17 @@ func (arena *Arena) generateAllianceStationDisplayModeMessage() interface{} {
 func (arena *Arena) generateArenaStatusMessage() interface{} {
 	// Convert AP team wifi network status array to a map by station for ease of client use.
 	teamWifiStatuses := make(map[string]network.TeamWifiStatus)
-	for i, station := range []string{"R1", "R2", "R3", "B1", "B2", "B3"} {
-		if arena.EventSettings.Ap2TeamChannel == 0 || i < 3 {
-			teamWifiStatuses[station] = arena.accessPoint.TeamWifiStatuses[i]
-		} else {
-			teamWifiStatuses[station] = arena.accessPoint2.TeamWifiStatuses[i]
+	stations := model.AllianceStationNames(arena.TeamsPerAlliance)
+	for i, station := range stations {
+		accessPoint, alliance := arena.accessPoint, ""
+		if arena.usesSecondAccessPoint() {
+			alliance = "red"
+			if i >= arena.TeamsPerAlliance {
+				accessPoint, alliance = arena.accessPoint2, "blue"
+			}
+		}
+		if networkIndex := network.TeamNetworkIndex(i, len(stations), alliance); networkIndex >= 0 {
+			teamWifiStatuses[station] = accessPoint.TeamWifiStatuses[networkIndex]
 		}
 	}
 
@@ -90,6 +108,12 @@ func (arena *Arena) generateArenaStatusMessage() interface{} {
 	if startMatchErr != nil {
 		startMatchErrString = startMatchErr.Error()
 	}
+	// Let the FTA know when the check that is blocking the start of the match can be overridden.
+	overridableRuleId := ""
+	if rule, _ := arena.checkReadinessRules(); rule != nil &&
+		arena.readinessRuleMode(rule) == model.ReadinessRuleOverridable {
+		overridableRuleId = rule.Id
+	}
 	return &struct {
 		MatchId          int
 		AllianceStations map[string]*AllianceStation
@@ -103,13 +127,15 @@ func (arena *Arena) generateArenaStatusMessage() interface{} {
 		ScoringSccConnected   bool
 		RedSccConnected       bool
 		BlueSccConnected      bool
+		OverridableRuleId     string
 	}{arena.CurrentMatch.Id, arena.AllianceStations, teamWifiStatuses, arena.MatchState,
 		startMatchErr == nil, startMatchErrString,
 		arena.Plc.IsHealthy, arena.Plc.GetFieldEstop(),
 		arena.Plc.GetArmorBlockStatuses(),
 		arena.Scc.IsSccConnected("scoring"),
 		arena.Scc.IsSccConnected("red"),
-		arena.Scc.IsSccConnected("blue")}
+		arena.Scc.IsSccConnected("blue"),
+		overridableRuleId}
 }
 
 func (arena *Arena) generateAudienceDisplayModeMessage() interface{} {
@@ -127,10 +153,43 @@ func (arena *Arena) generateDisplayConfigurationMessage() interface{} {
 	return displaysCopy
 }
 
+func (arena *Arena) generateDsAlertsMessage() interface{} {
+	return &struct {
+		MatchId int
+		Alerts  []DriverStationAlert
+	}{arena.CurrentMatch.Id, arena.DsAlerts}
+}
+
 func (arena *Arena) generateEventStatusMessage() interface{} {
 	return arena.EventStatus
 }
 
+func (arena *Arena) generateHeadRefStatusMessage() interface{} {
+	var approvedBy string
+	var approvedAt time.Time
+	if arena.HeadRefApproval != nil {
+		approvedBy = arena.HeadRefApproval.ApprovedBy
+		approvedAt = arena.HeadRefApproval.ApprovedAt
+	}
+	return &struct {
+		ApprovalRequired bool
+		Approved         bool
+		ApprovedBy       string
+		ApprovedAt       time.Time
+		ReturnReason     string
+		RedCards         map[string]string
+		BlueCards        map[string]string
+	}{
+		arena.EventSettings.RequireHeadRefApproval,
+		arena.IsResultApproved(),
+		approvedBy,
+		approvedAt,
+		arena.HeadRefReturnReason,
+		arena.RedCards,
+		arena.BlueCards,
+	}
+}
+
 func (arena *Arena) generateLowerThirdMessage() interface{} {
 	return &struct {
 		LowerThird     *model.LowerThird
@@ -176,6 +235,7 @@ func (arena *Arena) generateMatchLoadMessage() interface{} {
 		Matchup           *bracket.Matchup
 		RedOffFieldTeams  []*model.Team
 		BlueOffFieldTeams []*model.Team
+		TeamsPerAlliance  int
 	}{
 		arena.CurrentMatch.CapitalizedType(),
 		arena.CurrentMatch,
@@ -184,15 +244,18 @@ func (arena *Arena) generateMatchLoadMessage() interface{} {
 		matchup,
 		redOffFieldTeams,
 		blueOffFieldTeams,
+		arena.TeamsPerAlliance,
 	}
 }
 
 func (arena *Arena) generateMatchTimeMessage() interface{} {
-	return MatchTimeMessage{arena.MatchState, int(arena.MatchTimeSec())}
+	return MatchTimeMessage{
+		arena.MatchState, int(arena.MatchTimeSec()), arena.matchPeriodIndex, arena.FieldFaultResumeCountdownSec(),
+	}
 }
 
 func (arena *Arena) generateMatchTimingMessage() interface{} {
-	return &game.MatchTiming
+	return &arena.MatchTiming
 }
 
 func (arena *Arena) generateRealtimeScoreMessage() interface{} {
@@ -225,22 +288,73 @@ func (arena *Arena) generateScorePostedMessage() interface{} {
 		rankings[ranking.TeamId] = ranking
 	}
 
+	redScoreSummary := arena.SavedMatchResult.RedScoreSummary()
+	blueScoreSummary := arena.SavedMatchResult.BlueScoreSummary()
 	return &struct {
-		MatchType        string
-		Match            *model.Match
-		RedScoreSummary  *game.ScoreSummary
-		BlueScoreSummary *game.ScoreSummary
-		Rankings         map[int]game.Ranking
-		SeriesStatus     string
-		SeriesLeader     string
+		MatchType              string
+		Match                  *model.Match
+		RedScoreSummary        *game.ScoreSummary
+		BlueScoreSummary       *game.ScoreSummary
+		RedElementScores       []game.ScoringElementScore
+		BlueElementScores      []game.ScoringElementScore
+		RedBonusRankingPoints  []game.BonusRankingPointRule
+		BlueBonusRankingPoints []game.BonusRankingPointRule
+		RedCards               map[string]string
+		BlueCards              map[string]string
+		Rankings               map[int]game.Ranking
+		SeriesStatus           string
+		SeriesLeader           string
+		RedRobotPoints         [game.MaxTeamsPerAlliance]int
+		BlueRobotPoints        [game.MaxTeamsPerAlliance]int
+		TeamContributions      map[int]game.TeamContribution
+		HasScoreTimeline       bool
+		TeamsPerAlliance       int
 	}{
 		arena.SavedMatch.CapitalizedType(),
 		arena.SavedMatch,
-		arena.SavedMatchResult.RedScoreSummary(),
-		arena.SavedMatchResult.BlueScoreSummary(),
+		redScoreSummary,
+		blueScoreSummary,
+		arena.SavedMatchResult.RedScore.ElementScores(),
+		arena.SavedMatchResult.BlueScore.ElementScores(),
+		redScoreSummary.BonusRankingPointRules(),
+		blueScoreSummary.BonusRankingPointRules(),
+		arena.SavedMatchResult.RedCards,
+		arena.SavedMatchResult.BlueCards,
 		rankings,
 		seriesStatus,
 		seriesLeader,
+		getRobotPoints(arena.SavedMatchResult.RedScore),
+		getRobotPoints(arena.SavedMatchResult.BlueScore),
+		arena.SavedTeamContributions,
+		len(arena.SavedMatchResult.ScoreTimeline) > 0,
+		arena.TeamsPerAlliance,
+	}
+}
+
+// Returns the points contributed by each of the alliance's robots through the per-robot scoring elements.
+func getRobotPoints(score *game.Score) [game.MaxTeamsPerAlliance]int {
+	var robotPoints [game.MaxTeamsPerAlliance]int
+	for i := range robotPoints {
+		robotPoints[i] = score.RobotPoints(i)
+	}
+	return robotPoints
+}
+
+func (arena *Arena) generateScoringStatusMessage() interface{} {
+	return &struct {
+		NumRedScoringPanels       int
+		NumRedScoringPanelsReady  int
+		NumBlueScoringPanels      int
+		NumBlueScoringPanelsReady int
+		NumScoringPanelsRequired  int
+		AllScoringPanelsCommitted bool
+	}{
+		arena.ScoringPanelRegistry.GetNumPanels("red"),
+		arena.ScoringPanelRegistry.GetNumScoreCommitted("red"),
+		arena.ScoringPanelRegistry.GetNumPanels("blue"),
+		arena.ScoringPanelRegistry.GetNumScoreCommitted("blue"),
+		arena.ScoringPanelRegistry.GetNumRequiredPanels(),
+		arena.ScoringPanelRegistry.AllScoresCommitted(),
 	}
 }
 
@@ -256,5 +370,6 @@ func getAudienceAllianceScoreFields(allianceScore *game.Score,
 	fields := new(audienceAllianceScoreFields)
 	fields.Score = allianceScore
 	fields.ScoreSummary = allianceScoreSummary
+	fields.ElementScores = allianceScore.ElementScores()
 	return fields
 }
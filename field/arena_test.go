@@ -6,6 +6,7 @@ package field
 import (
 	"github.com/FRCTeam1987/crimson-arena/game"
 	"github.com/FRCTeam1987/crimson-arena/model"
+	"github.com/FRCTeam1987/crimson-arena/network"
 	"github.com/FRCTeam1987/crimson-arena/tournament"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -202,6 +203,120 @@ func TestArenaMatchFlow(t *testing.T) {
 	assert.Equal(t, false, arena.AllianceStations["R1"].Bypass)
 }
 
+func TestArenaCustomMatchPeriods(t *testing.T) {
+	arena := setupTestArena(t)
+	defer func(definition *game.GameDefinition) { game.CurrentGame = definition }(game.CurrentGame)
+	definition := *game.CurrentGame
+	definition.Periods = []game.MatchPeriod{
+		{Name: "Auto", DurationSec: 10, Enabled: true, Auto: true, StartSound: "start"},
+		{Name: "Teleop", DurationSec: 20, Enabled: true},
+		{Name: "Endgame", DurationSec: 10, Enabled: true, StartSound: "warning", EndSound: "end"},
+	}
+	game.CurrentGame = &definition
+
+	for _, station := range []string{"R1", "R2", "R3", "B1", "B2", "B3"} {
+		arena.AllianceStations[station].Bypass = true
+	}
+	assert.Nil(t, arena.StartMatch())
+	arena.Update()
+	assert.Equal(t, AutoPeriod, arena.MatchState)
+	assert.Equal(t, "Auto", arena.CurrentMatchPeriod().Name)
+	assert.Equal(t, 10, arena.getPeriodSecondsRemaining())
+
+	arena.MatchStartTime = time.Now().Add(-12 * time.Second)
+	arena.Update()
+	assert.Equal(t, TeleopPeriod, arena.MatchState)
+	assert.Equal(t, "Teleop", arena.CurrentMatchPeriod().Name)
+	assert.Equal(t, 18, arena.getPeriodSecondsRemaining())
+
+	// Check that consecutive periods in the same control mode still advance the period.
+	arena.MatchStartTime = time.Now().Add(-31 * time.Second)
+	arena.Update()
+	assert.Equal(t, TeleopPeriod, arena.MatchState)
+	assert.Equal(t, "Endgame", arena.CurrentMatchPeriod().Name)
+	assert.Equal(t, 9, arena.getPeriodSecondsRemaining())
+	auto, enabled := arena.getMatchPeriodControl()
+	assert.False(t, auto)
+	assert.True(t, enabled)
+
+	arena.MatchStartTime = time.Now().Add(-40 * time.Second)
+	arena.Update()
+	assert.Equal(t, PostMatch, arena.MatchState)
+	assert.Nil(t, arena.CurrentMatchPeriod())
+	assert.Equal(t, 0, arena.getPeriodSecondsRemaining())
+}
+
+func TestArenaTimingProfiles(t *testing.T) {
+	arena := setupTestArena(t)
+
+	timingProfile := model.TimingProfile{Name: "Practice", AutoDurationSec: 10, TeleopDurationSec: 100}
+	assert.Nil(t, arena.Database.CreateTimingProfile(&timingProfile))
+	arena.EventSettings.TimingProfileIds = map[string]int{"practice": timingProfile.Id}
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+
+	// Check that only the match type with the profile assigned gets its durations.
+	assert.Equal(t, game.MatchTiming.AutoDurationSec, arena.MatchTiming.AutoDurationSec)
+	assert.Nil(t, arena.LoadMatch(&model.Match{Type: "practice"}))
+	assert.Equal(t, 10, arena.MatchTiming.AutoDurationSec)
+	assert.Equal(t, 110*time.Second, arena.MatchTiming.GetDurationToMatchEnd())
+
+	// Check that changes to the profile and settings don't affect a match that is already running.
+	for _, station := range []string{"R1", "R2", "R3", "B1", "B2", "B3"} {
+		arena.AllianceStations[station].Bypass = true
+	}
+	assert.Nil(t, arena.StartMatch())
+	arena.Update()
+	timingProfile.AutoDurationSec = 20
+	assert.Nil(t, arena.Database.UpdateTimingProfile(&timingProfile))
+	arena.EventSettings.TimingProfileIds = nil
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	assert.Equal(t, 10, arena.MatchTiming.AutoDurationSec)
+	arena.MatchStartTime = time.Now().Add(-11 * time.Second)
+	arena.Update()
+	assert.Equal(t, TeleopPeriod, arena.MatchState)
+	arena.MatchStartTime = time.Now().Add(-110 * time.Second)
+	arena.Update()
+	assert.Equal(t, PostMatch, arena.MatchState)
+}
+
+func TestArenaGameData(t *testing.T) {
+	arena := setupTestArena(t)
+	defer func(definition *game.GameDefinition) { game.CurrentGame = definition }(game.CurrentGame)
+	definition := *game.CurrentGame
+	definition.GameData = game.GameDataRule{
+		Mode: game.PerAllianceGameData, RedValue: "L", BlueValue: "R", SendAtPeriod: "Teleoperated",
+	}
+	game.CurrentGame = &definition
+
+	match := model.Match{Type: "practice", DisplayName: "1"}
+	arena.Database.CreateMatch(&match)
+	assert.Nil(t, arena.LoadMatch(&match))
+	for _, station := range []string{"R1", "R2", "R3", "B1", "B2", "B3"} {
+		arena.AllianceStations[station].Bypass = true
+	}
+	assert.Nil(t, arena.StartMatch())
+	arena.Update()
+	assert.False(t, arena.gameDataSent)
+
+	// Check that the data is stored on the match as soon as it starts.
+	match2, _ := arena.Database.GetMatchById(match.Id)
+	assert.Equal(t, "L", match2.RedGameData)
+	assert.Equal(t, "R", match2.BlueGameData)
+
+	arena.MatchStartTime = time.Now().Add(-time.Duration(game.MatchTiming.WarmupDurationSec+
+		game.MatchTiming.AutoDurationSec) * time.Second)
+	arena.Update()
+	assert.Equal(t, PausePeriod, arena.MatchState)
+	assert.False(t, arena.gameDataSent)
+	arena.MatchStartTime = time.Now().Add(-time.Duration(game.MatchTiming.WarmupDurationSec+
+		game.MatchTiming.AutoDurationSec+game.MatchTiming.PauseDurationSec) * time.Second)
+	arena.Update()
+	assert.Equal(t, TeleopPeriod, arena.MatchState)
+	assert.True(t, arena.gameDataSent)
+}
+
 func TestArenaStateEnforcement(t *testing.T) {
 	arena := setupTestArena(t)
 
@@ -424,6 +539,102 @@ func TestLoadNextMatch(t *testing.T) {
 	assert.Equal(t, qualificationMatch2.Id, arena.CurrentMatch.Id)
 }
 
+func TestLoadNextMatchReplay(t *testing.T) {
+	arena := setupTestArena(t)
+
+	match1 := model.Match{Type: "qualification", DisplayName: "1", Status: game.RedWonMatch}
+	match2 := model.Match{Type: "qualification", DisplayName: "2"}
+	match3 := model.Match{Type: "qualification", DisplayName: "3"}
+	arena.Database.CreateMatch(&match1)
+	arena.Database.CreateMatch(&match2)
+	arena.Database.CreateMatch(&match3)
+	assert.Nil(t, arena.Database.QueueMatchReplay(&model.MatchReplay{MatchId: match1.Id}, "qualification", 1))
+
+	// Check that the replay is loaded right after the match it was queued behind is committed.
+	assert.Nil(t, arena.LoadMatch(&match2))
+	match2.Status = game.BlueWonMatch
+	arena.Database.UpdateMatch(&match2)
+	assert.Nil(t, arena.LoadNextMatch())
+	assert.Equal(t, match1.Id, arena.CurrentMatch.Id)
+
+	// Check that the schedule carries on once the replay has been played.
+	assert.Nil(t, arena.Database.CompletePendingMatchReplay(match1.Id, 2))
+	assert.Nil(t, arena.LoadNextMatch())
+	assert.Equal(t, match3.Id, arena.CurrentMatch.Id)
+}
+
+func TestNewArenas(t *testing.T) {
+	arena := setupTestArena(t)
+	assert.Nil(t, arena.Field)
+	assert.Equal(t, 0, arena.FieldId())
+	assert.Equal(t, network.ServerIpAddress, arena.ServerIpAddress())
+
+	arena.Database.CreateField(&model.Field{Name: "Field 1"})
+	arena.Database.CreateField(&model.Field{Name: "Field 2", ServerIpAddress: "10.0.200.5"})
+	match1 := model.Match{Type: "qualification", DisplayName: "1", FieldId: 1}
+	match2 := model.Match{Type: "qualification", DisplayName: "2", FieldId: 2}
+	match3 := model.Match{Type: "qualification", DisplayName: "3", FieldId: 1}
+	match4 := model.Match{Type: "qualification", DisplayName: "4"}
+	arena.Database.CreateMatch(&match1)
+	arena.Database.CreateMatch(&match2)
+	arena.Database.CreateMatch(&match3)
+	arena.Database.CreateMatch(&match4)
+	assert.Nil(t, arena.Database.Close())
+
+	arenas, err := NewArenas(arena.Database.Path)
+	assert.Nil(t, err)
+	if !assert.Equal(t, 2, len(arenas)) {
+		return
+	}
+	assert.Same(t, arenas[0].Database, arenas[1].Database)
+	assert.Equal(t, "Field 1", arenas[0].Field.Name)
+	assert.Equal(t, network.ServerIpAddress, arenas[0].ServerIpAddress())
+	assert.Equal(t, 2, arenas[1].FieldId())
+	assert.Equal(t, "10.0.200.5", arenas[1].ServerIpAddress())
+
+	// Check that each arena only moves on to the matches scheduled on its own field or on any field.
+	match1.Status = game.RedWonMatch
+	arenas[0].Database.UpdateMatch(&match1)
+	assert.Nil(t, arenas[0].LoadMatch(&match1))
+	assert.Nil(t, arenas[0].LoadNextMatch())
+	assert.Equal(t, match3.Id, arenas[0].CurrentMatch.Id)
+	match3.Status = game.RedWonMatch
+	arenas[0].Database.UpdateMatch(&match3)
+	assert.Nil(t, arenas[0].LoadNextMatch())
+	assert.Equal(t, match4.Id, arenas[0].CurrentMatch.Id)
+	assert.Nil(t, arenas[1].LoadMatch(&match2))
+	match2.Status = game.BlueWonMatch
+	arenas[1].Database.UpdateMatch(&match2)
+	assert.Nil(t, arenas[1].LoadNextMatch())
+	assert.Equal(t, match4.Id, arenas[1].CurrentMatch.Id)
+}
+
+func TestAssignPlayoffMatchFields(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.Database.CreateField(&model.Field{Name: "Field 1"})
+	arena.Database.CreateField(&model.Field{Name: "Field 2"})
+	tournament.CreateTestAlliances(arena.Database, 2)
+	arena.EventSettings.NumElimAlliances = 2
+	assert.Nil(t, arena.CreatePlayoffBracket())
+
+	// Check that the matches created by the bracket are put on the fields in alternation.
+	assert.Nil(t, arena.UpdatePlayoffBracket(nil))
+	matches, _ := arena.Database.GetMatchesByType("elimination")
+	if assert.Equal(t, 2, len(matches)) {
+		assert.Equal(t, 1, matches[0].FieldId)
+		assert.Equal(t, 2, matches[1].FieldId)
+	}
+
+	// Check that a match created later, as after a tie, is put on a field too.
+	matches[0].Status = game.TieMatch
+	arena.Database.UpdateMatch(&matches[0])
+	assert.Nil(t, arena.UpdatePlayoffBracket(nil))
+	matches, _ = arena.Database.GetMatchesByType("elimination")
+	if assert.Equal(t, 3, len(matches)) {
+		assert.Equal(t, 1, matches[2].FieldId)
+	}
+}
+
 func TestSubstituteTeam(t *testing.T) {
 	arena := setupTestArena(t)
 	tournament.CreateTestAlliances(arena.Database, 2)
@@ -472,6 +683,46 @@ func TestSubstituteTeam(t *testing.T) {
 	assert.Nil(t, arena.SubstituteTeam(107, "R1"))
 }
 
+func TestArenaTeamsPerAlliance(t *testing.T) {
+	arena := setupTestArena(t)
+	for teamId := 101; teamId <= 108; teamId++ {
+		arena.Database.CreateTeam(&model.Team{Id: teamId})
+	}
+	assert.Equal(t, 3, arena.TeamsPerAlliance)
+	assert.Equal(t, 6, len(arena.AllianceStations))
+
+	// Check that changing the setting rebuilds the alliance stations once the match is reloaded.
+	arena.EventSettings.TeamsPerAlliance = 2
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	assert.Equal(t, 2, arena.TeamsPerAlliance)
+	assert.Equal(t, 4, len(arena.AllianceStations))
+	assert.Contains(t, arena.AllianceStations, "B2")
+	assert.NotContains(t, arena.AllianceStations, "R3")
+	match := model.Match{Type: "practice", Red1: 101, Red2: 102, Red3: 103, Blue1: 104, Blue2: 105}
+	arena.Database.CreateMatch(&match)
+	assert.Nil(t, arena.LoadMatch(&match))
+	assert.Equal(t, 102, arena.AllianceStations["R2"].Team.Id)
+	assert.Equal(t, 105, arena.AllianceStations["B2"].Team.Id)
+	assert.NotNil(t, arena.SubstituteTeam(106, "B3"))
+
+	// Check that a fourth station is available on each alliance.
+	arena.EventSettings.TeamsPerAlliance = 4
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	assert.Equal(t, 8, len(arena.AllianceStations))
+	assert.Nil(t, arena.SubstituteTeam(107, "R4"))
+	assert.Nil(t, arena.SubstituteTeam(108, "B4"))
+	assert.Equal(t, 107, arena.CurrentMatch.Red4)
+	assert.Equal(t, 108, arena.AllianceStations["B4"].Team.Id)
+	for _, allianceStation := range arena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+	assert.Nil(t, arena.checkCanStartMatch())
+	arena.AllianceStations["R4"].Bypass = false
+	assert.NotNil(t, arena.checkCanStartMatch())
+}
+
 func TestAstop(t *testing.T) {
 	arena := setupTestArena(t)
 
@@ -569,7 +820,7 @@ func TestArenaTimeout(t *testing.T) {
 	// Test regular ending of timeout.
 	timeoutDurationSec := 9
 	assert.Nil(t, arena.StartTimeout(timeoutDurationSec))
-	assert.Equal(t, timeoutDurationSec, game.MatchTiming.TimeoutDurationSec)
+	assert.Equal(t, timeoutDurationSec, arena.MatchTiming.TimeoutDurationSec)
 	assert.Equal(t, TimeoutActive, arena.MatchState)
 	arena.MatchStartTime = time.Now().Add(-time.Duration(timeoutDurationSec) * time.Second)
 	arena.Update()
@@ -581,7 +832,7 @@ func TestArenaTimeout(t *testing.T) {
 	// Test early cancellation of timeout.
 	timeoutDurationSec = 28
 	assert.Nil(t, arena.StartTimeout(timeoutDurationSec))
-	assert.Equal(t, timeoutDurationSec, game.MatchTiming.TimeoutDurationSec)
+	assert.Equal(t, timeoutDurationSec, arena.MatchTiming.TimeoutDurationSec)
 	assert.Equal(t, TimeoutActive, arena.MatchState)
 	assert.Nil(t, arena.AbortMatch())
 	arena.Update()
@@ -601,7 +852,7 @@ func TestArenaTimeout(t *testing.T) {
 	arena.Update()
 	assert.NotNil(t, arena.StartTimeout(1))
 	assert.NotEqual(t, TimeoutActive, arena.MatchState)
-	assert.Equal(t, timeoutDurationSec, game.MatchTiming.TimeoutDurationSec)
+	assert.NotEqual(t, 1, arena.MatchTiming.TimeoutDurationSec)
 	arena.MatchStartTime = time.Now().Add(-time.Duration(game.MatchTiming.WarmupDurationSec+
 		game.MatchTiming.AutoDurationSec+game.MatchTiming.PauseDurationSec+game.MatchTiming.TeleopDurationSec) *
 		time.Second)
@@ -647,3 +898,25 @@ func TestSaveTeamHasConnected(t *testing.T) {
 		assert.Equal(t, "San Jose", teams[5].City)
 	}
 }
+
+func TestArenaPendingPlayNumber(t *testing.T) {
+	arena := setupTestArena(t)
+
+	match := model.Match{Type: "qualification", DisplayName: "1"}
+	assert.Nil(t, arena.Database.CreateMatch(&match))
+	assert.Nil(t, arena.LoadMatch(&match))
+	assert.Equal(t, 1, arena.PendingPlayNumber)
+
+	// Check that the play number is worked out again on reload, and that score changes are recorded against it.
+	assert.Nil(t, arena.Database.CreateMatchResult(model.BuildTestMatchResult(match.Id, 1)))
+	assert.Nil(t, arena.LoadMatch(&match))
+	assert.Equal(t, 2, arena.PendingPlayNumber)
+	previousRedScore := *arena.RedScore
+	arena.RedScore.SetElementCount("auto", 2)
+	assert.Nil(t, arena.RecordScoreChange(model.ApiScoreEventSource, "", &previousRedScore, arena.BlueScore))
+	events, err := arena.Database.GetScoreEventsForMatch(match.Id)
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(events)) {
+		assert.Equal(t, 2, events[0].PlayNumber)
+	}
+}
@@ -7,9 +7,7 @@ package field
 
 import (
 	"fmt"
-	"github.com/FRCTeam1987/crimson-arena/game"
 	"github.com/FRCTeam1987/crimson-arena/model"
-	"github.com/FRCTeam1987/crimson-arena/network"
 	"log"
 	"net"
 	"regexp"
@@ -55,7 +53,11 @@ type DriverStationConnection struct {
 	WrongStation string
 }
 
-var allianceStationPositionMap = map[string]byte{"R1": 0, "R2": 1, "R3": 2, "B1": 3, "B2": 4, "B3": 5}
+// The driver station protocol only knows of three positions per alliance, so the fourth station on each alliance, used
+// at events with four teams per alliance, identifies itself to the driver station as the third.
+var allianceStationPositionMap = map[string]byte{
+	"R1": 0, "R2": 1, "R3": 2, "R4": 2, "B1": 3, "B2": 4, "B3": 5, "B4": 5,
+}
 
 // Opens a UDP connection for communicating to the driver station.
 func newDriverStationConnection(teamId int, allianceStation string, tcpConn net.Conn) (*DriverStationConnection, error) {
@@ -74,7 +76,12 @@ func newDriverStationConnection(teamId int, allianceStation string, tcpConn net.
 
 // Loops indefinitely to read packets and update connection status.
 func (arena *Arena) listenForDsUdpPackets() {
-	udpAddress, _ := net.ResolveUDPAddr("udp4", fmt.Sprintf(":%d", driverStationUdpReceivePort))
+	// Each field's arena has to listen on its own address when there is more than one, since they share the port.
+	udpHost := ""
+	if arena.Field != nil {
+		udpHost = arena.ServerIpAddress()
+	}
+	udpAddress, _ := net.ResolveUDPAddr("udp4", fmt.Sprintf("%s:%d", udpHost, driverStationUdpReceivePort))
 	listener, err := net.ListenUDP("udp4", udpAddress)
 	if err != nil {
 		log.Fatalf("Error opening driver station UDP socket: %v", err)
@@ -220,27 +227,8 @@ func (dsConn *DriverStationConnection) encodeControlPacket(arena *Arena) [22]byt
 	packet[18] = byte(currentTime.Month())
 	packet[19] = byte(currentTime.Year() - 1900)
 
-	// Remaining number of seconds in match.
-	var matchSecondsRemaining int
-	switch arena.MatchState {
-	case PreMatch:
-		fallthrough
-	case TimeoutActive:
-		fallthrough
-	case PostTimeout:
-		matchSecondsRemaining = game.MatchTiming.AutoDurationSec
-	case StartMatch:
-		fallthrough
-	case AutoPeriod:
-		matchSecondsRemaining = game.MatchTiming.AutoDurationSec - int(arena.MatchTimeSec())
-	case PausePeriod:
-		matchSecondsRemaining = game.MatchTiming.TeleopDurationSec
-	case TeleopPeriod:
-		matchSecondsRemaining = game.MatchTiming.AutoDurationSec + game.MatchTiming.TeleopDurationSec +
-			game.MatchTiming.PauseDurationSec - int(arena.MatchTimeSec())
-	default:
-		matchSecondsRemaining = 0
-	}
+	// Remaining number of seconds in the current period, or the length of the upcoming period if robots are disabled.
+	matchSecondsRemaining := arena.getPeriodSecondsRemaining()
 	packet[20] = byte(matchSecondsRemaining >> 8 & 0xff)
 	packet[21] = byte(matchSecondsRemaining & 0xff)
 
@@ -274,10 +262,10 @@ func (dsConn *DriverStationConnection) decodeStatusPacket(data [36]byte) {
 
 // Listens for TCP connection requests to Cheesy Arena from driver stations.
 func (arena *Arena) listenForDriverStations() {
-	l, err := net.Listen("tcp", fmt.Sprintf("%s:%d", network.ServerIpAddress, driverStationTcpListenPort))
+	l, err := net.Listen("tcp", fmt.Sprintf("%s:%d", arena.ServerIpAddress(), driverStationTcpListenPort))
 	if err != nil {
 		log.Printf("Error opening driver station TCP socket: %v", err.Error())
-		log.Printf("Change IP address to %s and restart Cheesy Arena to fix.", network.ServerIpAddress)
+		log.Printf("Change IP address to %s and restart Cheesy Arena to fix.", arena.ServerIpAddress())
 		return
 	}
 	defer l.Close()
@@ -360,6 +348,11 @@ func (arena *Arena) listenForDriverStations() {
 			dsConn.WrongStation = wrongAssignedStation
 		}
 
+		// Bring a driver station that reconnects mid-match up to date with the game data already sent.
+		if arena.gameDataSent {
+			arena.sendGameDataToDs(dsConn, assignedStation)
+		}
+
 		// Spin up a goroutine to handle further TCP communication with this driver station.
 		go dsConn.handleTcpConnection(arena)
 	}
@@ -4,6 +4,7 @@
 package field
 
 import (
+	"github.com/FRCTeam1987/crimson-arena/game"
 	"github.com/FRCTeam1987/crimson-arena/network"
 	"github.com/stretchr/testify/assert"
 	"net"
@@ -110,19 +111,20 @@ func TestEncodeControlPacket(t *testing.T) {
 	assert.Equal(t, byte(84), data[8])
 
 	// Check the countdown at different points during the match.
+	warmup := time.Duration(game.MatchTiming.WarmupDurationSec) * time.Second
 	arena.MatchState = AutoPeriod
-	arena.MatchStartTime = time.Now().Add(-time.Duration(4 * time.Second))
+	arena.MatchStartTime = time.Now().Add(-warmup - time.Duration(4*time.Second))
 	data = dsConn.encodeControlPacket(arena)
 	assert.Equal(t, byte(11), data[21])
 	arena.MatchState = PausePeriod
-	arena.MatchStartTime = time.Now().Add(-time.Duration(16 * time.Second))
+	arena.MatchStartTime = time.Now().Add(-warmup - time.Duration(16*time.Second))
 	data = dsConn.encodeControlPacket(arena)
 	assert.Equal(t, byte(135), data[21])
 	arena.MatchState = TeleopPeriod
-	arena.MatchStartTime = time.Now().Add(-time.Duration(33 * time.Second))
+	arena.MatchStartTime = time.Now().Add(-warmup - time.Duration(33*time.Second))
 	data = dsConn.encodeControlPacket(arena)
 	assert.Equal(t, byte(119), data[21])
-	arena.MatchStartTime = time.Now().Add(-time.Duration(150 * time.Second))
+	arena.MatchStartTime = time.Now().Add(-warmup - time.Duration(150*time.Second))
 	data = dsConn.encodeControlPacket(arena)
 	assert.Equal(t, byte(2), data[21])
 	arena.MatchState = PostMatch
@@ -0,0 +1,170 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Rolling history of the driver station connection status in each alliance station, and the alerts raised for the
+// FTA when it shows a problem during a match.
+
+package field
+
+import (
+	"fmt"
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"time"
+)
+
+type DriverStationAlertType string
+
+const (
+	LowBatteryAlert   DriverStationAlertType = "lowBattery"
+	HighTripTimeAlert DriverStationAlertType = "highTripTime"
+	LinkLostAlert     DriverStationAlertType = "linkLost"
+)
+
+// Snapshot of the driver station connection status in an alliance station.
+type DriverStationSample struct {
+	Time              time.Time
+	MatchTimeSec      float64
+	TeamId            int
+	DsLinked          bool
+	RadioLinked       bool
+	RobotLinked       bool
+	BatteryVoltage    float64
+	DsRobotTripTimeMs int
+	MissedPacketCount int
+}
+
+// Problem with a driver station connection observed during a match.
+type DriverStationAlert struct {
+	Time         time.Time
+	MatchTimeSec float64
+	Station      string
+	TeamId       int
+	Type         DriverStationAlertType
+	Message      string
+}
+
+// Returns a copy of the connection history of the given alliance station, oldest first. Safe to call from outside the
+// arena loop.
+func (arena *Arena) GetDsHistory(station string) []DriverStationSample {
+	allianceStation, ok := arena.AllianceStations[station]
+	if !ok {
+		return nil
+	}
+	arena.dsHistoryMutex.Lock()
+	defer arena.dsHistoryMutex.Unlock()
+	history := make([]DriverStationSample, len(allianceStation.dsHistory))
+	copy(history, allianceStation.dsHistory)
+	return history
+}
+
+// Records the current connection status of each occupied alliance station, and raises alerts for any problems that
+// have arisen since the last sample while the match is running.
+func (arena *Arena) sampleDsHistory(matchTimeSec float64) {
+	arena.dsHistoryMutex.Lock()
+	defer arena.dsHistoryMutex.Unlock()
+	alertsRaised := false
+	maxSamples := arena.dsHistoryMaxSamples()
+	for _, station := range model.AllianceStationNames(arena.TeamsPerAlliance) {
+		allianceStation := arena.AllianceStations[station]
+		if allianceStation.Team == nil {
+			continue
+		}
+
+		sample := DriverStationSample{Time: time.Now(), MatchTimeSec: matchTimeSec, TeamId: allianceStation.Team.Id}
+		if dsConn := allianceStation.DsConn; dsConn != nil {
+			sample.DsLinked = dsConn.DsLinked
+			sample.RadioLinked = dsConn.RadioLinked
+			sample.RobotLinked = dsConn.RobotLinked
+			sample.BatteryVoltage = dsConn.BatteryVoltage
+			sample.DsRobotTripTimeMs = dsConn.DsRobotTripTimeMs
+			sample.MissedPacketCount = dsConn.MissedPacketCount
+		}
+		var previousSample *DriverStationSample
+		if numSamples := len(allianceStation.dsHistory); numSamples > 0 {
+			previousSample = &allianceStation.dsHistory[numSamples-1]
+		}
+		if arena.isDsAlertPeriod() && !allianceStation.Bypass {
+			if arena.checkDsAlerts(station, &sample, previousSample) {
+				alertsRaised = true
+			}
+		}
+
+		allianceStation.dsHistory = append(allianceStation.dsHistory, sample)
+		if len(allianceStation.dsHistory) > maxSamples {
+			allianceStation.dsHistory = allianceStation.dsHistory[len(allianceStation.dsHistory)-maxSamples:]
+		}
+	}
+	if alertsRaised {
+		arena.DsAlertsNotifier.Notify()
+	}
+}
+
+// Returns the number of samples kept for each alliance station; one is taken with each driver station packet.
+func (arena *Arena) dsHistoryMaxSamples() int {
+	return arena.EventSettings.DsHistoryDurationSec * 1000 / dsPacketPeriodMs
+}
+
+// Returns whether robots are meant to be on the field and running, such that connection problems warrant an alert.
+func (arena *Arena) isDsAlertPeriod() bool {
+	switch arena.MatchState {
+	case WarmupPeriod, AutoPeriod, PausePeriod, TeleopPeriod:
+		return true
+	}
+	return false
+}
+
+// Raises an alert for each problem shown in the given sample that wasn't already alerted on, and returns whether any
+// were raised. Battery and trip time alerts are re-armed once the value recovers.
+func (arena *Arena) checkDsAlerts(station string, sample, previousSample *DriverStationSample) bool {
+	alertsRaised := false
+	raiseAlertOnce := func(alertType DriverStationAlertType, active bool, message string) {
+		key := station + string(alertType)
+		if active && !arena.dsAlertsActive[key] {
+			arena.raiseDsAlert(station, sample, alertType, message)
+			alertsRaised = true
+		}
+		arena.dsAlertsActive[key] = active
+	}
+	if sample.RobotLinked {
+		// The battery voltage is only reported while the robot is linked, so leave the alert armed or not until then.
+		raiseAlertOnce(
+			LowBatteryAlert,
+			sample.BatteryVoltage > 0 && sample.BatteryVoltage < arena.EventSettings.DsAlertBatteryVoltage,
+			fmt.Sprintf("Battery sagged to %.1f V", sample.BatteryVoltage),
+		)
+	}
+	raiseAlertOnce(
+		HighTripTimeAlert,
+		sample.DsRobotTripTimeMs > arena.EventSettings.DsAlertTripTimeMs,
+		fmt.Sprintf("Trip time spiked to %d ms", sample.DsRobotTripTimeMs),
+	)
+
+	// Only a link that goes down is alerted on, so that one that was never up doesn't keep raising alerts.
+	if previousSample != nil && previousSample.TeamId == sample.TeamId && previousSample.RobotLinked &&
+		!sample.RobotLinked {
+		message := "Robot link lost"
+		if !sample.DsLinked {
+			message = "Driver station link lost"
+		} else if !sample.RadioLinked {
+			message = "Radio link lost"
+		}
+		arena.raiseDsAlert(station, sample, LinkLostAlert, message)
+		alertsRaised = true
+	}
+	return alertsRaised
+}
+
+func (arena *Arena) raiseDsAlert(
+	station string, sample *DriverStationSample, alertType DriverStationAlertType, message string,
+) {
+	arena.DsAlerts = append(
+		arena.DsAlerts,
+		DriverStationAlert{
+			Time:         sample.Time,
+			MatchTimeSec: sample.MatchTimeSec,
+			Station:      station,
+			TeamId:       sample.TeamId,
+			Type:         alertType,
+			Message:      message,
+		},
+	)
+}
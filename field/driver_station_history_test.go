@@ -0,0 +1,120 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDsHistory(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.Database.CreateTeam(&model.Team{Id: 254})
+	assert.Nil(t, arena.assignTeam(254, "R2"))
+	dsConn := &DriverStationConnection{TeamId: 254, DsLinked: true, RobotLinked: true, BatteryVoltage: 12.5}
+	arena.AllianceStations["R2"].DsConn = dsConn
+
+	arena.sampleDsHistory(0)
+	dsConn.BatteryVoltage = 12.1
+	arena.sampleDsHistory(0)
+	history := arena.GetDsHistory("R2")
+	if assert.Equal(t, 2, len(history)) {
+		assert.Equal(t, 254, history[0].TeamId)
+		assert.Equal(t, 12.5, history[0].BatteryVoltage)
+		assert.Equal(t, 12.1, history[1].BatteryVoltage)
+	}
+	assert.Empty(t, arena.GetDsHistory("R1"))
+	assert.Nil(t, arena.GetDsHistory("R9"))
+
+	// Check that the history only keeps the most recent samples, as configured.
+	arena.EventSettings.DsHistoryDurationSec = 10
+	maxSamples := 10 * 1000 / dsPacketPeriodMs
+	for i := 0; i < maxSamples; i++ {
+		dsConn.DsRobotTripTimeMs = i
+		arena.sampleDsHistory(0)
+	}
+	history = arena.GetDsHistory("R2")
+	if assert.Equal(t, maxSamples, len(history)) {
+		assert.Equal(t, 0, history[0].DsRobotTripTimeMs)
+		assert.Equal(t, maxSamples-1, history[maxSamples-1].DsRobotTripTimeMs)
+	}
+	assert.Empty(t, arena.DsAlerts)
+}
+
+func TestDsAlerts(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.Database.CreateTeam(&model.Team{Id: 254})
+	assert.Nil(t, arena.assignTeam(254, "B1"))
+	dsConn := &DriverStationConnection{
+		TeamId: 254, DsLinked: true, RadioLinked: true, RobotLinked: true, BatteryVoltage: 12.5, DsRobotTripTimeMs: 5,
+	}
+	arena.AllianceStations["B1"].DsConn = dsConn
+
+	// Check that no alerts are raised outside of a running match.
+	dsConn.BatteryVoltage = 6.5
+	arena.sampleDsHistory(0)
+	assert.Empty(t, arena.DsAlerts)
+
+	arena.MatchState = AutoPeriod
+	arena.sampleDsHistory(3.25)
+	arena.sampleDsHistory(3.5)
+	if assert.Equal(t, 1, len(arena.DsAlerts)) {
+		assert.Equal(t, LowBatteryAlert, arena.DsAlerts[0].Type)
+		assert.Equal(t, "B1", arena.DsAlerts[0].Station)
+		assert.Equal(t, 254, arena.DsAlerts[0].TeamId)
+		assert.Equal(t, 3.25, arena.DsAlerts[0].MatchTimeSec)
+		assert.Equal(t, "Battery sagged to 6.5 V", arena.DsAlerts[0].Message)
+	}
+
+	// Check that the battery alert is re-armed once the voltage recovers.
+	arena.MatchState = TeleopPeriod
+	dsConn.BatteryVoltage = 11.8
+	arena.sampleDsHistory(20)
+	dsConn.BatteryVoltage = 6.9
+	dsConn.DsRobotTripTimeMs = 120
+	arena.sampleDsHistory(40.75)
+	if assert.Equal(t, 3, len(arena.DsAlerts)) {
+		assert.Equal(t, LowBatteryAlert, arena.DsAlerts[1].Type)
+		assert.Equal(t, 40.75, arena.DsAlerts[1].MatchTimeSec)
+		assert.Equal(t, HighTripTimeAlert, arena.DsAlerts[2].Type)
+		assert.Equal(t, "Trip time spiked to 120 ms", arena.DsAlerts[2].Message)
+	}
+
+	// Check that a link drop is alerted on once, naming the link that went down.
+	dsConn.DsRobotTripTimeMs = 5
+	dsConn.RadioLinked = false
+	dsConn.RobotLinked = false
+	arena.sampleDsHistory(50)
+	arena.sampleDsHistory(51)
+	if assert.Equal(t, 4, len(arena.DsAlerts)) {
+		assert.Equal(t, LinkLostAlert, arena.DsAlerts[3].Type)
+		assert.Equal(t, "Radio link lost", arena.DsAlerts[3].Message)
+		assert.Equal(t, 50.0, arena.DsAlerts[3].MatchTimeSec)
+	}
+
+	// Check that bypassed stations don't raise alerts.
+	dsConn.RobotLinked = true
+	arena.sampleDsHistory(52)
+	arena.AllianceStations["B1"].Bypass = true
+	dsConn.RobotLinked = false
+	arena.sampleDsHistory(53)
+	assert.Equal(t, 4, len(arena.DsAlerts))
+
+	// Check that the thresholds are taken from the event settings.
+	arena.AllianceStations["B1"].Bypass = false
+	arena.EventSettings.DsAlertBatteryVoltage = 6.5
+	arena.EventSettings.DsAlertTripTimeMs = 150
+	dsConn.RobotLinked = true
+	dsConn.BatteryVoltage = 11.8
+	arena.sampleDsHistory(54)
+	dsConn.BatteryVoltage = 6.9
+	dsConn.DsRobotTripTimeMs = 120
+	arena.sampleDsHistory(55)
+	assert.Equal(t, 4, len(arena.DsAlerts))
+
+	// Check that the alerts are cleared when the next match is loaded.
+	arena.MatchState = PreMatch
+	assert.Nil(t, arena.LoadTestMatch())
+	assert.Empty(t, arena.DsAlerts)
+}
@@ -7,6 +7,7 @@ package field
 
 import (
 	"fmt"
+	"github.com/FRCTeam1987/crimson-arena/model"
 	"math"
 	"time"
 )
@@ -59,12 +60,18 @@ func (arena *Arena) getEarlyLateMessage() string {
 	}
 
 	var minutesLate float64
-	if arena.MatchState > PreMatch && arena.MatchState < PostMatch {
+	if arena.MatchState > PreMatch && arena.MatchState < PostMatch || arena.MatchState == FieldFault {
 		// The match is in progress; simply calculate lateness from its start time.
 		minutesLate = currentMatch.StartedAt.Sub(currentMatch.Time).Minutes()
 	} else {
-		// We need to check the adjacent matches to accurately determine lateness.
-		matches, _ := arena.Database.GetMatchesByType(currentMatch.Type)
+		// We need to check the adjacent matches on the same field to accurately determine lateness.
+		allMatches, _ := arena.Database.GetMatchesByType(currentMatch.Type)
+		var matches []model.Match
+		for _, match := range allMatches {
+			if match.IsOnField(arena.FieldId()) {
+				matches = append(matches, match)
+			}
+		}
 
 		previousMatchIndex := -1
 		nextMatchIndex := len(matches)
@@ -0,0 +1,97 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Methods for pausing a running match due to a field fault and resuming it afterward.
+
+package field
+
+import (
+	"fmt"
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"time"
+)
+
+// Number of seconds between the resumption of a paused match being requested and the robots being re-enabled.
+const fieldFaultCountdownSec = 3
+
+// Disables all robots and freezes the match clock until the match is resumed, and logs the fault on the match.
+func (arena *Arena) StartFieldFault(reason string) error {
+	switch arena.MatchState {
+	case WarmupPeriod, AutoPeriod, PausePeriod, TeleopPeriod:
+	default:
+		return fmt.Errorf("Cannot signal a field fault when there is no match in progress.")
+	}
+
+	arena.fieldFaultMatchTimeSec = arena.MatchTimeSec()
+	arena.fieldFaultPriorState = arena.MatchState
+	arena.fieldFaultResumeTime = time.Time{}
+	arena.MatchState = FieldFault
+	fieldFault := model.FieldFault{
+		StartedAt:    time.Now(),
+		MatchTimeSec: arena.fieldFaultMatchTimeSec,
+		Reason:       reason,
+	}
+	if arena.matchPeriodIndex < len(arena.MatchTiming.Periods) {
+		fieldFault.PeriodName = arena.MatchTiming.Periods[arena.matchPeriodIndex].Name
+	}
+	arena.CurrentMatch.FieldFaults = append(arena.CurrentMatch.FieldFaults, fieldFault)
+	if arena.CurrentMatch.Type != "test" {
+		arena.Database.UpdateMatch(arena.CurrentMatch)
+	}
+
+	// Disable the robots right away rather than waiting for the next scheduled packet.
+	auto, _ := arena.getMatchPeriodControl()
+	arena.sendDsPacket(auto, false)
+	arena.playSound("abort")
+	arena.MatchTimeNotifier.Notify()
+	arena.ArenaStatusNotifier.Notify()
+	return nil
+}
+
+// Starts the countdown to resuming a match paused for a field fault from the same period and time remaining.
+func (arena *Arena) ResumeMatch() error {
+	if arena.MatchState != FieldFault {
+		return fmt.Errorf("Cannot resume the match when it is not paused for a field fault.")
+	}
+	if !arena.fieldFaultResumeTime.IsZero() {
+		return fmt.Errorf("The match is already resuming.")
+	}
+	arena.fieldFaultResumeTime = time.Now().Add(fieldFaultCountdownSec * time.Second)
+	arena.MatchTimeNotifier.Notify()
+	return nil
+}
+
+// Returns the number of whole seconds remaining until a paused match resumes, or 0 if it isn't resuming.
+func (arena *Arena) FieldFaultResumeCountdownSec() int {
+	if arena.MatchState != FieldFault || arena.fieldFaultResumeTime.IsZero() {
+		return 0
+	}
+	return int(time.Until(arena.fieldFaultResumeTime).Seconds()) + 1
+}
+
+// Keeps the robots disabled while the match is paused for a field fault, and resumes the match once the countdown has
+// elapsed. Returns the auto and enabled flags for the robots and whether a packet should be sent to them immediately.
+func (arena *Arena) updateFieldFault() (bool, bool, bool) {
+	auto, _ := arena.getMatchPeriodControl()
+	if arena.fieldFaultResumeTime.IsZero() || time.Now().Before(arena.fieldFaultResumeTime) {
+		// The match clock is frozen, so tick the displays over as the resumption countdown progresses instead.
+		if countdownSec := arena.FieldFaultResumeCountdownSec(); countdownSec != arena.fieldFaultLastCountdownSec {
+			arena.fieldFaultLastCountdownSec = countdownSec
+			arena.MatchTimeNotifier.Notify()
+		}
+		return auto, false, false
+	}
+
+	// Shift the start time so that the clock picks up where it was frozen.
+	arena.MatchStartTime = time.Now().Add(-time.Duration(arena.fieldFaultMatchTimeSec * float64(time.Second)))
+	arena.MatchState = arena.fieldFaultPriorState
+	arena.fieldFaultResumeTime = time.Time{}
+	if numFaults := len(arena.CurrentMatch.FieldFaults); numFaults > 0 {
+		arena.CurrentMatch.FieldFaults[numFaults-1].ResumedAt = time.Now()
+		if arena.CurrentMatch.Type != "test" {
+			arena.Database.UpdateMatch(arena.CurrentMatch)
+		}
+	}
+	arena.playSound("resume")
+	auto, enabled := arena.getMatchPeriodControl()
+	return auto, enabled, true
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFieldFault(t *testing.T) {
+	arena := setupTestArena(t)
+
+	match := model.Match{Type: "qualification", DisplayName: "3"}
+	arena.Database.CreateMatch(&match)
+	assert.Nil(t, arena.LoadMatch(&match))
+	arena.Database.CreateTeam(&model.Team{Id: 254})
+	assert.Nil(t, arena.assignTeam(254, "B3"))
+	for _, allianceStation := range arena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+
+	err := arena.StartFieldFault("")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "no match in progress")
+	}
+	assert.NotNil(t, arena.ResumeMatch())
+
+	// Pause the match partway through autonomous.
+	assert.Nil(t, arena.StartMatch())
+	arena.Update()
+	arena.AllianceStations["B3"].DsConn = &DriverStationConnection{TeamId: 254, RobotLinked: true}
+	arena.AllianceStations["B3"].Bypass = false
+	arena.MatchStartTime = time.Now().Add(-time.Duration(game.MatchTiming.WarmupDurationSec+5) * time.Second)
+	arena.Update()
+	assert.Equal(t, AutoPeriod, arena.MatchState)
+	assert.True(t, arena.AllianceStations["B3"].DsConn.Enabled)
+	assert.Nil(t, arena.StartFieldFault("Field wall fell over"))
+	assert.Equal(t, FieldFault, arena.MatchState)
+	assert.False(t, arena.AllianceStations["B3"].DsConn.Enabled)
+	frozenMatchTimeSec := arena.MatchTimeSec()
+	assert.Equal(t, game.MatchTiming.WarmupDurationSec+5, int(frozenMatchTimeSec))
+	assert.NotNil(t, arena.StartFieldFault(""))
+
+	// Check that the robots stay disabled and the clock stays frozen however long the fault lasts.
+	arena.MatchStartTime = time.Now().Add(-time.Hour)
+	arena.lastDsPacketTime = arena.lastDsPacketTime.Add(-300 * time.Millisecond)
+	arena.Update()
+	assert.Equal(t, FieldFault, arena.MatchState)
+	assert.False(t, arena.AllianceStations["B3"].DsConn.Enabled)
+	assert.Equal(t, frozenMatchTimeSec, arena.MatchTimeSec())
+	assert.Equal(t, 0, arena.FieldFaultResumeCountdownSec())
+	dbMatch, _ := arena.Database.GetMatchById(match.Id)
+	if assert.Equal(t, 1, len(dbMatch.FieldFaults)) {
+		assert.Equal(t, "Field wall fell over", dbMatch.FieldFaults[0].Reason)
+		assert.Equal(t, "Autonomous", dbMatch.FieldFaults[0].PeriodName)
+		assert.Equal(t, frozenMatchTimeSec, dbMatch.FieldFaults[0].MatchTimeSec)
+		assert.True(t, dbMatch.FieldFaults[0].ResumedAt.IsZero())
+	}
+
+	// Check that the match stays paused during the resumption countdown and then picks up where it left off.
+	assert.Nil(t, arena.ResumeMatch())
+	assert.NotNil(t, arena.ResumeMatch())
+	assert.Equal(t, fieldFaultCountdownSec, arena.FieldFaultResumeCountdownSec())
+	arena.Update()
+	assert.Equal(t, FieldFault, arena.MatchState)
+	arena.fieldFaultResumeTime = time.Now().Add(-time.Millisecond)
+	arena.Update()
+	assert.Equal(t, AutoPeriod, arena.MatchState)
+	assert.True(t, arena.AllianceStations["B3"].DsConn.Auto)
+	assert.True(t, arena.AllianceStations["B3"].DsConn.Enabled)
+	assert.InDelta(t, frozenMatchTimeSec, arena.MatchTimeSec(), 0.5)
+	dbMatch, _ = arena.Database.GetMatchById(match.Id)
+	if assert.Equal(t, 1, len(dbMatch.FieldFaults)) {
+		assert.False(t, dbMatch.FieldFaults[0].ResumedAt.IsZero())
+	}
+
+	// Check that a match can be aborted while paused.
+	arena.MatchStartTime = time.Now().Add(-time.Duration(game.MatchTiming.WarmupDurationSec+
+		game.MatchTiming.AutoDurationSec+game.MatchTiming.PauseDurationSec+10) * time.Second)
+	arena.Update()
+	assert.Equal(t, TeleopPeriod, arena.MatchState)
+	assert.Nil(t, arena.StartFieldFault(""))
+	assert.Nil(t, arena.AbortMatch())
+	arena.Update()
+	assert.Equal(t, PostMatch, arena.MatchState)
+	assert.False(t, arena.AllianceStations["B3"].DsConn.Enabled)
+	assert.Equal(t, 2, len(arena.CurrentMatch.FieldFaults))
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Methods for tracking the head referee's review and sign-off of the pending result of the current match.
+
+package field
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type HeadRefApproval struct {
+	ApprovedBy string
+	ApprovedAt time.Time
+	// Serialized copy of the result that was approved, used to detect any later changes.
+	approvedResult []byte
+}
+
+// Records the head referee's approval of the current pending result.
+func (arena *Arena) ApproveResult(approvedBy string) error {
+	if arena.MatchState != PostMatch {
+		return fmt.Errorf("Cannot approve the result of a match that is not over.")
+	}
+	if approvedBy == "" {
+		return fmt.Errorf("The name of the approving referee must be given.")
+	}
+	pendingResult, err := arena.serializePendingResult()
+	if err != nil {
+		return err
+	}
+	arena.HeadRefApproval = &HeadRefApproval{approvedBy, time.Now(), pendingResult}
+	arena.HeadRefReturnReason = ""
+	arena.HeadRefStatusNotifier.Notify()
+	return nil
+}
+
+// Sends the pending result back to the scorekeeper for correction, revoking any earlier approval.
+func (arena *Arena) ReturnResult(reason string) error {
+	if arena.MatchState != PostMatch {
+		return fmt.Errorf("Cannot return the result of a match that is not over.")
+	}
+	if reason == "" {
+		return fmt.Errorf("A reason for returning the result must be given.")
+	}
+	arena.HeadRefApproval = nil
+	arena.HeadRefReturnReason = reason
+	arena.HeadRefStatusNotifier.Notify()
+	return nil
+}
+
+// Returns true if the head referee has approved the pending result and it hasn't changed since.
+func (arena *Arena) IsResultApproved() bool {
+	if arena.HeadRefApproval == nil {
+		return false
+	}
+	pendingResult, err := arena.serializePendingResult()
+	return err == nil && string(pendingResult) == string(arena.HeadRefApproval.approvedResult)
+}
+
+// Lets listeners know that the pending result was edited, which revokes any approval that was given for it.
+func (arena *Arena) NotifyPendingResultEdited() {
+	if arena.HeadRefApproval != nil {
+		arena.HeadRefStatusNotifier.Notify()
+	}
+}
+
+// Returns an error if the head referee's approval is required and hasn't been given for the pending result.
+func (arena *Arena) CheckCanCommitResult() error {
+	if arena.EventSettings.RequireHeadRefApproval && !arena.IsResultApproved() {
+		return fmt.Errorf("Cannot commit results: the head referee has not approved the current result.")
+	}
+	return nil
+}
+
+// Clears the approval state in preparation for a new match.
+func (arena *Arena) resetHeadRefApproval() {
+	arena.HeadRefApproval = nil
+	arena.HeadRefReturnReason = ""
+}
+
+func (arena *Arena) serializePendingResult() ([]byte, error) {
+	return json.Marshal(struct{ RedScore, BlueScore, RedCards, BlueCards any }{
+		arena.RedScore, arena.BlueScore, arena.RedCards, arena.BlueCards,
+	})
+}
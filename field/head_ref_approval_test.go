@@ -0,0 +1,57 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHeadRefApproval(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.EventSettings.RequireHeadRefApproval = true
+	assert.Nil(t, arena.LoadTestMatch())
+
+	err := arena.ApproveResult("Alice")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "not over")
+	}
+
+	arena.MatchState = PostMatch
+	if err = arena.CheckCanCommitResult(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "head referee has not approved")
+	}
+	if err = arena.ApproveResult(""); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "name of the approving referee")
+	}
+	assert.Nil(t, arena.ApproveResult("Alice"))
+	assert.True(t, arena.IsResultApproved())
+	assert.Equal(t, "Alice", arena.HeadRefApproval.ApprovedBy)
+	assert.Nil(t, arena.CheckCanCommitResult())
+
+	// Any change to the scores or cards after approval invalidates it.
+	arena.RedScore.PlayoffDq = true
+	assert.False(t, arena.IsResultApproved())
+	assert.NotNil(t, arena.CheckCanCommitResult())
+	arena.RedScore.PlayoffDq = false
+	assert.True(t, arena.IsResultApproved())
+	arena.BlueCards["254"] = "yellow"
+	assert.False(t, arena.IsResultApproved())
+
+	if err = arena.ReturnResult(""); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "reason")
+	}
+	assert.Nil(t, arena.ReturnResult("Missing foul on 254"))
+	assert.Nil(t, arena.HeadRefApproval)
+	assert.Equal(t, "Missing foul on 254", arena.HeadRefReturnReason)
+
+	// Approval isn't needed to commit if the event doesn't require it.
+	arena.EventSettings.RequireHeadRefApproval = false
+	assert.Nil(t, arena.CheckCanCommitResult())
+
+	arena.ApproveResult("Alice")
+	arena.MatchState = PreMatch
+	assert.Nil(t, arena.LoadTestMatch())
+	assert.Nil(t, arena.HeadRefApproval)
+	assert.Equal(t, "", arena.HeadRefReturnReason)
+}
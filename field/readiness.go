@@ -0,0 +1,178 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Rules that must be satisfied before a match can be started, and the overriding of them by the FTA.
+
+package field
+
+import (
+	"fmt"
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"strings"
+	"time"
+)
+
+// A condition that is checked before a match can be started, the enforcement of which is configurable per event.
+type ReadinessRule struct {
+	Id   string
+	Name string
+	// Safety rules are always required and can't be disabled or overridden.
+	IsSafety bool
+	check    func(arena *Arena) error
+}
+
+// The readiness rules, in the order in which they are checked.
+var ReadinessRules = []ReadinessRule{
+	{"estops", "Emergency stops clear", true, (*Arena).checkEstopsClear},
+	{"robotsLinked", "Robots connected or bypassed", false, (*Arena).checkRobotsLinked},
+	{"sccConnected", "Alliance SCCs connected", false, (*Arena).checkSccsConnected},
+	{"plcHealthy", "PLC healthy", false, (*Arena).checkPlcHealthy},
+	{"armorBlocks", "PLC ArmorBlocks connected", false, (*Arena).checkArmorBlocksConnected},
+}
+
+// Returns the readiness rule with the given ID, or nil if there isn't one.
+func GetReadinessRule(ruleId string) *ReadinessRule {
+	for i := range ReadinessRules {
+		if ReadinessRules[i].Id == ruleId {
+			return &ReadinessRules[i]
+		}
+	}
+	return nil
+}
+
+// Returns how strictly the rule is to be enforced under the arena's current event settings.
+func (arena *Arena) readinessRuleMode(rule *ReadinessRule) model.ReadinessRuleMode {
+	if rule.IsSafety {
+		return model.ReadinessRuleRequired
+	}
+	return arena.EventSettings.GetReadinessRuleMode(rule.Id)
+}
+
+// Runs each enabled readiness rule in turn and returns the first one that is failing and hasn't been overridden,
+// along with its error, or nil if the match is ready to start.
+func (arena *Arena) checkReadinessRules() (*ReadinessRule, error) {
+	for i := range ReadinessRules {
+		rule := &ReadinessRules[i]
+		mode := arena.readinessRuleMode(rule)
+		if mode == model.ReadinessRuleDisabled {
+			continue
+		}
+		if err := rule.check(arena); err != nil {
+			if mode == model.ReadinessRuleOverridable && arena.readinessOverrides[rule.Id] {
+				continue
+			}
+			return rule, err
+		}
+	}
+	return nil, nil
+}
+
+// Allows the current match to be started despite the given readiness rule failing, and logs the override on the match
+// along with who approved it.
+func (arena *Arena) OverrideReadinessRule(ruleId, reason, overriddenBy string) error {
+	if arena.MatchState != PreMatch {
+		return fmt.Errorf("Cannot override a readiness check while there is a match still in progress or with " +
+			"results pending.")
+	}
+	rule := GetReadinessRule(ruleId)
+	if rule == nil {
+		return fmt.Errorf("Invalid readiness check '%s'.", ruleId)
+	}
+	if arena.readinessRuleMode(rule) != model.ReadinessRuleOverridable {
+		return fmt.Errorf("The '%s' readiness check is not overridable at this event.", rule.Name)
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return fmt.Errorf("A reason must be given for overriding a readiness check.")
+	}
+	overriddenBy = strings.TrimSpace(overriddenBy)
+	if overriddenBy == "" {
+		return fmt.Errorf("The name of the person approving the override of a readiness check must be given.")
+	}
+	failure := rule.check(arena)
+	if failure == nil {
+		return fmt.Errorf("The '%s' readiness check is not failing.", rule.Name)
+	}
+
+	arena.readinessOverrides[rule.Id] = true
+	arena.CurrentMatch.ReadinessOverrides = append(
+		arena.CurrentMatch.ReadinessOverrides,
+		model.ReadinessOverride{
+			RuleId:       rule.Id,
+			Failure:      failure.Error(),
+			Reason:       reason,
+			OverriddenBy: overriddenBy,
+			OverriddenAt: time.Now(),
+		},
+	)
+	if arena.CurrentMatch.Type != "test" {
+		if err := arena.Database.UpdateMatch(arena.CurrentMatch); err != nil {
+			return err
+		}
+	}
+	arena.ArenaStatusNotifier.Notify()
+	return nil
+}
+
+func (arena *Arena) checkEstopsClear() error {
+	for _, station := range model.AllianceStationNames(arena.TeamsPerAlliance) {
+		if arena.AllianceStations[station].Estop {
+			return fmt.Errorf("Cannot start match while an emergency stop is active.")
+		}
+	}
+	if err := arena.checkSccEstops(); err != nil {
+		return err
+	}
+	// The field e-stop state can't be read from an unhealthy PLC, so treat it as active rather than letting the match
+	// start when the PLC health rule is disabled or overridden.
+	if arena.Plc.IsEnabled() && !arena.Plc.IsHealthy {
+		return fmt.Errorf("Cannot start match while PLC is not healthy, as the field emergency stop state is unknown.")
+	}
+	if arena.Plc.GetFieldEstop() {
+		return fmt.Errorf("Cannot start match while field emergency stop is active.")
+	}
+	return nil
+}
+
+func (arena *Arena) checkRobotsLinked() error {
+	for _, station := range model.AllianceStationNames(arena.TeamsPerAlliance) {
+		allianceStation := arena.AllianceStations[station]
+		if !allianceStation.Bypass && (allianceStation.DsConn == nil || !allianceStation.DsConn.RobotLinked) {
+			return fmt.Errorf("Cannot start match until all robots are connected or bypassed.")
+		}
+	}
+	return nil
+}
+
+func (arena *Arena) checkSccsConnected() error {
+	for _, station := range model.AllianceStationNames(arena.TeamsPerAlliance) {
+		if arena.AllianceStations[station].Bypass {
+			continue
+		}
+		if station[0] == 'R' && !arena.Scc.IsSccConnected("red") {
+			return fmt.Errorf("Cannot start match without red alliance SCC connected")
+		}
+		if station[0] == 'B' && !arena.Scc.IsSccConnected("blue") {
+			return fmt.Errorf("Cannot start match without blue alliance SCC connected")
+		}
+	}
+	return nil
+}
+
+func (arena *Arena) checkPlcHealthy() error {
+	if arena.Plc.IsEnabled() && !arena.Plc.IsHealthy {
+		return fmt.Errorf("Cannot start match while PLC is not healthy.")
+	}
+	return nil
+}
+
+func (arena *Arena) checkArmorBlocksConnected() error {
+	if !arena.Plc.IsEnabled() {
+		return nil
+	}
+	for name, status := range arena.Plc.GetArmorBlockStatuses() {
+		if !status {
+			return fmt.Errorf("Cannot start match while PLC ArmorBlock '%s' is not connected.", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReadinessRuleModes(t *testing.T) {
+	arena := setupTestArena(t)
+	err := arena.checkCanStartMatch()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Cannot start match until all robots are connected or bypassed")
+	}
+
+	// Check that a disabled rule is skipped.
+	arena.EventSettings.ReadinessRuleModes = map[string]model.ReadinessRuleMode{
+		"robotsLinked": model.ReadinessRuleDisabled,
+	}
+	err = arena.checkCanStartMatch()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "SCC connected")
+	}
+	arena.EventSettings.ReadinessRuleModes["sccConnected"] = model.ReadinessRuleDisabled
+	assert.Nil(t, arena.checkCanStartMatch())
+
+	// Check that safety rules can't be disabled.
+	arena.EventSettings.ReadinessRuleModes["estops"] = model.ReadinessRuleDisabled
+	arena.AllianceStations["R2"].Estop = true
+	err = arena.checkCanStartMatch()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "emergency stop is active")
+	}
+	assert.NotNil(t, arena.OverrideReadinessRule("estops", "Testing", "FTA"))
+
+	// Check that the field e-stop is treated as active when it can't be read, even with the PLC health rule disabled.
+	arena.AllianceStations["R2"].Estop = false
+	arena.EventSettings.ReadinessRuleModes["plcHealthy"] = model.ReadinessRuleDisabled
+	arena.Plc.SetAddress("10.0.100.10")
+	defer arena.Plc.SetAddress("")
+	arena.Plc.IsHealthy = false
+	err = arena.checkCanStartMatch()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "emergency stop state is unknown")
+	}
+}
+
+func TestOverrideReadinessRule(t *testing.T) {
+	arena := setupTestArena(t)
+	match := model.Match{Type: "qualification", DisplayName: "1"}
+	arena.Database.CreateMatch(&match)
+	assert.Nil(t, arena.LoadMatch(&match))
+	for _, allianceStation := range arena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+	arena.AllianceStations["B1"].Bypass = false
+
+	err := arena.OverrideReadinessRule("robotsLinked", "Robot is fine", "FTA")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "not overridable")
+	}
+	arena.EventSettings.ReadinessRuleModes = map[string]model.ReadinessRuleMode{
+		"robotsLinked": model.ReadinessRuleOverridable,
+		"plcHealthy":   model.ReadinessRuleOverridable,
+	}
+	err = arena.OverrideReadinessRule("bogus", "Robot is fine", "FTA")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Invalid readiness check")
+	}
+	err = arena.OverrideReadinessRule("robotsLinked", "  ", "FTA")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "reason must be given")
+	}
+	err = arena.OverrideReadinessRule("robotsLinked", "Robot is fine", " ")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "approving the override")
+	}
+	err = arena.OverrideReadinessRule("plcHealthy", "PLC is fine", "FTA")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "is not failing")
+	}
+
+	// Check that the override lets the match start and is logged against it.
+	rule, _ := arena.checkReadinessRules()
+	if assert.NotNil(t, rule) {
+		assert.Equal(t, "robotsLinked", rule.Id)
+	}
+	assert.Nil(t, arena.OverrideReadinessRule("robotsLinked", "Robot is fine", "FTA"))
+	err = arena.checkCanStartMatch()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "blue alliance SCC connected")
+	}
+	arena.EventSettings.ReadinessRuleModes["sccConnected"] = model.ReadinessRuleDisabled
+	assert.Nil(t, arena.checkCanStartMatch())
+	dbMatch, _ := arena.Database.GetMatchById(match.Id)
+	if assert.Equal(t, 1, len(dbMatch.ReadinessOverrides)) {
+		assert.Equal(t, "robotsLinked", dbMatch.ReadinessOverrides[0].RuleId)
+		assert.Equal(t, "Robot is fine", dbMatch.ReadinessOverrides[0].Reason)
+		assert.Equal(t, "FTA", dbMatch.ReadinessOverrides[0].OverriddenBy)
+		assert.Contains(t, dbMatch.ReadinessOverrides[0].Failure, "all robots are connected or bypassed")
+	}
+
+	// Check that overrides don't carry over to the next match.
+	assert.Nil(t, arena.LoadMatch(&match))
+	err = arena.checkCanStartMatch()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "all robots are connected or bypassed")
+	}
+}
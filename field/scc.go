@@ -82,9 +82,9 @@ func (scc *SCC) ApplyUpdate(update SCCUpdate) {
 		status.EStops = update.EStops
 
 		if alliance == "R" || alliance == "B" {
-			scc.updateEstop(alliance, 1, update.EStops[0])
-			scc.updateEstop(alliance, 2, update.EStops[1])
-			scc.updateEstop(alliance, 3, update.EStops[2])
+			for i, estop := range update.EStops {
+				scc.updateEstop(alliance, i+1, estop)
+			}
 		} else if update.EStops[0] {
 			scc.arena.AbortMatch()
 		}
@@ -97,7 +97,12 @@ func (scc *SCC) ApplyUpdate(update SCCUpdate) {
 
 func (scc *SCC) updateEstop(alliance string, station int, newValue bool) {
 	code := fmt.Sprintf("%s%d", alliance, station)
-	if scc.arena.AllianceStations[code].Estop == false || newValue {
+	allianceStation, ok := scc.arena.AllianceStations[code]
+	if !ok {
+		// The SCC has an emergency stop for a station that isn't in use with the current number of teams per alliance.
+		return
+	}
+	if allianceStation.Estop == false || newValue {
 		scc.arena.handleEstop(code, newValue)
 	}
 }
@@ -106,17 +111,15 @@ func (scc *SCC) Disconnect(alliance string) {
 	status, ok := scc.status[alliance]
 	if ok {
 		status.Connected = false
-		// Mark all 3 eStops as off
+		// Mark all eStops as off
 		code := "R"
 		if alliance == "blue" {
 			code = "B"
 		}
-		status.EStops[0] = false
-		status.EStops[1] = false
-		status.EStops[2] = false
-		scc.updateEstop(code, 1, false)
-		scc.updateEstop(code, 2, false)
-		scc.updateEstop(code, 3, false)
+		for i := range status.EStops {
+			status.EStops[i] = false
+			scc.updateEstop(code, i+1, false)
+		}
 		scc.arena.SCCNotifier.Notify()
 	}
 }
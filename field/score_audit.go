@@ -0,0 +1,35 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Methods for recording an audit trail of the changes made to the realtime scores of the current match.
+
+package field
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"github.com/FRCTeam1987/crimson-arena/model"
+)
+
+// Records an event for each alliance whose realtime score differs from the given copy of it taken before the change.
+// Nothing is recorded for test matches, since their results aren't saved either.
+func (arena *Arena) RecordScoreChange(
+	source model.ScoreEventSource, sourceDetail string, previousRedScore, previousBlueScore *game.Score,
+) error {
+	if arena.CurrentMatch.Type == "test" {
+		return nil
+	}
+
+	// The pending result will become the next play of the match once it is committed.
+	matchResult := &model.MatchResult{
+		MatchId:    arena.CurrentMatch.Id,
+		PlayNumber: arena.PendingPlayNumber,
+		RedScore:   arena.RedScore,
+		BlueScore:  arena.BlueScore,
+	}
+	return arena.Database.CreateScoreEvents(
+		&model.MatchResult{RedScore: previousRedScore, BlueScore: previousBlueScore},
+		matchResult,
+		arena.MatchTimeSec(),
+		source,
+		sourceDetail,
+	)
+}
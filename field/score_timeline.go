@@ -0,0 +1,22 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Methods for sampling the realtime scores over the course of a match.
+
+package field
+
+import "github.com/FRCTeam1987/crimson-arena/game"
+
+// Appends a sample of both alliances' score summaries to the timeline of the current match, once for each second of
+// the match and once more when it ends.
+func (arena *Arena) sampleScoreTimeline(matchTimeSec float64) {
+	if numSamples := len(arena.ScoreTimeline); numSamples > 0 && arena.MatchState != PostMatch &&
+		int(matchTimeSec) == int(arena.ScoreTimeline[numSamples-1].MatchTimeSec) {
+		return
+	}
+	arena.ScoreTimeline = append(
+		arena.ScoreTimeline,
+		game.ScoreTimelineSample{
+			MatchTimeSec: matchTimeSec, Red: *arena.RedScoreSummary(), Blue: *arena.BlueScoreSummary(),
+		},
+	)
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestScoreTimelineSampling(t *testing.T) {
+	arena := setupTestArena(t)
+	for _, station := range []string{"R1", "R2", "R3", "B1", "B2", "B3"} {
+		arena.AllianceStations[station].Bypass = true
+	}
+	assert.Nil(t, arena.StartMatch())
+	arena.Update()
+	arena.Update()
+	if assert.Equal(t, 1, len(arena.ScoreTimeline)) {
+		assert.Equal(t, 0, arena.ScoreTimeline[0].Red.Score)
+	}
+
+	// Check that only one sample is taken per second of the match.
+	arena.RedScore.SetElementCount("auto", 5)
+	arena.MatchStartTime = time.Now().Add(-1500 * time.Millisecond)
+	arena.Update()
+	arena.Update()
+	arena.BlueScore.SetElementCount("auto", 2)
+	arena.MatchStartTime = time.Now().Add(-2500 * time.Millisecond)
+	arena.Update()
+	if assert.Equal(t, 3, len(arena.ScoreTimeline)) {
+		assert.Equal(t, 1, int(arena.ScoreTimeline[1].MatchTimeSec))
+		assert.Equal(t, 5, arena.ScoreTimeline[1].Red.Score)
+		assert.Equal(t, 0, arena.ScoreTimeline[1].Blue.Score)
+		assert.Equal(t, 2, arena.ScoreTimeline[2].Blue.Score)
+	}
+
+	// Check that a final sample is taken when the match ends.
+	matchDuration := arena.MatchTiming.GetDurationToMatchEnd()
+	arena.MatchStartTime = time.Now().Add(-matchDuration - time.Millisecond)
+	arena.Update()
+	assert.Equal(t, PostMatch, arena.MatchState)
+	if assert.Equal(t, 4, len(arena.ScoreTimeline)) {
+		assert.Equal(t, int(matchDuration.Seconds()), int(arena.ScoreTimeline[3].MatchTimeSec))
+	}
+	arena.Update()
+	assert.Equal(t, 4, len(arena.ScoreTimeline))
+
+	arena.MatchState = PreMatch
+	assert.Nil(t, arena.LoadTestMatch())
+	assert.Empty(t, arena.ScoreTimeline)
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Model representing and methods for tracking the state of the realtime scoring panels connected for each alliance.
+
+package field
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/websocket"
+	"sync"
+)
+
+type ScoringPanelRegistry struct {
+	scoringPanels map[string]map[*websocket.Websocket]bool // The score committed state for each panel.
+	// Number of panels per alliance that must have committed, so that results can't be committed without them even if
+	// they were never connected or have disconnected.
+	numRequiredPanels int
+	mutex             sync.Mutex
+}
+
+func (registry *ScoringPanelRegistry) initialize() {
+	registry.scoringPanels = map[string]map[*websocket.Websocket]bool{"red": {}, "blue": {}}
+}
+
+// Resets the score committed state for each registered panel to false.
+func (registry *ScoringPanelRegistry) resetScoreCommitted() {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	for _, alliancePanels := range registry.scoringPanels {
+		for key := range alliancePanels {
+			alliancePanels[key] = false
+		}
+	}
+}
+
+// Returns the number of panels per alliance that must commit their scores before results can be committed.
+func (registry *ScoringPanelRegistry) GetNumRequiredPanels() int {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	return registry.numRequiredPanels
+}
+
+func (registry *ScoringPanelRegistry) setNumRequiredPanels(numRequiredPanels int) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.numRequiredPanels = numRequiredPanels
+}
+
+// Returns the number of registered panels for the given alliance.
+func (registry *ScoringPanelRegistry) GetNumPanels(alliance string) int {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	return len(registry.scoringPanels[alliance])
+}
+
+// Returns the number of panels for the given alliance that have committed their score.
+func (registry *ScoringPanelRegistry) GetNumScoreCommitted(alliance string) int {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	numCommitted := 0
+	for _, scoreCommitted := range registry.scoringPanels[alliance] {
+		if scoreCommitted {
+			numCommitted++
+		}
+	}
+	return numCommitted
+}
+
+// Returns true if the given panel has committed its score for the current match.
+func (registry *ScoringPanelRegistry) IsScoreCommitted(alliance string, ws *websocket.Websocket) bool {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	return registry.scoringPanels[alliance][ws]
+}
+
+// Returns true if every registered panel for both alliances has committed its score for the current match, and at
+// least the required number of panels for each alliance have done so.
+func (registry *ScoringPanelRegistry) AllScoresCommitted() bool {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	for _, alliancePanels := range registry.scoringPanels {
+		numCommitted := 0
+		for _, scoreCommitted := range alliancePanels {
+			if !scoreCommitted {
+				return false
+			}
+			numCommitted++
+		}
+		if numCommitted < registry.numRequiredPanels {
+			return false
+		}
+	}
+	return true
+}
+
+// Adds the given websocket to the list of registered panels for the given alliance.
+func (registry *ScoringPanelRegistry) RegisterPanel(alliance string, ws *websocket.Websocket) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.scoringPanels[alliance][ws] = false
+}
+
+// Marks the given panel as having committed its score for the current match.
+func (registry *ScoringPanelRegistry) SetScoreCommitted(alliance string, ws *websocket.Websocket) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.scoringPanels[alliance][ws] = true
+}
+
+// Removes the given websocket from the list of registered panels for the given alliance.
+func (registry *ScoringPanelRegistry) UnregisterPanel(alliance string, ws *websocket.Websocket) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	delete(registry.scoringPanels[alliance], ws)
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package field
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/websocket"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestScoringPanelRegistry(t *testing.T) {
+	var registry ScoringPanelRegistry
+	registry.initialize()
+	assert.True(t, registry.AllScoresCommitted())
+
+	ws1, ws2, ws3 := new(websocket.Websocket), new(websocket.Websocket), new(websocket.Websocket)
+	registry.RegisterPanel("red", ws1)
+	registry.RegisterPanel("red", ws2)
+	registry.RegisterPanel("blue", ws3)
+	assert.Equal(t, 2, registry.GetNumPanels("red"))
+	assert.Equal(t, 1, registry.GetNumPanels("blue"))
+	assert.False(t, registry.AllScoresCommitted())
+
+	registry.SetScoreCommitted("red", ws1)
+	registry.SetScoreCommitted("blue", ws3)
+	assert.Equal(t, 1, registry.GetNumScoreCommitted("red"))
+	assert.True(t, registry.IsScoreCommitted("red", ws1))
+	assert.False(t, registry.IsScoreCommitted("red", ws2))
+	assert.False(t, registry.AllScoresCommitted())
+
+	// A panel that disconnects is no longer required to commit.
+	registry.UnregisterPanel("red", ws2)
+	assert.True(t, registry.AllScoresCommitted())
+
+	registry.resetScoreCommitted()
+	assert.Equal(t, 0, registry.GetNumScoreCommitted("red"))
+	assert.Equal(t, 0, registry.GetNumScoreCommitted("blue"))
+	assert.False(t, registry.AllScoresCommitted())
+}
+
+func TestScoringPanelRegistryRequiredPanels(t *testing.T) {
+	var registry ScoringPanelRegistry
+	registry.initialize()
+	registry.setNumRequiredPanels(1)
+	assert.Equal(t, 1, registry.GetNumRequiredPanels())
+	assert.False(t, registry.AllScoresCommitted())
+
+	ws1, ws2 := new(websocket.Websocket), new(websocket.Websocket)
+	registry.RegisterPanel("red", ws1)
+	registry.RegisterPanel("blue", ws2)
+	registry.SetScoreCommitted("red", ws1)
+	assert.False(t, registry.AllScoresCommitted())
+	registry.SetScoreCommitted("blue", ws2)
+	assert.True(t, registry.AllScoresCommitted())
+
+	// A required panel that disconnects takes its commit with it.
+	registry.UnregisterPanel("blue", ws2)
+	assert.False(t, registry.AllScoresCommitted())
+	registry.setNumRequiredPanels(0)
+	assert.True(t, registry.AllScoresCommitted())
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Model representing a rule for awarding bonus ranking points to an alliance that reaches a scoring threshold.
+
+package game
+
+import "fmt"
+
+type BonusMetric string
+
+const (
+	AutoPointsMetric    BonusMetric = "autoPoints"
+	TeleopPointsMetric  BonusMetric = "teleopPoints"
+	EndgamePointsMetric BonusMetric = "endgamePoints"
+	MatchPointsMetric   BonusMetric = "matchPoints"
+	ElementCountMetric  BonusMetric = "elementCount"
+)
+
+// A bonus ranking point that is earned when the alliance's value for the given metric meets or exceeds the threshold.
+// For the element count metric, the value is the total count of the listed scoring elements.
+type BonusRankingPointRule struct {
+	Id            string      `yaml:"id"`
+	Name          string      `yaml:"name"`
+	Metric        BonusMetric `yaml:"metric"`
+	Elements      []string    `yaml:"elements"`
+	Threshold     int         `yaml:"threshold"`
+	RankingPoints int         `yaml:"rankingPoints"`
+}
+
+// Returns the number of ranking points the rule awards, which defaults to one if not specified.
+func (rule *BonusRankingPointRule) Value() int {
+	if rule.RankingPoints == 0 {
+		return 1
+	}
+	return rule.RankingPoints
+}
+
+// Returns true if the given score meets the rule's threshold. Foul points are excluded from every metric so that a
+// bonus can't be earned through the opponent's penalties.
+func (rule *BonusRankingPointRule) IsEarned(score *Score, summary *ScoreSummary) bool {
+	var value int
+	switch rule.Metric {
+	case AutoPointsMetric:
+		value = summary.AutoPoints
+	case TeleopPointsMetric:
+		value = summary.TeleopPoints
+	case EndgamePointsMetric:
+		value = summary.EndgamePoints
+	case MatchPointsMetric:
+		value = summary.AutoPoints + summary.TeleopPoints + summary.EndgamePoints
+	case ElementCountMetric:
+		for _, id := range rule.Elements {
+			value += score.ElementCounts[id]
+		}
+	}
+	return value >= rule.Threshold
+}
+
+// Returns an error if the rule is incomplete or refers to scoring elements that don't exist in the given definition.
+func (rule *BonusRankingPointRule) validate(definition *GameDefinition) error {
+	if rule.Id == "" {
+		return fmt.Errorf("bonus ranking point '%s' is missing an ID", rule.Name)
+	}
+	if rule.Threshold <= 0 {
+		return fmt.Errorf("bonus ranking point '%s' must have a positive threshold", rule.Id)
+	}
+	if rule.RankingPoints < 0 {
+		return fmt.Errorf("bonus ranking point '%s' must not award negative ranking points", rule.Id)
+	}
+	switch rule.Metric {
+	case AutoPointsMetric, TeleopPointsMetric, EndgamePointsMetric, MatchPointsMetric:
+	case ElementCountMetric:
+		if len(rule.Elements) == 0 {
+			return fmt.Errorf("bonus ranking point '%s' must list at least one scoring element", rule.Id)
+		}
+		for _, id := range rule.Elements {
+			if definition.GetScoringElement(id) == nil {
+				return fmt.Errorf("bonus ranking point '%s' refers to unknown scoring element '%s'", rule.Id, id)
+			}
+		}
+	default:
+		return fmt.Errorf("bonus ranking point '%s' has invalid metric '%s'", rule.Id, rule.Metric)
+	}
+	return nil
+}
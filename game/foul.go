@@ -0,0 +1,62 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Model representing a foul or penalty assessed against an alliance during a match.
+
+package game
+
+type FoulType string
+
+const (
+	MinorFoul FoulType = "minor"
+	MajorFoul FoulType = "major"
+	TechFoul  FoulType = "tech"
+)
+
+// Point values credited to the opposing alliance for each type of foul.
+type FoulPoints struct {
+	Minor int `yaml:"minor"`
+	Major int `yaml:"major"`
+	Tech  int `yaml:"tech"`
+}
+
+// A game manual rule that a foul can be assessed under.
+type Rule struct {
+	Id          int      `yaml:"id"`
+	RuleNumber  string   `yaml:"ruleNumber"`
+	FoulType    FoulType `yaml:"foulType"`
+	Description string   `yaml:"description"`
+}
+
+type Foul struct {
+	Type           FoulType
+	TeamId         int
+	RuleId         int
+	TimeInMatchSec float64
+}
+
+// Returns true if the given string is one of the known foul types.
+func IsValidFoulType(foulType FoulType) bool {
+	switch foulType {
+	case MinorFoul, MajorFoul, TechFoul:
+		return true
+	}
+	return false
+}
+
+// Returns the rule that the foul was assessed under, or nil if it isn't tied to a specific rule.
+func (foul *Foul) Rule() *Rule {
+	return CurrentGame.GetRule(foul.RuleId)
+}
+
+// Returns the number of points the foul is worth to the opposing alliance.
+func (foul *Foul) PointValue() int {
+	switch foul.Type {
+	case MinorFoul:
+		return CurrentGame.FoulPoints.Minor
+	case MajorFoul:
+		return CurrentGame.FoulPoints.Major
+	case TechFoul:
+		return CurrentGame.FoulPoints.Tech
+	}
+	return 0
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Model representing the rule by which the game-specific data sent to the driver stations is generated for a match.
+
+package game
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+type GameDataMode string
+
+const (
+	NoGameData          GameDataMode = ""
+	FixedGameData       GameDataMode = "fixed"
+	RandomGameData      GameDataMode = "random"
+	PerAllianceGameData GameDataMode = "perAlliance"
+)
+
+// Longest game data string that driver stations will accept.
+const maxGameDataLength = 64
+
+// Determines the game data for each match. In fixed mode both alliances get the value. In random mode one of the
+// options is chosen for the match and sent to both alliances. In per-alliance mode each alliance is independently
+// given a random one of the options, or the red and blue values if there are no options.
+type GameDataRule struct {
+	Mode      GameDataMode `yaml:"mode"`
+	Value     string       `yaml:"value"`
+	Options   []string     `yaml:"options"`
+	RedValue  string       `yaml:"redValue"`
+	BlueValue string       `yaml:"blueValue"`
+	// Name of the match period at whose start the data is sent; blank to send it when the match starts.
+	SendAtPeriod string `yaml:"sendAtPeriod"`
+}
+
+// Returns the game data to send to the red and blue alliances for a new match.
+func (rule *GameDataRule) Generate() (string, string) {
+	switch rule.Mode {
+	case FixedGameData:
+		return rule.Value, rule.Value
+	case RandomGameData:
+		value := rule.Options[rand.Intn(len(rule.Options))]
+		return value, value
+	case PerAllianceGameData:
+		if len(rule.Options) == 0 {
+			return rule.RedValue, rule.BlueValue
+		}
+		return rule.Options[rand.Intn(len(rule.Options))], rule.Options[rand.Intn(len(rule.Options))]
+	}
+	return "", ""
+}
+
+// Returns the index of the period in the given timing at whose start the data should be sent. Falls back to the start
+// of the match if the configured period doesn't exist, such as when the pause is configured to zero length.
+func (rule *GameDataRule) GetSendPeriodIndex(timing *Timing) int {
+	for i, period := range timing.Periods {
+		if period.Name == rule.SendAtPeriod {
+			return i
+		}
+	}
+	return 0
+}
+
+// Returns an error if the rule is incomplete for its mode or would produce data the driver stations can't accept.
+func (rule *GameDataRule) validate(definition *GameDefinition) error {
+	var values []string
+	switch rule.Mode {
+	case NoGameData:
+		return nil
+	case FixedGameData:
+		values = []string{rule.Value}
+	case RandomGameData:
+		if len(rule.Options) == 0 {
+			return fmt.Errorf("random game data must list at least one option")
+		}
+		values = rule.Options
+	case PerAllianceGameData:
+		values = append([]string{rule.RedValue, rule.BlueValue}, rule.Options...)
+	default:
+		return fmt.Errorf("game data has invalid mode '%s'", rule.Mode)
+	}
+	for _, value := range values {
+		if len(value) > maxGameDataLength {
+			return fmt.Errorf("game data '%s' is longer than %d characters", value, maxGameDataLength)
+		}
+	}
+
+	if rule.SendAtPeriod != "" && len(definition.Periods) > 0 {
+		for _, period := range definition.Periods {
+			if period.Name == rule.SendAtPeriod {
+				return nil
+			}
+		}
+		return fmt.Errorf("game data refers to unknown match period '%s'", rule.SendAtPeriod)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestGameDataRuleGenerate(t *testing.T) {
+	rand.Seed(0)
+
+	rule := GameDataRule{}
+	red, blue := rule.Generate()
+	assert.Equal(t, "", red)
+	assert.Equal(t, "", blue)
+
+	rule = GameDataRule{Mode: FixedGameData, Value: "B"}
+	red, blue = rule.Generate()
+	assert.Equal(t, "B", red)
+	assert.Equal(t, "B", blue)
+
+	rule = GameDataRule{Mode: RandomGameData, Options: []string{"LRL", "RLR", "LLL", "RRR"}}
+	for i := 0; i < 10; i++ {
+		red, blue = rule.Generate()
+		assert.Contains(t, rule.Options, red)
+		assert.Equal(t, red, blue)
+	}
+
+	rule = GameDataRule{Mode: PerAllianceGameData, RedValue: "R", BlueValue: "B"}
+	red, blue = rule.Generate()
+	assert.Equal(t, "R", red)
+	assert.Equal(t, "B", blue)
+
+	// Check that the alliances are given independent values when there are options.
+	rule.Options = []string{"A", "B", "C", "D"}
+	differed := false
+	for i := 0; i < 20; i++ {
+		red, blue = rule.Generate()
+		assert.Contains(t, rule.Options, red)
+		assert.Contains(t, rule.Options, blue)
+		differed = differed || red != blue
+	}
+	assert.True(t, differed)
+}
+
+func TestGameDataRuleSendPeriod(t *testing.T) {
+	timing := Timing{
+		AutoDurationSec:                    15,
+		PauseDurationSec:                   2,
+		TeleopDurationSec:                  135,
+		WarningRemainingDurationSec:        30,
+		TimeoutWarningRemainingDurationSec: 60,
+	}
+	timing.UpdatePeriods(DefaultGameDefinition())
+
+	rule := GameDataRule{Mode: FixedGameData, Value: "B"}
+	assert.Equal(t, 0, rule.GetSendPeriodIndex(&timing))
+	rule.SendAtPeriod = "Teleoperated"
+	assert.Equal(t, 2, rule.GetSendPeriodIndex(&timing))
+	rule.SendAtPeriod = "Endgame"
+	assert.Equal(t, 0, rule.GetSendPeriodIndex(&timing))
+}
+
+func TestGameDataRuleValidate(t *testing.T) {
+	definition := DefaultGameDefinition()
+	definition.GameData = GameDataRule{Mode: "sometimes"}
+	if err := definition.Validate(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "invalid mode")
+	}
+	definition.GameData = GameDataRule{Mode: RandomGameData}
+	if err := definition.Validate(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "at least one option")
+	}
+	definition.GameData = GameDataRule{Mode: FixedGameData, Value: strings.Repeat("A", 65)}
+	if err := definition.Validate(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "longer than 64 characters")
+	}
+	definition.GameData = GameDataRule{Mode: FixedGameData, Value: "A", SendAtPeriod: "Endgame"}
+	assert.Nil(t, definition.Validate())
+	definition.Periods = []MatchPeriod{{Name: "Teleop", DurationSec: 150, Enabled: true}}
+	if err := definition.Validate(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "unknown match period 'Endgame'")
+	}
+	definition.GameData.SendAtPeriod = "Teleop"
+	assert.Nil(t, definition.Validate())
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Model representing a declarative definition of a game's scoring elements, loaded from a JSON or YAML file so that
+// the rules can change from event to event without modifying code.
+
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type ScoringPeriod string
+
+const (
+	AutoScoringPeriod    ScoringPeriod = "auto"
+	TeleopScoringPeriod  ScoringPeriod = "teleop"
+	EndgameScoringPeriod ScoringPeriod = "endgame"
+)
+
+type ScoringElement struct {
+	Id     string        `yaml:"id"`
+	Name   string        `yaml:"name"`
+	Period ScoringPeriod `yaml:"period"`
+	Points int           `yaml:"points"`
+	// Whether the element is counted separately for each robot on the alliance rather than for the alliance as a whole.
+	PerRobot bool `yaml:"perRobot"`
+}
+
+type GameDefinition struct {
+	Name               string                  `yaml:"name"`
+	ScoringElements    []ScoringElement        `yaml:"scoringElements"`
+	FoulPoints         FoulPoints              `yaml:"foulPoints"`
+	Rules              []Rule                  `yaml:"rules"`
+	WinRankingPoints   int                     `yaml:"winRankingPoints"`
+	TieRankingPoints   int                     `yaml:"tieRankingPoints"`
+	BonusRankingPoints []BonusRankingPointRule `yaml:"bonusRankingPoints"`
+	// Sequence of periods making up a match; if empty, the durations from the event settings are used instead.
+	Periods []MatchPeriod `yaml:"periods"`
+	// Rule for generating the game-specific data sent to the driver stations; no data is sent if unset.
+	GameData GameDataRule `yaml:"gameData"`
+}
+
+// The game definition currently in effect; replaced when the event settings are loaded.
+var CurrentGame = DefaultGameDefinition()
+
+// Returns the definition used when no game definition file is configured, which mirrors the historical fixed
+// Auto/Teleop/Endgame point fields.
+func DefaultGameDefinition() *GameDefinition {
+	return &GameDefinition{
+		Name: "Generic",
+		ScoringElements: []ScoringElement{
+			{Id: "auto", Name: "Auto", Period: AutoScoringPeriod, Points: 1},
+			{Id: "teleop", Name: "Teleop", Period: TeleopScoringPeriod, Points: 1},
+			{Id: "endgame", Name: "Endgame", Period: EndgameScoringPeriod, Points: 1},
+		},
+		FoulPoints:       FoulPoints{Minor: 2, Major: 6, Tech: 12},
+		WinRankingPoints: 2,
+		TieRankingPoints: 1,
+	}
+}
+
+// Reads and validates the game definition at the given path. The format is determined from the file extension.
+func LoadGameDefinition(path string) (*GameDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pre-populate the ranking point values so that files which don't specify them keep the traditional values.
+	definition := &GameDefinition{WinRankingPoints: 2, TieRankingPoints: 1}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, definition)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, definition)
+	default:
+		return nil, fmt.Errorf("unsupported game definition file type: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing game definition %s: %v", path, err)
+	}
+
+	if err = definition.Validate(); err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+// Returns an error if the definition is incomplete or internally inconsistent.
+func (definition *GameDefinition) Validate() error {
+	if len(definition.ScoringElements) == 0 {
+		return fmt.Errorf("game definition must have at least one scoring element")
+	}
+	elementIds := make(map[string]struct{})
+	for _, element := range definition.ScoringElements {
+		if element.Id == "" {
+			return fmt.Errorf("scoring element '%s' is missing an ID", element.Name)
+		}
+		if _, ok := elementIds[element.Id]; ok {
+			return fmt.Errorf("duplicate scoring element ID '%s'", element.Id)
+		}
+		elementIds[element.Id] = struct{}{}
+		switch element.Period {
+		case AutoScoringPeriod, TeleopScoringPeriod, EndgameScoringPeriod:
+		default:
+			return fmt.Errorf("scoring element '%s' has invalid period '%s'", element.Id, element.Period)
+		}
+	}
+
+	if definition.FoulPoints.Minor < 0 || definition.FoulPoints.Major < 0 || definition.FoulPoints.Tech < 0 {
+		return fmt.Errorf("foul point values must not be negative")
+	}
+	ruleIds := make(map[int]struct{})
+	for _, rule := range definition.Rules {
+		if rule.Id <= 0 {
+			return fmt.Errorf("rule '%s' must have a positive ID", rule.RuleNumber)
+		}
+		if _, ok := ruleIds[rule.Id]; ok {
+			return fmt.Errorf("duplicate rule ID %d", rule.Id)
+		}
+		ruleIds[rule.Id] = struct{}{}
+		if !IsValidFoulType(rule.FoulType) {
+			return fmt.Errorf("rule '%s' has invalid foul type '%s'", rule.RuleNumber, rule.FoulType)
+		}
+	}
+
+	if definition.WinRankingPoints < 0 || definition.TieRankingPoints < 0 {
+		return fmt.Errorf("ranking point values must not be negative")
+	}
+	bonusIds := make(map[string]struct{})
+	for i := range definition.BonusRankingPoints {
+		rule := &definition.BonusRankingPoints[i]
+		if err := rule.validate(definition); err != nil {
+			return err
+		}
+		if _, ok := bonusIds[rule.Id]; ok {
+			return fmt.Errorf("duplicate bonus ranking point ID '%s'", rule.Id)
+		}
+		bonusIds[rule.Id] = struct{}{}
+	}
+
+	if len(definition.Periods) > 0 {
+		hasEnabledPeriod := false
+		for _, period := range definition.Periods {
+			if period.Name == "" {
+				return fmt.Errorf("match periods must have a name")
+			}
+			if period.DurationSec <= 0 {
+				return fmt.Errorf("match period '%s' must have a positive duration", period.Name)
+			}
+			if period.WarningRemainingDurationSec < 0 || period.WarningRemainingDurationSec > period.DurationSec {
+				return fmt.Errorf("match period '%s' has a warning time outside of the period", period.Name)
+			}
+			hasEnabledPeriod = hasEnabledPeriod || period.Enabled
+		}
+		if !hasEnabledPeriod {
+			return fmt.Errorf("at least one match period must have the robots enabled")
+		}
+	}
+
+	return definition.GameData.validate(definition)
+}
+
+// Returns the scoring element with the given ID, or nil if it doesn't exist.
+func (definition *GameDefinition) GetScoringElement(id string) *ScoringElement {
+	for i := range definition.ScoringElements {
+		if definition.ScoringElements[i].Id == id {
+			return &definition.ScoringElements[i]
+		}
+	}
+	return nil
+}
+
+// Returns true if any of the scoring elements are counted per robot.
+func (definition *GameDefinition) HasPerRobotElements() bool {
+	for _, element := range definition.ScoringElements {
+		if element.PerRobot {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the rule with the given ID, or nil if it doesn't exist.
+func (definition *GameDefinition) GetRule(id int) *Rule {
+	for i := range definition.Rules {
+		if definition.Rules[i].Id == id {
+			return &definition.Rules[i]
+		}
+	}
+	return nil
+}
+
+// Returns the bonus ranking point rule with the given ID, or nil if it doesn't exist.
+func (definition *GameDefinition) GetBonusRankingPoint(id string) *BonusRankingPointRule {
+	for i := range definition.BonusRankingPoints {
+		if definition.BonusRankingPoints[i].Id == id {
+			return &definition.BonusRankingPoints[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadGameDefinition(t *testing.T) {
+	definition, err := LoadGameDefinition("../games/reefscape_2025.yaml")
+	if assert.Nil(t, err) {
+		assert.NotEmpty(t, definition.Name)
+		assert.NotEmpty(t, definition.ScoringElements)
+	}
+
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "game.json")
+	os.WriteFile(jsonPath, []byte("{\"name\":\"Test\",\"scoringElements\":[{\"id\":\"cube\",\"name\":\"Cube\","+
+		"\"period\":\"teleop\",\"points\":5}]}"), 0644)
+	definition, err = LoadGameDefinition(jsonPath)
+	if assert.Nil(t, err) {
+		assert.Equal(t, "Test", definition.Name)
+		assert.Equal(
+			t,
+			[]ScoringElement{{Id: "cube", Name: "Cube", Period: TeleopScoringPeriod, Points: 5}},
+			definition.ScoringElements,
+		)
+		assert.Equal(t, 5, definition.GetScoringElement("cube").Points)
+		assert.Nil(t, definition.GetScoringElement("ball"))
+	}
+
+	yamlPath := filepath.Join(dir, "game.yml")
+	os.WriteFile(yamlPath, []byte("name: Test\nscoringElements:\n  - id: cube\n    name: Cube\n    period: teleop\n"+
+		"    points: 5\n"), 0644)
+	definition, err = LoadGameDefinition(yamlPath)
+	if assert.Nil(t, err) {
+		assert.Equal(
+			t,
+			[]ScoringElement{{Id: "cube", Name: "Cube", Period: TeleopScoringPeriod, Points: 5}},
+			definition.ScoringElements,
+		)
+	}
+
+	_, err = LoadGameDefinition(filepath.Join(dir, "game.txt"))
+	assert.NotNil(t, err)
+	txtPath := filepath.Join(dir, "game.txt")
+	os.WriteFile(txtPath, []byte("name: Test"), 0644)
+	_, err = LoadGameDefinition(txtPath)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "unsupported game definition file type")
+	}
+}
+
+func TestGameDefinitionValidate(t *testing.T) {
+	assert.Nil(t, DefaultGameDefinition().Validate())
+
+	definition := &GameDefinition{}
+	assert.NotNil(t, definition.Validate())
+
+	definition.ScoringElements = []ScoringElement{{Name: "Cube", Period: TeleopScoringPeriod}}
+	if err := definition.Validate(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "missing an ID")
+	}
+
+	definition.ScoringElements = []ScoringElement{
+		{Id: "cube", Period: TeleopScoringPeriod}, {Id: "cube", Period: AutoScoringPeriod},
+	}
+	if err := definition.Validate(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "duplicate scoring element ID")
+	}
+
+	definition.ScoringElements = []ScoringElement{{Id: "cube", Period: "overtime"}}
+	if err := definition.Validate(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "invalid period")
+	}
+}
+
+func TestGameDefinitionRules(t *testing.T) {
+	definition, err := LoadGameDefinition("../games/reefscape_2025.yaml")
+	if assert.Nil(t, err) {
+		assert.Equal(t, FoulPoints{Minor: 2, Major: 6, Tech: 12}, definition.FoulPoints)
+		rule := definition.GetRule(1)
+		if assert.NotNil(t, rule) {
+			assert.Equal(t, "G206", rule.RuleNumber)
+			assert.Equal(t, MinorFoul, rule.FoulType)
+		}
+		assert.Nil(t, definition.GetRule(0))
+	}
+
+	definition = DefaultGameDefinition()
+	definition.Rules = []Rule{{Id: 1, RuleNumber: "G101", FoulType: MinorFoul}, {Id: 1, FoulType: MajorFoul}}
+	if err := definition.Validate(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "duplicate rule ID")
+	}
+	definition.Rules = []Rule{{Id: 0, RuleNumber: "G101", FoulType: MinorFoul}}
+	if err := definition.Validate(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "positive ID")
+	}
+	definition.Rules = []Rule{{Id: 1, RuleNumber: "G101", FoulType: "yellow"}}
+	if err := definition.Validate(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "invalid foul type")
+	}
+	definition.Rules = nil
+	definition.FoulPoints.Tech = -1
+	if err := definition.Validate(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "must not be negative")
+	}
+}
+
+func TestGameDefinitionBonusRankingPoints(t *testing.T) {
+	definition, err := LoadGameDefinition("../games/reefscape_2025.yaml")
+	if assert.Nil(t, err) {
+		assert.Equal(t, 3, definition.WinRankingPoints)
+		assert.Equal(t, 1, definition.TieRankingPoints)
+		rule := definition.GetBonusRankingPoint("coralRp")
+		if assert.NotNil(t, rule) {
+			assert.Equal(t, ElementCountMetric, rule.Metric)
+			assert.Equal(t, 1, rule.Value())
+		}
+		assert.Nil(t, definition.GetBonusRankingPoint("bogus"))
+	}
+
+	// Check that the traditional ranking point values are used when the file doesn't specify them.
+	yamlPath := filepath.Join(t.TempDir(), "game.yaml")
+	os.WriteFile(yamlPath, []byte("name: Test\nscoringElements:\n  - id: cube\n    name: Cube\n    period: teleop\n"+
+		"    points: 5\n"), 0644)
+	definition, err = LoadGameDefinition(yamlPath)
+	if assert.Nil(t, err) {
+		assert.Equal(t, 2, definition.WinRankingPoints)
+		assert.Equal(t, 1, definition.TieRankingPoints)
+		assert.Empty(t, definition.BonusRankingPoints)
+	}
+
+	definition = DefaultGameDefinition()
+	definition.BonusRankingPoints = []BonusRankingPointRule{{Id: "rp", Metric: AutoPointsMetric}}
+	if err := definition.Validate(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "positive threshold")
+	}
+	definition.BonusRankingPoints = []BonusRankingPointRule{{Id: "rp", Metric: "fouls", Threshold: 5}}
+	if err := definition.Validate(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "invalid metric")
+	}
+	definition.BonusRankingPoints = []BonusRankingPointRule{
+		{Id: "rp", Metric: ElementCountMetric, Elements: []string{"cube"}, Threshold: 5},
+	}
+	if err := definition.Validate(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "unknown scoring element 'cube'")
+	}
+	definition.BonusRankingPoints = []BonusRankingPointRule{
+		{Id: "rp", Metric: AutoPointsMetric, Threshold: 5}, {Id: "rp", Metric: EndgamePointsMetric, Threshold: 5},
+	}
+	if err := definition.Validate(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "duplicate bonus ranking point ID")
+	}
+	definition.WinRankingPoints = -1
+	definition.BonusRankingPoints = nil
+	assert.NotNil(t, definition.Validate())
+}
@@ -12,68 +12,63 @@ type MatchSound struct {
 	Timeout       bool
 }
 
-// List of sounds and how many seconds into the match they are played. A negative time indicates that the sound can only
-// be triggered explicitly.
+// List of sounds for the event-wide default timing, used to preload the audio on the displays. A negative time
+// indicates that the sound can only be triggered explicitly.
 var MatchSounds []*MatchSound
 
+// Rebuilds the default list of sounds from the event-wide match timing.
 func UpdateMatchSounds() {
-	MatchSounds = []*MatchSound{
-		{
-			"start",
-			"wav",
-			0,
-			false,
-		},
-		{
-			"end",
-			"wav",
-			float64(MatchTiming.AutoDurationSec),
-			false,
-		},
-		{
-			"resume",
-			"wav",
-			float64(MatchTiming.AutoDurationSec + MatchTiming.PauseDurationSec),
-			false,
-		},
-		{
-			"warning",
-			"wav",
-			float64(
-				MatchTiming.AutoDurationSec + MatchTiming.PauseDurationSec + MatchTiming.TeleopDurationSec -
-					MatchTiming.WarningRemainingDurationSec,
-			),
-			false,
-		},
-		{
-			"end",
-			"wav",
-			float64(MatchTiming.AutoDurationSec + MatchTiming.PauseDurationSec + MatchTiming.TeleopDurationSec),
-			false,
-		},
-		{
+	MatchSounds = MatchTiming.GetSounds()
+}
+
+// Returns the list of sounds built from the sound cues of the match periods and the timeout duration, and how many
+// seconds into the match each is played.
+func (timing *Timing) GetSounds() []*MatchSound {
+	var sounds []*MatchSound
+	for i, period := range timing.Periods {
+		startSec := timing.GetDurationToPeriodStart(i).Seconds()
+		endSec := timing.GetDurationToPeriodEnd(i).Seconds()
+		if period.StartSound != "" {
+			sounds = append(sounds, &MatchSound{period.StartSound, "wav", startSec, false})
+		}
+		if period.WarningSound != "" {
+			sounds = append(
+				sounds,
+				&MatchSound{
+					period.WarningSound, "wav", endSec - float64(period.WarningRemainingDurationSec), false,
+				},
+			)
+		}
+		if period.EndSound != "" {
+			sounds = append(sounds, &MatchSound{period.EndSound, "wav", endSec, false})
+		}
+	}
+
+	return append(
+		sounds,
+		&MatchSound{
 			"timeout_warning",
 			"wav",
-			float64(MatchTiming.TimeoutDurationSec - MatchTiming.TimeoutWarningRemainingDurationSec),
+			float64(timing.TimeoutDurationSec - timing.TimeoutWarningRemainingDurationSec),
 			true,
 		},
-		{
+		&MatchSound{
 			"end",
 			"wav",
-			float64(MatchTiming.TimeoutDurationSec),
+			float64(timing.TimeoutDurationSec),
 			true,
 		},
-		{
+		&MatchSound{
 			"abort",
 			"wav",
 			-1,
 			false,
 		},
-		{
+		&MatchSound{
 			"match_result",
 			"wav",
 			-1,
 			false,
 		},
-	}
+	)
 }
@@ -7,7 +7,23 @@ package game
 
 import "time"
 
-var MatchTiming = struct {
+// A single contiguous period of a match, during which the robots are held in one control mode.
+type MatchPeriod struct {
+	Name        string `yaml:"name"`
+	DurationSec int    `yaml:"durationSec"`
+	Enabled     bool   `yaml:"enabled"`
+	Auto        bool   `yaml:"auto"`
+	// Names of the sounds (in static/audio) to play at the start and end of the period; blank for none.
+	StartSound string `yaml:"startSound"`
+	EndSound   string `yaml:"endSound"`
+	// Sound to play the given number of seconds before the end of the period; blank for none.
+	WarningSound                string `yaml:"warningSound"`
+	WarningRemainingDurationSec int    `yaml:"warningRemainingDurationSec"`
+}
+
+// The durations making up a match or timeout. Each match takes its own copy when it starts so that changes to the
+// settings don't affect a match that is already in progress.
+type Timing struct {
 	WarmupDurationSec                  int
 	AutoDurationSec                    int
 	PauseDurationSec                   int
@@ -15,18 +31,108 @@ var MatchTiming = struct {
 	WarningRemainingDurationSec        int
 	TimeoutDurationSec                 int
 	TimeoutWarningRemainingDurationSec int
-}{0, 15, 2, 135, 30, 0, 60}
+	// Durations by period name overriding those of the game definition's own periods, as set by a timing profile.
+	PeriodDurationSecs map[string]int
+	// Ordered periods making up a match, from which the match flow, sounds, and timer displays are driven.
+	Periods []MatchPeriod
+}
+
+// The event-wide default timing, used for any match type that doesn't have a timing profile assigned.
+var MatchTiming = Timing{
+	AutoDurationSec:                    15,
+	PauseDurationSec:                   2,
+	TeleopDurationSec:                  135,
+	WarningRemainingDurationSec:        30,
+	TimeoutWarningRemainingDurationSec: 60,
+}
+
+// Sets the match periods to those of the given game definition, with any overridden durations substituted in, or if it
+// doesn't define any, to the traditional warmup/auto/pause/teleop sequence built from the individual duration fields.
+// Periods with no duration are omitted.
+func (timing *Timing) UpdatePeriods(definition *GameDefinition) {
+	if len(definition.Periods) > 0 {
+		timing.Periods = nil
+		for _, period := range definition.Periods {
+			if durationSec, ok := timing.PeriodDurationSecs[period.Name]; ok {
+				period.DurationSec = durationSec
+			}
+			if period.DurationSec > 0 {
+				timing.Periods = append(timing.Periods, period)
+			}
+		}
+		return
+	}
+
+	// The start sound marks the start of the match, even if it begins with a warmup.
+	autoStartSound := "start"
+	if timing.WarmupDurationSec > 0 {
+		autoStartSound = ""
+	}
+	periods := []MatchPeriod{
+		{Name: "Warmup", DurationSec: timing.WarmupDurationSec, Auto: true, StartSound: "start"},
+		{
+			Name:        "Autonomous",
+			DurationSec: timing.AutoDurationSec,
+			Enabled:     true,
+			Auto:        true,
+			StartSound:  autoStartSound,
+			EndSound:    "end",
+		},
+		{Name: "Pause", DurationSec: timing.PauseDurationSec},
+		{
+			Name:                        "Teleoperated",
+			DurationSec:                 timing.TeleopDurationSec,
+			Enabled:                     true,
+			StartSound:                  "resume",
+			EndSound:                    "end",
+			WarningSound:                "warning",
+			WarningRemainingDurationSec: timing.WarningRemainingDurationSec,
+		},
+	}
+	timing.Periods = nil
+	for _, period := range periods {
+		if period.DurationSec > 0 {
+			timing.Periods = append(timing.Periods, period)
+		}
+	}
+}
+
+// Returns the time into the match at which the period with the given index starts.
+func (timing *Timing) GetDurationToPeriodStart(index int) time.Duration {
+	var durationSec int
+	for i := 0; i < index && i < len(timing.Periods); i++ {
+		durationSec += timing.Periods[i].DurationSec
+	}
+	return time.Duration(durationSec) * time.Second
+}
+
+// Returns the time into the match at which the period with the given index ends.
+func (timing *Timing) GetDurationToPeriodEnd(index int) time.Duration {
+	return timing.GetDurationToPeriodStart(index + 1)
+}
 
-func GetDurationToAutoEnd() time.Duration {
-	return time.Duration(MatchTiming.WarmupDurationSec+MatchTiming.AutoDurationSec) * time.Second
+// Returns the total length of a match.
+func (timing *Timing) GetDurationToMatchEnd() time.Duration {
+	return timing.GetDurationToPeriodStart(len(timing.Periods))
 }
 
-func GetDurationToTeleopStart() time.Duration {
-	return time.Duration(MatchTiming.WarmupDurationSec+MatchTiming.AutoDurationSec+MatchTiming.PauseDurationSec) *
-		time.Second
+// Returns the index of the period in progress at the given time into the match, or the number of periods if the match
+// is over.
+func (timing *Timing) GetMatchPeriodIndex(matchTimeSec float64) int {
+	for i := range timing.Periods {
+		if matchTimeSec < timing.GetDurationToPeriodEnd(i).Seconds() {
+			return i
+		}
+	}
+	return len(timing.Periods)
 }
 
-func GetDurationToTeleopEnd() time.Duration {
-	return time.Duration(MatchTiming.WarmupDurationSec+MatchTiming.AutoDurationSec+MatchTiming.PauseDurationSec+
-		MatchTiming.TeleopDurationSec) * time.Second
+// Returns the index of the first period at or after the given one in which robots are enabled, or -1 if there is none.
+func (timing *Timing) GetNextEnabledPeriodIndex(index int) int {
+	for i := index; i < len(timing.Periods); i++ {
+		if timing.Periods[i].Enabled {
+			return i
+		}
+	}
+	return -1
 }
@@ -0,0 +1,110 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestUpdateMatchPeriodsFromDurations(t *testing.T) {
+	timing := Timing{
+		AutoDurationSec:                    15,
+		PauseDurationSec:                   2,
+		TeleopDurationSec:                  135,
+		WarningRemainingDurationSec:        30,
+		TimeoutWarningRemainingDurationSec: 60,
+	}
+	timing.UpdatePeriods(DefaultGameDefinition())
+	if assert.Equal(t, 3, len(timing.Periods)) {
+		assert.Equal(t, "Autonomous", timing.Periods[0].Name)
+		assert.Equal(t, "start", timing.Periods[0].StartSound)
+		assert.Equal(t, "Pause", timing.Periods[1].Name)
+		assert.False(t, timing.Periods[1].Enabled)
+		assert.Equal(t, "Teleoperated", timing.Periods[2].Name)
+		assert.Equal(t, 30, timing.Periods[2].WarningRemainingDurationSec)
+	}
+	assert.Equal(t, 15*time.Second, timing.GetDurationToPeriodEnd(0))
+	assert.Equal(t, 17*time.Second, timing.GetDurationToPeriodStart(2))
+	assert.Equal(t, 152*time.Second, timing.GetDurationToMatchEnd())
+	assert.Equal(t, 0, timing.GetMatchPeriodIndex(14.9))
+	assert.Equal(t, 1, timing.GetMatchPeriodIndex(15))
+	assert.Equal(t, 3, timing.GetMatchPeriodIndex(152))
+	assert.Equal(t, 2, timing.GetNextEnabledPeriodIndex(1))
+	assert.Equal(t, -1, timing.GetNextEnabledPeriodIndex(3))
+
+	// Check that the start sound moves to the warmup period if there is one.
+	timing.WarmupDurationSec = 3
+	timing.UpdatePeriods(DefaultGameDefinition())
+	if assert.Equal(t, 4, len(timing.Periods)) {
+		assert.Equal(t, "Warmup", timing.Periods[0].Name)
+		assert.Equal(t, "start", timing.Periods[0].StartSound)
+		assert.Equal(t, "", timing.Periods[1].StartSound)
+	}
+	assert.Equal(t, 155*time.Second, timing.GetDurationToMatchEnd())
+}
+
+func TestUpdateMatchPeriodsFromGameDefinition(t *testing.T) {
+	yamlPath := filepath.Join(t.TempDir(), "game.yaml")
+	os.WriteFile(yamlPath, []byte("name: Test\n"+
+		"scoringElements:\n  - {id: cube, name: Cube, period: teleop, points: 5}\n"+
+		"periods:\n"+
+		"  - {name: Sandstorm, durationSec: 15, enabled: true, auto: true, startSound: start}\n"+
+		"  - {name: Teleop, durationSec: 105, enabled: true, startSound: resume}\n"+
+		"  - {name: Endgame, durationSec: 30, enabled: true, warningSound: warning, warningRemainingDurationSec: 10,"+
+		" endSound: end}\n"), 0644)
+	definition, err := LoadGameDefinition(yamlPath)
+	if !assert.Nil(t, err) {
+		return
+	}
+	timing := Timing{TimeoutWarningRemainingDurationSec: 60}
+	timing.UpdatePeriods(definition)
+	assert.Equal(t, definition.Periods, timing.Periods)
+	assert.Equal(t, 150*time.Second, timing.GetDurationToMatchEnd())
+
+	var matchSounds []MatchSound
+	for _, sound := range timing.GetSounds() {
+		if !sound.Timeout && sound.MatchTimeSec >= 0 {
+			matchSounds = append(matchSounds, *sound)
+		}
+	}
+	assert.Equal(
+		t,
+		[]MatchSound{
+			{"start", "wav", 0, false},
+			{"resume", "wav", 15, false},
+			{"warning", "wav", 140, false},
+			{"end", "wav", 150, false},
+		},
+		matchSounds,
+	)
+
+	// Check that durations overridden by name are substituted in and that periods overridden to zero are omitted.
+	timing.PeriodDurationSecs = map[string]int{"Teleop": 90, "Endgame": 0, "Overtime": 30}
+	timing.UpdatePeriods(definition)
+	if assert.Equal(t, 2, len(timing.Periods)) {
+		assert.Equal(t, "Sandstorm", timing.Periods[0].Name)
+		assert.Equal(t, 15, timing.Periods[0].DurationSec)
+		assert.Equal(t, "Teleop", timing.Periods[1].Name)
+		assert.Equal(t, 90, timing.Periods[1].DurationSec)
+	}
+	assert.Equal(t, 105, definition.Periods[1].DurationSec)
+
+	definition.Periods = []MatchPeriod{{Name: "Auto", DurationSec: 15, Auto: true}}
+	if err := definition.Validate(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "at least one match period")
+	}
+	definition.Periods = []MatchPeriod{{Name: "Teleop", Enabled: true}}
+	if err := definition.Validate(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "positive duration")
+	}
+	definition.Periods = []MatchPeriod{
+		{Name: "Teleop", DurationSec: 20, Enabled: true, WarningRemainingDurationSec: 30},
+	}
+	if err := definition.Validate(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "warning time")
+	}
+}
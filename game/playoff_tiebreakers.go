@@ -0,0 +1,80 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Configurable ordered list of criteria used to decide a playoff match whose final scores are tied.
+
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+var playoffTiebreakerNames = map[TiebreakerCriterion]string{
+	FewestFoulsTiebreaker:   "Fewer foul points",
+	AutoPointsTiebreaker:    "More auto points",
+	TeleopPointsTiebreaker:  "More teleop points",
+	EndgamePointsTiebreaker: "More endgame points",
+}
+
+// Parses a comma-separated list of playoff tiebreak criteria such as "fewestFouls, autoPoints, endgamePoints". Any
+// criterion not among the fixed ones is taken to be the ID of a scoring element, of which more wins. Returns an empty
+// list if the value is blank, meaning that tied playoff matches are always replayed.
+func ParsePlayoffTiebreakers(value string, definition *GameDefinition) ([]TiebreakerCriterion, error) {
+	var tiebreakers []TiebreakerCriterion
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		criterion := TiebreakerCriterion(item)
+		if _, ok := playoffTiebreakerNames[criterion]; !ok && definition.GetScoringElement(item) == nil {
+			return nil, fmt.Errorf("unknown playoff tiebreaker '%s'", item)
+		}
+		tiebreakers = append(tiebreakers, criterion)
+	}
+	return tiebreakers, nil
+}
+
+// Determines the winner of a playoff match, working down the given tiebreak ladder if the final scores are equal.
+// Returns the status along with a description of the criterion that decided the match, which is blank if the scores
+// weren't tied or if every criterion was also tied.
+func DeterminePlayoffMatchStatus(
+	redScore, blueScore *Score, redScoreSummary, blueScoreSummary *ScoreSummary, tiebreakers []TiebreakerCriterion,
+) (MatchStatus, string) {
+	status := DetermineMatchStatus(redScoreSummary, blueScoreSummary)
+	if status != TieMatch {
+		return status, ""
+	}
+
+	for _, criterion := range tiebreakers {
+		var redValue, blueValue int
+		switch criterion {
+		case FewestFoulsTiebreaker:
+			// Each alliance's fouls are credited to its opponent, so the one that received more committed fewer.
+			redValue, blueValue = redScoreSummary.FoulPoints, blueScoreSummary.FoulPoints
+		case AutoPointsTiebreaker:
+			redValue, blueValue = redScoreSummary.AutoPoints, blueScoreSummary.AutoPoints
+		case TeleopPointsTiebreaker:
+			redValue, blueValue = redScoreSummary.TeleopPoints, blueScoreSummary.TeleopPoints
+		case EndgamePointsTiebreaker:
+			redValue, blueValue = redScoreSummary.EndgamePoints, blueScoreSummary.EndgamePoints
+		default:
+			redValue, blueValue = redScore.ElementCounts[string(criterion)], blueScore.ElementCounts[string(criterion)]
+		}
+		if status = comparePoints(redValue, blueValue); status != TieMatch {
+			return status, playoffTiebreakerName(criterion)
+		}
+	}
+	return TieMatch, ""
+}
+
+// Returns a human-readable description of the given playoff tiebreak criterion.
+func playoffTiebreakerName(criterion TiebreakerCriterion) string {
+	if name, ok := playoffTiebreakerNames[criterion]; ok {
+		return name
+	}
+	if element := CurrentGame.GetScoringElement(string(criterion)); element != nil {
+		return "More " + element.Name
+	}
+	return "More " + string(criterion)
+}
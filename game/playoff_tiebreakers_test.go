@@ -0,0 +1,57 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParsePlayoffTiebreakers(t *testing.T) {
+	tiebreakers, err := ParsePlayoffTiebreakers("", DefaultGameDefinition())
+	assert.Nil(t, err)
+	assert.Empty(t, tiebreakers)
+
+	tiebreakers, err = ParsePlayoffTiebreakers("fewestFouls, endgame ,autoPoints", DefaultGameDefinition())
+	assert.Nil(t, err)
+	assert.Equal(t, []TiebreakerCriterion{FewestFoulsTiebreaker, "endgame", AutoPointsTiebreaker}, tiebreakers)
+
+	_, err = ParsePlayoffTiebreakers("fewestFouls, headToHead", DefaultGameDefinition())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "unknown playoff tiebreaker 'headToHead'")
+	}
+}
+
+func TestDeterminePlayoffMatchStatus(t *testing.T) {
+	redScore := &Score{ElementCounts: map[string]int{"auto": 10, "teleop": 30, "endgame": 5}}
+	blueScore := &Score{ElementCounts: map[string]int{"auto": 10, "teleop": 25, "endgame": 10}}
+	tiebreakers := []TiebreakerCriterion{FewestFoulsTiebreaker, AutoPointsTiebreaker, "endgame"}
+
+	status, reason := DeterminePlayoffMatchStatus(
+		redScore, blueScore, redScore.Summarize(blueScore), blueScore.Summarize(redScore), tiebreakers,
+	)
+	assert.Equal(t, BlueWonMatch, status)
+	assert.Equal(t, "More Endgame", reason)
+
+	// Fouls committed by blue are credited to red, giving red the edge on the first criterion.
+	redScore.ElementCounts["teleop"] -= 2
+	blueScore.Fouls = []Foul{{Type: MinorFoul}}
+	status, reason = DeterminePlayoffMatchStatus(
+		redScore, blueScore, redScore.Summarize(blueScore), blueScore.Summarize(redScore), tiebreakers,
+	)
+	assert.Equal(t, RedWonMatch, status)
+	assert.Equal(t, "Fewer foul points", reason)
+
+	// Untied matches and matches that remain tied after all criteria have no reason.
+	status, reason = DeterminePlayoffMatchStatus(
+		redScore, blueScore, redScore.Summarize(blueScore), blueScore.Summarize(redScore), nil,
+	)
+	assert.Equal(t, TieMatch, status)
+	assert.Equal(t, "", reason)
+	redScore.ElementCounts["teleop"]++
+	status, reason = DeterminePlayoffMatchStatus(
+		redScore, blueScore, redScore.Summarize(blueScore), blueScore.Summarize(redScore), tiebreakers,
+	)
+	assert.Equal(t, RedWonMatch, status)
+	assert.Equal(t, "", reason)
+}
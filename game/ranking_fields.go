@@ -8,15 +8,20 @@ package game
 import "math/rand"
 
 type RankingFields struct {
-	RankingPoints int
-	AutoPoints    int
-	EndgamePoints int
-	TeleopPoints  int
-	Random        float64
-	Wins          int
-	Losses        int
-	Ties          int
-	Played        int
+	RankingPoints      int
+	BonusRankingPoints int
+	AutoPoints         int
+	EndgamePoints      int
+	TeleopPoints       int
+	Random             float64
+	Wins               int
+	Losses             int
+	Ties               int
+	Disqualifications  int
+	Played             int
+	ConcededFoulPoints int
+	// Number of times each scoring element was achieved, summed over all matches; used for element tiebreakers.
+	ElementCounts map[string]int
 }
 
 type Ranking struct {
@@ -24,31 +29,52 @@ type Ranking struct {
 	Rank         int
 	PreviousRank int
 	RankingFields
+	// The tiebreaker criterion that separated this team from the team ranked immediately below it.
+	Tiebreaker string
 }
 
 type Rankings []Ranking
 
-func (fields *RankingFields) AddScoreSummary(ownScore *ScoreSummary, opponentScore *ScoreSummary) {
+func (fields *RankingFields) AddScoreSummary(ownScore *ScoreSummary, opponentScore *ScoreSummary, disqualified bool) {
 	fields.Played += 1
 
 	// Store a random value to be used as the last tiebreaker if necessary.
 	fields.Random = rand.Float64()
 
+	if disqualified {
+		// Don't award any points.
+		fields.Disqualifications += 1
+		return
+	}
+
 	// Assign ranking points and wins/losses/ties.
 	if ownScore.Score > opponentScore.Score {
-		fields.RankingPoints += 2
+		fields.RankingPoints += CurrentGame.WinRankingPoints
 		fields.Wins += 1
 	} else if ownScore.Score == opponentScore.Score {
-		fields.RankingPoints += 1
+		fields.RankingPoints += CurrentGame.TieRankingPoints
 		fields.Ties += 1
 	} else {
 		fields.Losses += 1
 	}
+	fields.RankingPoints += ownScore.BonusRankingPoints
+	fields.BonusRankingPoints += ownScore.BonusRankingPoints
 
 	// Assign tiebreaker points.
 	fields.AutoPoints += ownScore.AutoPoints
 	fields.EndgamePoints += ownScore.EndgamePoints
 	fields.TeleopPoints += ownScore.TeleopPoints
+	fields.ConcededFoulPoints += opponentScore.FoulPoints
+}
+
+// Accumulates the given scoring element counts for use as tiebreakers.
+func (fields *RankingFields) AddElementCounts(elementCounts map[string]int) {
+	if fields.ElementCounts == nil {
+		fields.ElementCounts = make(map[string]int)
+	}
+	for id, count := range elementCounts {
+		fields.ElementCounts[id] += count
+	}
 }
 
 // Helper function to implement the required interface for Sort.
@@ -56,25 +82,9 @@ func (rankings Rankings) Len() int {
 	return len(rankings)
 }
 
-// Helper function to implement the required interface for Sort.
+// Helper function to implement the required interface for Sort. Uses the default tiebreakers.
 func (rankings Rankings) Less(i, j int) bool {
-	a := rankings[i]
-	b := rankings[j]
-
-	// Use cross-multiplication to keep it in integer math.
-	if a.RankingPoints*b.Played == b.RankingPoints*a.Played {
-		if a.AutoPoints*b.Played == b.AutoPoints*a.Played {
-			if a.EndgamePoints*b.Played == b.EndgamePoints*a.Played {
-				if a.TeleopPoints*b.Played == b.TeleopPoints*a.Played {
-					return a.Random > b.Random
-				}
-				return a.TeleopPoints*b.Played > b.TeleopPoints*a.Played
-			}
-			return a.EndgamePoints*b.Played > b.EndgamePoints*a.Played
-		}
-		return a.AutoPoints*b.Played > b.AutoPoints*a.Played
-	}
-	return a.RankingPoints*b.Played > b.RankingPoints*a.Played
+	return compareRankings(&rankings[i], &rankings[j], DefaultTiebreakers) > 0
 }
 
 // Helper function to implement the required interface for Sort.
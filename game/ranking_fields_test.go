@@ -14,36 +14,44 @@ func TestAddScoreSummary(t *testing.T) {
 	rand.Seed(0)
 	redScore := TestScore1()
 	blueScore := TestScore2()
-	redSummary := redScore.Summarize()
-	blueSummary := blueScore.Summarize()
+	redSummary := redScore.Summarize(blueScore)
+	blueSummary := blueScore.Summarize(redScore)
 	rankingFields := RankingFields{}
 
 	// Add a loss.
-	rankingFields.AddScoreSummary(redSummary, blueSummary)
-	assert.Equal(t, RankingFields{2, 45, 30, 80, 0.9451961492941164, 1, 0, 0, 1}, rankingFields)
+	rankingFields.AddScoreSummary(redSummary, blueSummary, false)
+	assert.Equal(t, RankingFields{2, 0, 45, 30, 80, 0.9451961492941164, 1, 0, 0, 0, 1, 0, nil}, rankingFields)
 
 	// Add a win.
-	rankingFields.AddScoreSummary(blueSummary, redSummary)
-	assert.Equal(t, RankingFields{2, 60, 55, 120, 0.24496508529377975, 1, 1, 0, 2}, rankingFields)
+	rankingFields.AddScoreSummary(blueSummary, redSummary, false)
+	assert.Equal(t, RankingFields{2, 0, 60, 55, 120, 0.24496508529377975, 1, 1, 0, 0, 2, 0, nil}, rankingFields)
 
 	// Add a tie.
-	rankingFields.AddScoreSummary(redSummary, redSummary)
-	assert.Equal(t, RankingFields{3, 105, 85, 200, 0.6559562651954052, 1, 1, 1, 3}, rankingFields)
+	rankingFields.AddScoreSummary(redSummary, redSummary, false)
+	assert.Equal(t, RankingFields{3, 0, 105, 85, 200, 0.6559562651954052, 1, 1, 1, 0, 3, 0, nil}, rankingFields)
+
+	// Add a disqualification, which shouldn't award any points or count as a win.
+	rankingFields.AddScoreSummary(redSummary, blueSummary, true)
+	assert.Equal(t, 3, rankingFields.RankingPoints)
+	assert.Equal(t, 105, rankingFields.AutoPoints)
+	assert.Equal(t, 1, rankingFields.Wins)
+	assert.Equal(t, 1, rankingFields.Disqualifications)
+	assert.Equal(t, 4, rankingFields.Played)
 }
 
 func TestSortRankings(t *testing.T) {
 	// Check tiebreakers.
 	rankings := make(Rankings, 10)
-	rankings[0] = Ranking{1, 0, 0, RankingFields{50, 50, 50, 50, 0.49, 3, 2, 1, 10}}
-	rankings[1] = Ranking{2, 0, 0, RankingFields{50, 50, 50, 50, 0.51, 3, 2, 1, 10}}
-	rankings[2] = Ranking{3, 0, 0, RankingFields{50, 50, 50, 49, 0.50, 3, 2, 1, 10}}
-	rankings[3] = Ranking{4, 0, 0, RankingFields{50, 50, 50, 51, 0.50, 3, 2, 1, 10}}
-	rankings[4] = Ranking{5, 0, 0, RankingFields{50, 50, 49, 50, 0.50, 3, 2, 1, 10}}
-	rankings[5] = Ranking{6, 0, 0, RankingFields{50, 50, 51, 50, 0.50, 3, 2, 1, 10}}
-	rankings[6] = Ranking{7, 0, 0, RankingFields{50, 49, 50, 50, 0.50, 3, 2, 1, 10}}
-	rankings[7] = Ranking{8, 0, 0, RankingFields{50, 51, 50, 50, 0.50, 3, 2, 1, 10}}
-	rankings[8] = Ranking{9, 0, 0, RankingFields{49, 50, 50, 50, 0.50, 3, 2, 1, 10}}
-	rankings[9] = Ranking{10, 0, 0, RankingFields{51, 50, 50, 50, 0.50, 3, 2, 1, 10}}
+	rankings[0] = Ranking{1, 0, 0, RankingFields{50, 0, 50, 50, 50, 0.49, 3, 2, 1, 0, 10, 0, nil}, ""}
+	rankings[1] = Ranking{2, 0, 0, RankingFields{50, 0, 50, 50, 50, 0.51, 3, 2, 1, 0, 10, 0, nil}, ""}
+	rankings[2] = Ranking{3, 0, 0, RankingFields{50, 0, 50, 50, 49, 0.50, 3, 2, 1, 0, 10, 0, nil}, ""}
+	rankings[3] = Ranking{4, 0, 0, RankingFields{50, 0, 50, 50, 51, 0.50, 3, 2, 1, 0, 10, 0, nil}, ""}
+	rankings[4] = Ranking{5, 0, 0, RankingFields{50, 0, 50, 49, 50, 0.50, 3, 2, 1, 0, 10, 0, nil}, ""}
+	rankings[5] = Ranking{6, 0, 0, RankingFields{50, 0, 50, 51, 50, 0.50, 3, 2, 1, 0, 10, 0, nil}, ""}
+	rankings[6] = Ranking{7, 0, 0, RankingFields{50, 0, 49, 50, 50, 0.50, 3, 2, 1, 0, 10, 0, nil}, ""}
+	rankings[7] = Ranking{8, 0, 0, RankingFields{50, 0, 51, 50, 50, 0.50, 3, 2, 1, 0, 10, 0, nil}, ""}
+	rankings[8] = Ranking{9, 0, 0, RankingFields{49, 0, 50, 50, 50, 0.50, 3, 2, 1, 0, 10, 0, nil}, ""}
+	rankings[9] = Ranking{10, 0, 0, RankingFields{51, 0, 50, 50, 50, 0.50, 3, 2, 1, 0, 10, 0, nil}, ""}
 	sort.Sort(rankings)
 	assert.Equal(t, 10, rankings[0].TeamId)
 	assert.Equal(t, 8, rankings[1].TeamId)
@@ -58,11 +66,47 @@ func TestSortRankings(t *testing.T) {
 
 	// Check with unequal number of matches played.
 	rankings = make(Rankings, 3)
-	rankings[0] = Ranking{1, 0, 0, RankingFields{10, 25, 25, 25, 0.49, 3, 2, 1, 5}}
-	rankings[1] = Ranking{2, 0, 0, RankingFields{19, 50, 50, 50, 0.51, 3, 2, 1, 9}}
-	rankings[2] = Ranking{3, 0, 0, RankingFields{20, 50, 50, 50, 0.51, 3, 2, 1, 10}}
+	rankings[0] = Ranking{1, 0, 0, RankingFields{10, 0, 25, 25, 25, 0.49, 3, 2, 1, 0, 5, 0, nil}, ""}
+	rankings[1] = Ranking{2, 0, 0, RankingFields{19, 0, 50, 50, 50, 0.51, 3, 2, 1, 0, 9, 0, nil}, ""}
+	rankings[2] = Ranking{3, 0, 0, RankingFields{20, 0, 50, 50, 50, 0.51, 3, 2, 1, 0, 10, 0, nil}, ""}
 	sort.Sort(rankings)
 	assert.Equal(t, 2, rankings[0].TeamId)
 	assert.Equal(t, 3, rankings[1].TeamId)
 	assert.Equal(t, 1, rankings[2].TeamId)
 }
+
+func TestAddScoreSummaryBonusRankingPoints(t *testing.T) {
+	CurrentGame = DefaultGameDefinition()
+	CurrentGame.WinRankingPoints = 3
+	CurrentGame.BonusRankingPoints = []BonusRankingPointRule{
+		{Id: "autoRp", Name: "Auto RP", Metric: AutoPointsMetric, Threshold: 40},
+		{Id: "endgameRp", Name: "Endgame RP", Metric: EndgamePointsMetric, Threshold: 30, RankingPoints: 2},
+		{Id: "countRp", Name: "Count RP", Metric: ElementCountMetric, Elements: []string{"auto", "teleop"},
+			Threshold: 100},
+	}
+	defer func() { CurrentGame = DefaultGameDefinition() }()
+
+	redScore := TestScore1()
+	blueScore := TestScore2()
+	redSummary := redScore.Summarize(blueScore)
+	blueSummary := blueScore.Summarize(redScore)
+	assert.Equal(t, []string{"autoRp", "endgameRp", "countRp"}, redSummary.BonusRankingPointIds)
+	assert.Equal(t, 4, redSummary.BonusRankingPoints)
+	assert.Empty(t, blueSummary.BonusRankingPointIds)
+	assert.Equal(t, 0, blueSummary.BonusRankingPoints)
+	if rules := redSummary.BonusRankingPointRules(); assert.Equal(t, 3, len(rules)) {
+		assert.Equal(t, "Endgame RP", rules[1].Name)
+	}
+
+	rankingFields := RankingFields{}
+	rankingFields.AddScoreSummary(redSummary, blueSummary, false)
+	assert.Equal(t, 7, rankingFields.RankingPoints)
+	assert.Equal(t, 4, rankingFields.BonusRankingPoints)
+
+	// A loss without any bonuses adds nothing, and a disqualification forfeits any bonuses earned.
+	rankingFields.AddScoreSummary(blueSummary, redSummary, false)
+	assert.Equal(t, 7, rankingFields.RankingPoints)
+	rankingFields.AddScoreSummary(redSummary, blueSummary, true)
+	assert.Equal(t, 7, rankingFields.RankingPoints)
+	assert.Equal(t, 4, rankingFields.BonusRankingPoints)
+}
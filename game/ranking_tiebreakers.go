@@ -0,0 +1,278 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Configurable ordered list of criteria by which qualification rankings are sorted and ties are broken.
+
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type TiebreakerCriterion string
+
+const (
+	RankingPointsTiebreaker      TiebreakerCriterion = "rankingPoints"
+	BonusRankingPointsTiebreaker TiebreakerCriterion = "bonusRankingPoints"
+	AutoPointsTiebreaker         TiebreakerCriterion = "autoPoints"
+	TeleopPointsTiebreaker       TiebreakerCriterion = "teleopPoints"
+	EndgamePointsTiebreaker      TiebreakerCriterion = "endgamePoints"
+	WinsTiebreaker               TiebreakerCriterion = "wins"
+	FewestFoulsTiebreaker        TiebreakerCriterion = "fewestFouls"
+	HeadToHeadTiebreaker         TiebreakerCriterion = "headToHead"
+)
+
+// Recorded on a ranking when all configured criteria were equal and the random value separated the teams.
+const RandomTiebreakerName = "Random"
+
+var tiebreakerNames = map[TiebreakerCriterion]string{
+	RankingPointsTiebreaker:      "RP",
+	BonusRankingPointsTiebreaker: "Bonus RP",
+	AutoPointsTiebreaker:         "Auto",
+	TeleopPointsTiebreaker:       "Teleop",
+	EndgamePointsTiebreaker:      "Endgame",
+	WinsTiebreaker:               "Wins",
+	FewestFoulsTiebreaker:        "Fouls",
+	HeadToHeadTiebreaker:         "Head-to-Head",
+}
+
+// A single ranking criterion. Any criterion not in the list of constants above is taken to be the ID of a scoring
+// element of the current game definition.
+type Tiebreaker struct {
+	Criterion TiebreakerCriterion
+	// Whether to compare totals rather than per-match averages. Doesn't apply to the head-to-head criterion.
+	Total bool
+}
+
+// The sort order used when the event doesn't configure one.
+var DefaultTiebreakers = []Tiebreaker{
+	{Criterion: RankingPointsTiebreaker},
+	{Criterion: AutoPointsTiebreaker},
+	{Criterion: EndgamePointsTiebreaker},
+	{Criterion: TeleopPointsTiebreaker},
+}
+
+// Number of qualification matches in which each team beat each other team, keyed by winner and then loser.
+type HeadToHeadRecords map[int]map[int]int
+
+// Records a match in which the given winning team was on the opposite alliance from the given losing team.
+func (records HeadToHeadRecords) AddWin(winnerTeamId, loserTeamId int) {
+	if records[winnerTeamId] == nil {
+		records[winnerTeamId] = make(map[int]int)
+	}
+	records[winnerTeamId][loserTeamId]++
+}
+
+// Parses a comma-separated list of criteria such as "rankingPoints, total:autoPoints, headToHead". Criteria are
+// compared as per-match averages unless prefixed with "total:". Returns the default tiebreakers if the list is blank.
+func ParseTiebreakers(value string, definition *GameDefinition) ([]Tiebreaker, error) {
+	var tiebreakers []Tiebreaker
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		var tiebreaker Tiebreaker
+		if strings.HasPrefix(item, "total:") {
+			tiebreaker.Total = true
+			item = strings.TrimPrefix(item, "total:")
+		} else {
+			item = strings.TrimPrefix(item, "avg:")
+		}
+		tiebreaker.Criterion = TiebreakerCriterion(item)
+
+		if tiebreaker.Criterion == HeadToHeadTiebreaker && tiebreaker.Total {
+			return nil, fmt.Errorf("tiebreaker '%s' can't be totaled", item)
+		}
+		if _, ok := tiebreakerNames[tiebreaker.Criterion]; !ok && definition.GetScoringElement(item) == nil {
+			return nil, fmt.Errorf("unknown tiebreaker '%s'", item)
+		}
+		tiebreakers = append(tiebreakers, tiebreaker)
+	}
+
+	if len(tiebreakers) == 0 {
+		return DefaultTiebreakers, nil
+	}
+	return tiebreakers, nil
+}
+
+// Returns a short human-readable description of the tiebreaker, for use in reports.
+func (tiebreaker Tiebreaker) DisplayName() string {
+	name, ok := tiebreakerNames[tiebreaker.Criterion]
+	if !ok {
+		name = string(tiebreaker.Criterion)
+		if element := CurrentGame.GetScoringElement(name); element != nil {
+			name = element.Name
+		}
+	}
+	if tiebreaker.Criterion == HeadToHeadTiebreaker {
+		return name
+	}
+	if tiebreaker.Total {
+		return "Total " + name
+	}
+	return "Avg " + name
+}
+
+// Sorts the rankings according to the given criteria and records on each ranking the criterion that separated it from
+// the next-ranked team.
+func SortRankings(rankings Rankings, tiebreakers []Tiebreaker, headToHead HeadToHeadRecords) {
+	for i := range rankings {
+		rankings[i].Tiebreaker = ""
+	}
+	sortTiedRankings(rankings, tiebreakers, 0, headToHead)
+}
+
+// Sorts the given rankings, which are all tied on the criteria before the given index, by the remaining criteria and
+// records the separating criterion on each but the last. The name is blank for the first criterion, since teams
+// separated by it weren't tied.
+func sortTiedRankings(rankings Rankings, tiebreakers []Tiebreaker, index int, headToHead HeadToHeadRecords) {
+	if len(rankings) < 2 {
+		return
+	}
+	if index == len(tiebreakers) {
+		sort.SliceStable(rankings, func(i, j int) bool {
+			return rankings[i].Random > rankings[j].Random
+		})
+		for i := 0; i < len(rankings)-1; i++ {
+			rankings[i].Tiebreaker = RandomTiebreakerName
+		}
+		return
+	}
+
+	tiebreaker := tiebreakers[index]
+	var groupSizes []int
+	if tiebreaker.Criterion == HeadToHeadTiebreaker {
+		groupSizes = sortByHeadToHead(rankings, headToHead)
+	} else {
+		sort.SliceStable(rankings, func(i, j int) bool {
+			return tiebreaker.compare(&rankings[i], &rankings[j]) > 0
+		})
+		groupSizes = []int{1}
+		for i := 1; i < len(rankings); i++ {
+			if tiebreaker.compare(&rankings[i-1], &rankings[i]) == 0 {
+				groupSizes[len(groupSizes)-1]++
+			} else {
+				groupSizes = append(groupSizes, 1)
+			}
+		}
+	}
+
+	name := ""
+	if index > 0 {
+		name = tiebreaker.DisplayName()
+	}
+	groupStart := 0
+	for _, groupSize := range groupSizes {
+		sortTiedRankings(rankings[groupStart:groupStart+groupSize], tiebreakers, index+1, headToHead)
+		groupStart += groupSize
+		if groupStart < len(rankings) {
+			rankings[groupStart-1].Tiebreaker = name
+		}
+	}
+}
+
+// Sorts the given tied rankings by the number of other teams in the group that each has the better head-to-head record
+// against, and returns the sizes of the groups of teams that remain tied. Since head-to-head records needn't be
+// transitive, the whole group is left tied unless each team beat every team sorted below it and drew with those level
+// with it; a cycle such as A beating B, B beating C and C beating A falls through to the next criterion.
+func sortByHeadToHead(rankings Rankings, headToHead HeadToHeadRecords) []int {
+	beats := func(a, b *Ranking) bool {
+		return headToHead[a.TeamId][b.TeamId] > headToHead[b.TeamId][a.TeamId]
+	}
+	numBeaten := make(map[int]int)
+	for i := range rankings {
+		for j := range rankings {
+			if i != j && beats(&rankings[i], &rankings[j]) {
+				numBeaten[rankings[i].TeamId]++
+			}
+		}
+	}
+	sort.SliceStable(rankings, func(i, j int) bool {
+		return numBeaten[rankings[i].TeamId] > numBeaten[rankings[j].TeamId]
+	})
+
+	groupSizes := []int{1}
+	for i := range rankings {
+		for j := i + 1; j < len(rankings); j++ {
+			level := numBeaten[rankings[i].TeamId] == numBeaten[rankings[j].TeamId]
+			if level && (beats(&rankings[i], &rankings[j]) || beats(&rankings[j], &rankings[i])) ||
+				!level && !beats(&rankings[i], &rankings[j]) {
+				return []int{len(rankings)}
+			}
+		}
+		if i > 0 {
+			if numBeaten[rankings[i-1].TeamId] == numBeaten[rankings[i].TeamId] {
+				groupSizes[len(groupSizes)-1]++
+			} else {
+				groupSizes = append(groupSizes, 1)
+			}
+		}
+	}
+	return groupSizes
+}
+
+// Returns a positive number if a should be ranked ahead of b and a negative number otherwise. The head-to-head
+// criterion is skipped, since it can only be applied to a whole group of tied teams.
+func compareRankings(a, b *Ranking, tiebreakers []Tiebreaker) int {
+	for _, tiebreaker := range tiebreakers {
+		if tiebreaker.Criterion == HeadToHeadTiebreaker {
+			continue
+		}
+		if result := tiebreaker.compare(a, b); result != 0 {
+			return result
+		}
+	}
+	if a.Random > b.Random {
+		return 1
+	}
+	return -1
+}
+
+// Returns a positive number if a is ahead of b on this criterion, a negative number if b is ahead, or zero if tied.
+// Doesn't apply to the head-to-head criterion.
+func (tiebreaker Tiebreaker) compare(a, b *Ranking) int {
+	aValue := tiebreaker.value(&a.RankingFields)
+	bValue := tiebreaker.value(&b.RankingFields)
+	if tiebreaker.Total {
+		return aValue - bValue
+	}
+	// Use cross-multiplication to keep it in integer math.
+	return aValue*b.Played - bValue*a.Played
+}
+
+// Returns the value of the criterion for the given fields as shown in reports: a per-match average unless totaled, with
+// fouls given as the points conceded. Doesn't apply to the head-to-head criterion.
+func (tiebreaker Tiebreaker) DisplayValue(fields *RankingFields) float64 {
+	value := float64(tiebreaker.value(fields))
+	if tiebreaker.Criterion == FewestFoulsTiebreaker {
+		value = -value
+	}
+	if !tiebreaker.Total && fields.Played > 0 {
+		value /= float64(fields.Played)
+	}
+	return value
+}
+
+// Returns the value of the criterion for the given fields, oriented such that a higher value is better.
+func (tiebreaker Tiebreaker) value(fields *RankingFields) int {
+	switch tiebreaker.Criterion {
+	case RankingPointsTiebreaker:
+		return fields.RankingPoints
+	case BonusRankingPointsTiebreaker:
+		return fields.BonusRankingPoints
+	case AutoPointsTiebreaker:
+		return fields.AutoPoints
+	case TeleopPointsTiebreaker:
+		return fields.TeleopPoints
+	case EndgamePointsTiebreaker:
+		return fields.EndgamePoints
+	case WinsTiebreaker:
+		return fields.Wins
+	case FewestFoulsTiebreaker:
+		return -fields.ConcededFoulPoints
+	}
+	return fields.ElementCounts[string(tiebreaker.Criterion)]
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseTiebreakers(t *testing.T) {
+	tiebreakers, err := ParseTiebreakers("  ", DefaultGameDefinition())
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultTiebreakers, tiebreakers)
+
+	tiebreakers, err = ParseTiebreakers(
+		"rankingPoints, total:autoPoints,avg:teleop, headToHead,", DefaultGameDefinition(),
+	)
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		[]Tiebreaker{
+			{RankingPointsTiebreaker, false},
+			{AutoPointsTiebreaker, true},
+			{"teleop", false},
+			{HeadToHeadTiebreaker, false},
+		},
+		tiebreakers,
+	)
+	assert.Equal(t, "Total Auto", tiebreakers[1].DisplayName())
+	assert.Equal(t, "Avg Teleop", tiebreakers[2].DisplayName())
+	assert.Equal(t, "Head-to-Head", tiebreakers[3].DisplayName())
+
+	_, err = ParseTiebreakers("rankingPoints, coral", DefaultGameDefinition())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "unknown tiebreaker 'coral'")
+	}
+	_, err = ParseTiebreakers("total:headToHead", DefaultGameDefinition())
+	assert.NotNil(t, err)
+}
+
+func TestSortRankingsWithTiebreakers(t *testing.T) {
+	rankings := Rankings{
+		{TeamId: 1, RankingFields: RankingFields{RankingPoints: 20, Wins: 5, ConcededFoulPoints: 10, Played: 10}},
+		{TeamId: 2, RankingFields: RankingFields{RankingPoints: 20, Wins: 5, ConcededFoulPoints: 4, Played: 10}},
+		{TeamId: 3, RankingFields: RankingFields{RankingPoints: 20, Wins: 6, ConcededFoulPoints: 10, Played: 10}},
+		{TeamId: 4, RankingFields: RankingFields{RankingPoints: 20, Wins: 5, ConcededFoulPoints: 10, Played: 10}},
+		{TeamId: 5, RankingFields: RankingFields{RankingPoints: 21, Wins: 5, ConcededFoulPoints: 10, Played: 11}},
+		{TeamId: 6, RankingFields: RankingFields{RankingPoints: 19, Wins: 6, ConcededFoulPoints: 0, Played: 10}},
+	}
+	headToHead := make(HeadToHeadRecords)
+	headToHead.AddWin(4, 1)
+
+	tiebreakers := []Tiebreaker{
+		{Criterion: RankingPointsTiebreaker, Total: true},
+		{Criterion: WinsTiebreaker},
+		{Criterion: FewestFoulsTiebreaker},
+		{Criterion: HeadToHeadTiebreaker},
+	}
+	SortRankings(rankings, tiebreakers, headToHead)
+	var teamIds []int
+	var tiebreakerNames []string
+	for _, ranking := range rankings {
+		teamIds = append(teamIds, ranking.TeamId)
+		tiebreakerNames = append(tiebreakerNames, ranking.Tiebreaker)
+	}
+	assert.Equal(t, []int{5, 3, 2, 4, 1, 6}, teamIds)
+	assert.Equal(t, []string{"", "Avg Wins", "Avg Fouls", "Head-to-Head", "", ""}, tiebreakerNames)
+
+	// Averaging the ranking points instead should penalize the team that has played an extra match.
+	tiebreakers[0].Total = false
+	SortRankings(rankings, tiebreakers, headToHead)
+	assert.Equal(t, 3, rankings[0].TeamId)
+	assert.Equal(t, 5, rankings[4].TeamId)
+	assert.Equal(t, "", rankings[4].Tiebreaker)
+}
+
+func TestSortRankingsByHeadToHead(t *testing.T) {
+	newRankings := func() Rankings {
+		return Rankings{
+			{TeamId: 1, RankingFields: RankingFields{RankingPoints: 10, Wins: 1, Played: 5, Random: 0.1}},
+			{TeamId: 2, RankingFields: RankingFields{RankingPoints: 10, Wins: 2, Played: 5, Random: 0.2}},
+			{TeamId: 3, RankingFields: RankingFields{RankingPoints: 10, Wins: 3, Played: 5, Random: 0.3}},
+			{TeamId: 4, RankingFields: RankingFields{RankingPoints: 12, Wins: 0, Played: 5, Random: 0.4}},
+		}
+	}
+	tiebreakers := []Tiebreaker{
+		{Criterion: RankingPointsTiebreaker}, {Criterion: HeadToHeadTiebreaker}, {Criterion: WinsTiebreaker},
+	}
+	sortedTeamIds := func(rankings Rankings) []int {
+		var teamIds []int
+		for _, ranking := range rankings {
+			teamIds = append(teamIds, ranking.TeamId)
+		}
+		return teamIds
+	}
+
+	// Head-to-head is only applied within the group tied on ranking points, where it gives a consistent order.
+	headToHead := make(HeadToHeadRecords)
+	headToHead.AddWin(1, 2)
+	headToHead.AddWin(1, 3)
+	headToHead.AddWin(2, 3)
+	headToHead.AddWin(3, 4)
+	rankings := newRankings()
+	SortRankings(rankings, tiebreakers, headToHead)
+	assert.Equal(t, []int{4, 1, 2, 3}, sortedTeamIds(rankings))
+	assert.Equal(t, "", rankings[0].Tiebreaker)
+	assert.Equal(t, "Head-to-Head", rankings[1].Tiebreaker)
+	assert.Equal(t, "Head-to-Head", rankings[2].Tiebreaker)
+
+	// A cycle falls through to the next criterion for the whole group, regardless of the input order.
+	headToHead = make(HeadToHeadRecords)
+	headToHead.AddWin(1, 2)
+	headToHead.AddWin(2, 3)
+	headToHead.AddWin(3, 1)
+	for _, order := range [][]int{{0, 1, 2, 3}, {3, 2, 1, 0}, {1, 3, 0, 2}} {
+		unsortedRankings := newRankings()
+		rankings = make(Rankings, len(order))
+		for i, index := range order {
+			rankings[i] = unsortedRankings[index]
+		}
+		SortRankings(rankings, tiebreakers, headToHead)
+		assert.Equal(t, []int{4, 3, 2, 1}, sortedTeamIds(rankings))
+		assert.Equal(t, "Avg Wins", rankings[1].Tiebreaker)
+		assert.Equal(t, "Avg Wins", rankings[2].Tiebreaker)
+	}
+
+	// Teams level on head-to-head within the group are left tied for the next criterion.
+	headToHead = make(HeadToHeadRecords)
+	headToHead.AddWin(1, 2)
+	headToHead.AddWin(1, 3)
+	rankings = newRankings()
+	SortRankings(rankings, tiebreakers, headToHead)
+	assert.Equal(t, []int{4, 1, 3, 2}, sortedTeamIds(rankings))
+	assert.Equal(t, "Head-to-Head", rankings[1].Tiebreaker)
+	assert.Equal(t, "Avg Wins", rankings[2].Tiebreaker)
+}
+
+func TestSortRankingsByElementCount(t *testing.T) {
+	rankings := Rankings{
+		{TeamId: 1, RankingFields: RankingFields{Played: 2, Random: 0.9, ElementCounts: map[string]int{"auto": 3}}},
+		{TeamId: 2, RankingFields: RankingFields{Played: 2, Random: 0.1, ElementCounts: map[string]int{"auto": 5}}},
+		{TeamId: 3, RankingFields: RankingFields{Played: 2, Random: 0.5}},
+		{TeamId: 4, RankingFields: RankingFields{Played: 2, Random: 0.2}},
+	}
+	SortRankings(rankings, []Tiebreaker{{Criterion: RankingPointsTiebreaker}, {Criterion: "auto"}}, nil)
+	assert.Equal(t, 2, rankings[0].TeamId)
+	assert.Equal(t, "Avg Auto", rankings[0].Tiebreaker)
+	assert.Equal(t, 1, rankings[1].TeamId)
+	assert.Equal(t, 3, rankings[2].TeamId)
+	assert.Equal(t, RandomTiebreakerName, rankings[2].Tiebreaker)
+	assert.Equal(t, 4, rankings[3].TeamId)
+}
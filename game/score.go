@@ -5,31 +5,190 @@
 
 package game
 
+import "encoding/json"
+
+// Maximum number of teams that can be configured to play on each alliance.
+const MaxTeamsPerAlliance = 4
+
 type Score struct {
-	AutoPoints    int
-	TeleopPoints  int
-	EndgamePoints int
+	// Number of times each scoring element of the current game definition was achieved, keyed by element ID.
+	ElementCounts map[string]int
+	// Counts of the per-robot scoring elements achieved by the robot in each of the alliance's stations, keyed by
+	// element ID. The alliance totals of these are kept in ElementCounts.
+	RobotElementCounts [MaxTeamsPerAlliance]map[string]int
+	// Fouls committed by this alliance, the points for which are credited to the opposing alliance.
+	Fouls []Foul
+	// Whether the alliance was disqualified from a playoff match, which forfeits all of its points.
+	PlayoffDq bool
+}
+
+// Point value and count of a single scoring element within a score, for display purposes.
+type ScoringElementScore struct {
+	ScoringElement
+	Count       int
+	TotalPoints int
 }
 
-// Calculates and returns the summary fields used for ranking and display.
-func (score *Score) Summarize() *ScoreSummary {
+// Calculates and returns the summary fields used for ranking and display. The opponent's score is needed to credit
+// the points for the fouls it committed.
+func (score *Score) Summarize(opponentScore *Score) *ScoreSummary {
 	summary := new(ScoreSummary)
+	if score.PlayoffDq {
+		return summary
+	}
+
+	for _, element := range CurrentGame.ScoringElements {
+		points := score.ElementCounts[element.Id] * element.Points
+		switch element.Period {
+		case AutoScoringPeriod:
+			summary.AutoPoints += points
+		case TeleopScoringPeriod:
+			summary.TeleopPoints += points
+		case EndgameScoringPeriod:
+			summary.EndgamePoints += points
+		}
+	}
+	for _, foul := range opponentScore.Fouls {
+		summary.FoulPoints += foul.PointValue()
+	}
+	summary.Score = summary.AutoPoints + summary.TeleopPoints + summary.EndgamePoints + summary.FoulPoints
 
-	summary.AutoPoints = score.AutoPoints
-	summary.TeleopPoints = score.TeleopPoints
-	summary.EndgamePoints = score.EndgamePoints
-	summary.Score = summary.AutoPoints + summary.TeleopPoints + summary.EndgamePoints
+	for i := range CurrentGame.BonusRankingPoints {
+		rule := &CurrentGame.BonusRankingPoints[i]
+		if rule.IsEarned(score, summary) {
+			summary.BonusRankingPointIds = append(summary.BonusRankingPointIds, rule.Id)
+			summary.BonusRankingPoints += rule.Value()
+		}
+	}
 
 	return summary
 }
 
+// Returns the per-element breakdown of the score, in the order the elements appear in the current game definition.
+func (score *Score) ElementScores() []ScoringElementScore {
+	elementScores := make([]ScoringElementScore, len(CurrentGame.ScoringElements))
+	for i, element := range CurrentGame.ScoringElements {
+		count := score.ElementCounts[element.Id]
+		elementScores[i] = ScoringElementScore{element, count, count * element.Points}
+	}
+	return elementScores
+}
+
+// Sets the count of the given scoring element, allocating the counts map if necessary.
+func (score *Score) SetElementCount(id string, count int) {
+	if score.ElementCounts == nil {
+		score.ElementCounts = make(map[string]int)
+	}
+	score.ElementCounts[id] = count
+}
+
+// Sets the count of the given per-robot scoring element for the robot at the given station index (0-3), and updates
+// the alliance total to match.
+func (score *Score) SetRobotElementCount(robotIndex int, id string, count int) {
+	if score.RobotElementCounts[robotIndex] == nil {
+		score.RobotElementCounts[robotIndex] = make(map[string]int)
+	}
+	score.RobotElementCounts[robotIndex][id] = count
+	score.updateRobotElementTotal(id)
+}
+
+// Recalculates the alliance totals of all per-robot scoring elements for which per-robot counts have been recorded.
+// Elements with no per-robot counts keep their alliance total, so that scores entered before the element was made
+// per-robot are preserved.
+func (score *Score) UpdateRobotElementTotals() {
+	for _, element := range CurrentGame.ScoringElements {
+		if element.PerRobot {
+			score.updateRobotElementTotal(element.Id)
+		}
+	}
+}
+
+func (score *Score) updateRobotElementTotal(id string) {
+	total := 0
+	hasRobotCounts := false
+	for _, counts := range score.RobotElementCounts {
+		if count, ok := counts[id]; ok {
+			total += count
+			hasRobotCounts = true
+		}
+	}
+	if hasRobotCounts {
+		score.SetElementCount(id, total)
+	}
+}
+
+// Returns the points contributed by the robot at the given zero-based station index through the per-robot scoring
+// elements.
+func (score *Score) RobotPoints(robotIndex int) int {
+	points := 0
+	for _, element := range CurrentGame.ScoringElements {
+		if element.PerRobot {
+			points += score.RobotElementCounts[robotIndex][element.Id] * element.Points
+		}
+	}
+	return points
+}
+
 // Returns true if and only if all fields of the two scores are equal.
 func (score *Score) Equals(other *Score) bool {
-	if score.AutoPoints != other.AutoPoints ||
-		score.TeleopPoints != other.TeleopPoints ||
-		score.EndgamePoints != other.EndgamePoints {
+	if !countsEqual(score.ElementCounts, other.ElementCounts) {
 		return false
 	}
+	for i := range score.RobotElementCounts {
+		if !countsEqual(score.RobotElementCounts[i], other.RobotElementCounts[i]) {
+			return false
+		}
+	}
+
+	if score.PlayoffDq != other.PlayoffDq || len(score.Fouls) != len(other.Fouls) {
+		return false
+	}
+	for i, foul := range score.Fouls {
+		if foul != other.Fouls[i] {
+			return false
+		}
+	}
+
+	return true
+}
 
+// Returns true if the two element count maps have the same counts, treating missing entries as zero.
+func countsEqual(a, b map[string]int) bool {
+	for id, count := range a {
+		if b[id] != count {
+			return false
+		}
+	}
+	for id, count := range b {
+		if a[id] != count {
+			return false
+		}
+	}
 	return true
 }
+
+// Deserializes the score, additionally accepting the fixed AutoPoints/TeleopPoints/EndgamePoints fields stored by
+// earlier versions and mapping them onto the elements of the default game definition.
+func (score *Score) UnmarshalJSON(data []byte) error {
+	type scoreAlias Score
+	var legacyScore struct {
+		scoreAlias
+		AutoPoints    *int
+		TeleopPoints  *int
+		EndgamePoints *int
+	}
+	if err := json.Unmarshal(data, &legacyScore); err != nil {
+		return err
+	}
+
+	*score = Score(legacyScore.scoreAlias)
+	legacyFields := map[string]*int{
+		"auto": legacyScore.AutoPoints, "teleop": legacyScore.TeleopPoints, "endgame": legacyScore.EndgamePoints,
+	}
+	for id, points := range legacyFields {
+		if points != nil {
+			score.SetElementCount(id, *points)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Methods for determining how a score changed between two points in time.
+
+package game
+
+import "sort"
+
+// A change in the count of a single scoring element between two versions of a score.
+type ScoreDelta struct {
+	ElementId string
+	// One-based station index of the robot for per-robot counts, or zero for the alliance count.
+	Position int
+	Delta    int
+}
+
+// Returns a deep copy of the score, so that it can be compared against after the original is modified in place.
+func (score *Score) Copy() *Score {
+	scoreCopy := &Score{PlayoffDq: score.PlayoffDq}
+	scoreCopy.ElementCounts = copyCounts(score.ElementCounts)
+	for i, counts := range score.RobotElementCounts {
+		scoreCopy.RobotElementCounts[i] = copyCounts(counts)
+	}
+	if score.Fouls != nil {
+		scoreCopy.Fouls = append([]Foul{}, score.Fouls...)
+	}
+	return scoreCopy
+}
+
+// Returns the changes in element counts from the given previous version of the score to this one, ordered by element
+// ID and position. Changes to the alliance count of a per-robot element are omitted when they are already accounted for
+// by changes to the robot counts.
+func (score *Score) Deltas(previous *Score) []ScoreDelta {
+	var deltas []ScoreDelta
+	robotDeltaIds := make(map[string]bool)
+	for i := range score.RobotElementCounts {
+		for _, delta := range countDeltas(previous.RobotElementCounts[i], score.RobotElementCounts[i]) {
+			delta.Position = i + 1
+			deltas = append(deltas, delta)
+			robotDeltaIds[delta.ElementId] = true
+		}
+	}
+	for _, delta := range countDeltas(previous.ElementCounts, score.ElementCounts) {
+		if !robotDeltaIds[delta.ElementId] {
+			deltas = append(deltas, delta)
+		}
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		if deltas[i].ElementId == deltas[j].ElementId {
+			return deltas[i].Position < deltas[j].Position
+		}
+		return deltas[i].ElementId < deltas[j].ElementId
+	})
+	return deltas
+}
+
+func copyCounts(counts map[string]int) map[string]int {
+	if counts == nil {
+		return nil
+	}
+	countsCopy := make(map[string]int, len(counts))
+	for id, count := range counts {
+		countsCopy[id] = count
+	}
+	return countsCopy
+}
+
+// Returns a delta for each element whose count differs between the two maps, treating missing entries as zero.
+func countDeltas(previous, current map[string]int) []ScoreDelta {
+	var deltas []ScoreDelta
+	for id, count := range current {
+		if count != previous[id] {
+			deltas = append(deltas, ScoreDelta{ElementId: id, Delta: count - previous[id]})
+		}
+	}
+	for id, count := range previous {
+		if _, ok := current[id]; !ok && count != 0 {
+			deltas = append(deltas, ScoreDelta{ElementId: id, Delta: -count})
+		}
+	}
+	return deltas
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestScoreCopy(t *testing.T) {
+	score := TestScore1()
+	score.SetRobotElementCount(1, "leave", 1)
+	score.Fouls = []Foul{{Type: MinorFoul, TeamId: 254}}
+	scoreCopy := score.Copy()
+	assert.Equal(t, score, scoreCopy)
+
+	score.SetElementCount("auto", 0)
+	score.SetRobotElementCount(1, "leave", 0)
+	score.Fouls[0].Type = MajorFoul
+	assert.Equal(t, 45, scoreCopy.ElementCounts["auto"])
+	assert.Equal(t, 1, scoreCopy.RobotElementCounts[1]["leave"])
+	assert.Equal(t, MinorFoul, scoreCopy.Fouls[0].Type)
+}
+
+func TestScoreDeltas(t *testing.T) {
+	previousScore := TestScore1()
+	assert.Nil(t, previousScore.Copy().Deltas(previousScore))
+
+	score := previousScore.Copy()
+	score.SetElementCount("teleop", 90)
+	delete(score.ElementCounts, "endgame")
+	score.SetRobotElementCount(2, "leave", 1)
+	score.SetRobotElementCount(0, "leave", 2)
+	assert.Equal(
+		t,
+		[]ScoreDelta{
+			{ElementId: "endgame", Delta: -30},
+			{ElementId: "leave", Position: 1, Delta: 2},
+			{ElementId: "leave", Position: 3, Delta: 1},
+			{ElementId: "teleop", Delta: 10},
+		},
+		score.Deltas(previousScore),
+	)
+}
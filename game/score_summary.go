@@ -9,7 +9,22 @@ type ScoreSummary struct {
 	AutoPoints    int
 	TeleopPoints  int
 	EndgamePoints int
+	FoulPoints    int
 	Score         int
+	// IDs of the bonus ranking point rules of the current game definition that the alliance earned.
+	BonusRankingPointIds []string
+	BonusRankingPoints   int
+}
+
+// Returns the bonus ranking point rules of the current game definition that the alliance earned, for display purposes.
+func (summary *ScoreSummary) BonusRankingPointRules() []BonusRankingPointRule {
+	rules := make([]BonusRankingPointRule, 0, len(summary.BonusRankingPointIds))
+	for _, id := range summary.BonusRankingPointIds {
+		if rule := CurrentGame.GetBonusRankingPoint(id); rule != nil {
+			rules = append(rules, *rule)
+		}
+	}
+	return rules
 }
 
 type MatchStatus string
@@ -4,6 +4,7 @@
 package game
 
 import (
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -12,12 +13,12 @@ func TestScoreSummary(t *testing.T) {
 	redScore := TestScore1()
 	blueScore := TestScore2()
 
-	redSummary := redScore.Summarize()
+	redSummary := redScore.Summarize(blueScore)
 	assert.Equal(t, 45, redSummary.AutoPoints)
 	assert.Equal(t, 80, redSummary.TeleopPoints)
 	assert.Equal(t, 30, redSummary.EndgamePoints)
 
-	blueSummary := blueScore.Summarize()
+	blueSummary := blueScore.Summarize(redScore)
 	assert.Equal(t, 15, blueSummary.AutoPoints)
 	assert.Equal(t, 40, blueSummary.TeleopPoints)
 	assert.Equal(t, 25, blueSummary.EndgamePoints)
@@ -34,17 +35,129 @@ func TestScoreEquals(t *testing.T) {
 	assert.False(t, score3.Equals(score1))
 
 	score2 = TestScore1()
-	score2.AutoPoints = 20
+	score2.ElementCounts["auto"] = 20
 	assert.False(t, score1.Equals(score2))
 	assert.False(t, score2.Equals(score1))
 
 	score2 = TestScore1()
-	score2.TeleopPoints = 35
+	score2.ElementCounts["teleop"] = 35
 	assert.False(t, score1.Equals(score2))
 	assert.False(t, score2.Equals(score1))
 
 	score2 = TestScore1()
-	score2.EndgamePoints = 15
+	score2.ElementCounts["endgame"] = 15
 	assert.False(t, score1.Equals(score2))
 	assert.False(t, score2.Equals(score1))
 }
+
+func TestScoreSummaryWithCustomGameDefinition(t *testing.T) {
+	CurrentGame = &GameDefinition{
+		ScoringElements: []ScoringElement{
+			{Id: "leave", Name: "Leave", Period: AutoScoringPeriod, Points: 3},
+			{Id: "autoCoral", Name: "Auto Coral", Period: AutoScoringPeriod, Points: 4},
+			{Id: "coral", Name: "Coral", Period: TeleopScoringPeriod, Points: 2},
+			{Id: "park", Name: "Park", Period: EndgameScoringPeriod, Points: 2},
+		},
+	}
+	defer func() { CurrentGame = DefaultGameDefinition() }()
+
+	score := &Score{ElementCounts: map[string]int{"leave": 2, "autoCoral": 3, "coral": 10, "park": 1, "bogus": 100}}
+	summary := score.Summarize(new(Score))
+	assert.Equal(t, 18, summary.AutoPoints)
+	assert.Equal(t, 20, summary.TeleopPoints)
+	assert.Equal(t, 2, summary.EndgamePoints)
+	assert.Equal(t, 40, summary.Score)
+
+	elementScores := score.ElementScores()
+	if assert.Equal(t, 4, len(elementScores)) {
+		assert.Equal(t, "autoCoral", elementScores[1].Id)
+		assert.Equal(t, 3, elementScores[1].Count)
+		assert.Equal(t, 12, elementScores[1].TotalPoints)
+	}
+
+	assert.Equal(t, 0, new(Score).Summarize(new(Score)).Score)
+}
+
+func TestScoreUnmarshalLegacyJson(t *testing.T) {
+	var score Score
+	assert.Nil(t, json.Unmarshal([]byte("{\"AutoPoints\":45,\"TeleopPoints\":80,\"EndgamePoints\":30}"), &score))
+	assert.Equal(t, TestScore1(), &score)
+
+	score = Score{}
+	assert.Nil(t, json.Unmarshal([]byte("{\"ElementCounts\":{\"auto\":15,\"teleop\":40,\"endgame\":25}}"), &score))
+	assert.Equal(t, TestScore2(), &score)
+}
+
+func TestScoreSummaryFouls(t *testing.T) {
+	redScore := TestScore1()
+	blueScore := TestScore2()
+	blueScore.Fouls = []Foul{
+		{Type: MinorFoul, TeamId: 1114, TimeInMatchSec: 12.5},
+		{Type: MajorFoul, TeamId: 1114, TimeInMatchSec: 30},
+		{Type: TechFoul, TeamId: 2056, TimeInMatchSec: 140},
+	}
+	redScore.Fouls = []Foul{{Type: MinorFoul, TeamId: 254, TimeInMatchSec: 95}}
+
+	// Foul points are credited to the alliance that didn't commit them.
+	redSummary := redScore.Summarize(blueScore)
+	assert.Equal(t, 20, redSummary.FoulPoints)
+	assert.Equal(t, 175, redSummary.Score)
+	blueSummary := blueScore.Summarize(redScore)
+	assert.Equal(t, 2, blueSummary.FoulPoints)
+	assert.Equal(t, 82, blueSummary.Score)
+
+	// Fouls shouldn't count toward any of the period totals.
+	assert.Equal(t, 45, redSummary.AutoPoints)
+	assert.Equal(t, 80, redSummary.TeleopPoints)
+	assert.Equal(t, 30, redSummary.EndgamePoints)
+
+	CurrentGame = DefaultGameDefinition()
+	CurrentGame.FoulPoints = FoulPoints{Minor: 5, Major: 10, Tech: 0}
+	defer func() { CurrentGame = DefaultGameDefinition() }()
+	assert.Equal(t, 15, redScore.Summarize(blueScore).FoulPoints)
+}
+
+func TestScoreEqualsFouls(t *testing.T) {
+	score1 := TestScore1()
+	score1.Fouls = []Foul{{Type: MajorFoul, TeamId: 254, RuleId: 3, TimeInMatchSec: 20}}
+	score2 := TestScore1()
+	assert.False(t, score1.Equals(score2))
+	score2.Fouls = []Foul{{Type: MajorFoul, TeamId: 254, RuleId: 3, TimeInMatchSec: 20}}
+	assert.True(t, score1.Equals(score2))
+	score2.Fouls[0].TeamId = 1114
+	assert.False(t, score1.Equals(score2))
+}
+
+func TestScoreRobotElementCounts(t *testing.T) {
+	CurrentGame = &GameDefinition{
+		ScoringElements: []ScoringElement{
+			{Id: "leave", Name: "Leave", Period: AutoScoringPeriod, Points: 3, PerRobot: true},
+			{Id: "coral", Name: "Coral", Period: TeleopScoringPeriod, Points: 2},
+			{Id: "park", Name: "Park", Period: EndgameScoringPeriod, Points: 2, PerRobot: true},
+		},
+	}
+	defer func() { CurrentGame = DefaultGameDefinition() }()
+
+	score := &Score{ElementCounts: map[string]int{"coral": 10, "park": 3}}
+	score.SetRobotElementCount(0, "leave", 1)
+	score.SetRobotElementCount(2, "leave", 1)
+	assert.Equal(t, 2, score.ElementCounts["leave"])
+	assert.Equal(t, 3, score.RobotPoints(0))
+	assert.Equal(t, 0, score.RobotPoints(1))
+	assert.Equal(t, 32, score.Summarize(new(Score)).Score)
+
+	// Elements with no per-robot counts keep their alliance total.
+	score.ElementCounts["leave"] = 5
+	score.UpdateRobotElementTotals()
+	assert.Equal(t, 2, score.ElementCounts["leave"])
+	assert.Equal(t, 3, score.ElementCounts["park"])
+
+	score2 := &Score{ElementCounts: map[string]int{"coral": 10, "park": 3, "leave": 2}}
+	assert.False(t, score.Equals(score2))
+	score2.SetRobotElementCount(0, "leave", 1)
+	score2.SetRobotElementCount(1, "leave", 1)
+	assert.False(t, score.Equals(score2))
+	score2.SetRobotElementCount(1, "leave", 0)
+	score2.SetRobotElementCount(2, "leave", 1)
+	assert.True(t, score.Equals(score2))
+}
@@ -0,0 +1,30 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Model representing the progression of both alliances' scores over the course of a match.
+
+package game
+
+import "reflect"
+
+// A snapshot of both alliances' score summaries taken at a point during a match.
+type ScoreTimelineSample struct {
+	MatchTimeSec float64
+	Red          ScoreSummary
+	Blue         ScoreSummary
+}
+
+// Returns the given timeline with a sample of the final summaries appended at the time of its last sample if they
+// differ from it, such as when the scores were adjusted after the end of the match. An empty timeline is returned as
+// is, since there is no match time to attribute the final result to.
+func FinalizeScoreTimeline(
+	timeline []ScoreTimelineSample, redSummary, blueSummary *ScoreSummary,
+) []ScoreTimelineSample {
+	if len(timeline) == 0 {
+		return timeline
+	}
+	lastSample := timeline[len(timeline)-1]
+	if reflect.DeepEqual(lastSample.Red, *redSummary) && reflect.DeepEqual(lastSample.Blue, *blueSummary) {
+		return timeline
+	}
+	return append(timeline, ScoreTimelineSample{lastSample.MatchTimeSec, *redSummary, *blueSummary})
+}
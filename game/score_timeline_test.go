@@ -0,0 +1,23 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFinalizeScoreTimeline(t *testing.T) {
+	redSummary, blueSummary := &ScoreSummary{Score: 20}, &ScoreSummary{Score: 15}
+	assert.Empty(t, FinalizeScoreTimeline(nil, redSummary, blueSummary))
+
+	timeline := []ScoreTimelineSample{{0, ScoreSummary{}, ScoreSummary{}}, {150, *redSummary, *blueSummary}}
+	assert.Equal(t, timeline, FinalizeScoreTimeline(timeline, redSummary, blueSummary))
+
+	// Adjustments made after the end of the match are attributed to the time of the last sample.
+	blueSummary.Score = 21
+	timeline = FinalizeScoreTimeline(timeline, redSummary, blueSummary)
+	if assert.Equal(t, 3, len(timeline)) {
+		assert.Equal(t, ScoreTimelineSample{150, ScoreSummary{Score: 20}, ScoreSummary{Score: 21}}, timeline[2])
+	}
+}
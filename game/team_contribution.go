@@ -0,0 +1,36 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Model representing a single team's accumulated share of its alliances' per-robot scoring elements.
+
+package game
+
+type TeamContribution struct {
+	TeamId        int
+	MatchesPlayed int
+	// Total count of each per-robot scoring element achieved by the team, keyed by element ID.
+	ElementCounts map[string]int
+	Points        int
+}
+
+// Adds the team's per-robot scoring from the given alliance score, in which it occupied the station with the given
+// index (0-2).
+func (contribution *TeamContribution) AddMatch(score *Score, robotIndex int) {
+	contribution.MatchesPlayed++
+	if contribution.ElementCounts == nil {
+		contribution.ElementCounts = make(map[string]int)
+	}
+	for _, element := range CurrentGame.ScoringElements {
+		if element.PerRobot {
+			contribution.ElementCounts[element.Id] += score.RobotElementCounts[robotIndex][element.Id]
+		}
+	}
+	contribution.Points += score.RobotPoints(robotIndex)
+}
+
+// Returns the average points contributed per match played.
+func (contribution TeamContribution) AveragePoints() float64 {
+	if contribution.MatchesPlayed == 0 {
+		return 0
+	}
+	return float64(contribution.Points) / float64(contribution.MatchesPlayed)
+}
@@ -7,24 +7,20 @@ package game
 
 func TestScore1() *Score {
 	return &Score{
-		AutoPoints:    45,
-		TeleopPoints:  80,
-		EndgamePoints: 30,
+		ElementCounts: map[string]int{"auto": 45, "teleop": 80, "endgame": 30},
 	}
 }
 
 func TestScore2() *Score {
 	return &Score{
-		AutoPoints:    15,
-		TeleopPoints:  40,
-		EndgamePoints: 25,
+		ElementCounts: map[string]int{"auto": 15, "teleop": 40, "endgame": 25},
 	}
 }
 
 func TestRanking1() *Ranking {
-	return &Ranking{254, 1, 0, RankingFields{20, 625, 90, 554, 0.254, 3, 2, 1, 10}}
+	return &Ranking{254, 1, 0, RankingFields{20, 4, 625, 90, 554, 0.254, 3, 2, 1, 0, 10, 0, nil}, ""}
 }
 
 func TestRanking2() *Ranking {
-	return &Ranking{1114, 2, 1, RankingFields{18, 700, 625, 90, 0.1114, 1, 3, 2, 10}}
+	return &Ranking{1114, 2, 1, RankingFields{18, 3, 700, 625, 90, 0.1114, 1, 3, 2, 0, 10, 0, nil}, ""}
 }
@@ -17,15 +17,18 @@ const httpPort = 8080
 
 // Main entry point for the application.
 func main() {
-	arena, err := field.NewArena(eventDbPath)
+	arenas, err := field.NewArenas(eventDbPath)
 	if err != nil {
 		log.Fatalln("Error during startup: ", err)
 	}
 
 	// Start the web server in a separate goroutine.
-	web := web.NewWeb(arena)
+	web := web.NewWeb(arenas...)
 	go web.ServeWebInterface(httpPort)
 
-	// Run the arena state machine in the main thread.
-	arena.Run()
+	// Run the state machine of any additional fields in separate goroutines and the first in the main thread.
+	for _, arena := range arenas[1:] {
+		go arena.Run()
+	}
+	arenas[0].Run()
 }
@@ -5,12 +5,16 @@
 
 package model
 
-import "sort"
+import (
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"sort"
+)
 
 type Alliance struct {
 	Id      int `db:"id,manual"`
 	TeamIds []int
-	Lineup  [3]int
+	// Teams in each of the alliance's stations for its next match, with zero for unused stations.
+	Lineup [game.MaxTeamsPerAlliance]int
 }
 
 func (database *Database) CreateAlliance(alliance *Alliance) error {
@@ -45,7 +49,7 @@ func (database *Database) GetAllAlliances() ([]Alliance, error) {
 }
 
 // Updates the alliance, if necessary, to include whoever played in the match, in case there was a substitute.
-func (database *Database) UpdateAllianceFromMatch(allianceId int, matchTeamIds [3]int) error {
+func (database *Database) UpdateAllianceFromMatch(allianceId int, matchTeamIds [game.MaxTeamsPerAlliance]int) error {
 	alliance, err := database.GetAllianceById(allianceId)
 	if err != nil {
 		return err
@@ -58,6 +62,9 @@ func (database *Database) UpdateAllianceFromMatch(allianceId int, matchTeamIds [
 	}
 
 	for _, teamId := range matchTeamIds {
+		if teamId == 0 {
+			continue
+		}
 		found := false
 		for _, allianceTeamId := range alliance.TeamIds {
 			if teamId == allianceTeamId {
@@ -81,16 +88,12 @@ func (database *Database) UpdateAllianceFromMatch(allianceId int, matchTeamIds [
 // elimination alliance but are not playing in the given match.
 // If the given match isn't an elimination match, empty arrays are returned.
 func (database *Database) GetOffFieldTeamIds(match *Match) ([]int, []int, error) {
-	redOffFieldTeams, err := database.getOffFieldTeamIdsForAlliance(
-		match.ElimRedAlliance, match.Red1, match.Red2, match.Red3,
-	)
+	redOffFieldTeams, err := database.getOffFieldTeamIdsForAlliance(match.ElimRedAlliance, match.RedTeamIds())
 	if err != nil {
 		return nil, nil, err
 	}
 
-	blueOffFieldTeams, err := database.getOffFieldTeamIdsForAlliance(
-		match.ElimBlueAlliance, match.Blue1, match.Blue2, match.Blue3,
-	)
+	blueOffFieldTeams, err := database.getOffFieldTeamIdsForAlliance(match.ElimBlueAlliance, match.BlueTeamIds())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -98,7 +101,9 @@ func (database *Database) GetOffFieldTeamIds(match *Match) ([]int, []int, error)
 	return redOffFieldTeams, blueOffFieldTeams, nil
 }
 
-func (database *Database) getOffFieldTeamIdsForAlliance(allianceId int, teamId1, teamId2, teamId3 int) ([]int, error) {
+func (database *Database) getOffFieldTeamIdsForAlliance(
+	allianceId int, matchTeamIds [game.MaxTeamsPerAlliance]int,
+) ([]int, error) {
 	if allianceId == 0 {
 		return []int{}, nil
 	}
@@ -109,7 +114,14 @@ func (database *Database) getOffFieldTeamIdsForAlliance(allianceId int, teamId1,
 	}
 	offFieldTeamIds := []int{}
 	for _, allianceTeamId := range alliance.TeamIds {
-		if allianceTeamId != teamId1 && allianceTeamId != teamId2 && allianceTeamId != teamId3 {
+		onField := false
+		for _, teamId := range matchTeamIds {
+			if allianceTeamId == teamId {
+				onField = true
+				break
+			}
+		}
+		if !onField {
 			offFieldTeamIds = append(offFieldTeamIds, allianceTeamId)
 		}
 	}
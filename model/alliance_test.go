@@ -4,6 +4,7 @@
 package model
 
 import (
+	"github.com/FRCTeam1987/crimson-arena/game"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -21,7 +22,9 @@ func TestAllianceCrud(t *testing.T) {
 	db := setupTestDb(t)
 	defer db.Close()
 
-	alliance := Alliance{Id: 3, TeamIds: []int{254, 1114, 296, 1503}, Lineup: [3]int{1114, 254, 296}}
+	alliance := Alliance{
+		Id: 3, TeamIds: []int{254, 1114, 296, 1503}, Lineup: [game.MaxTeamsPerAlliance]int{1114, 254, 296},
+	}
 	assert.Nil(t, db.CreateAlliance(&alliance))
 	alliance2, err := db.GetAllianceById(3)
 	assert.Nil(t, err)
@@ -43,20 +46,22 @@ func TestUpdateAllianceFromMatch(t *testing.T) {
 	db := setupTestDb(t)
 	defer db.Close()
 
-	alliance := Alliance{Id: 3, TeamIds: []int{254, 1114, 296, 1503}, Lineup: [3]int{1114, 254, 296}}
+	alliance := Alliance{
+		Id: 3, TeamIds: []int{254, 1114, 296, 1503}, Lineup: [game.MaxTeamsPerAlliance]int{1114, 254, 296},
+	}
 	assert.Nil(t, db.CreateAlliance(&alliance))
-	assert.Nil(t, db.UpdateAllianceFromMatch(3, [3]int{1503, 188, 296}))
+	assert.Nil(t, db.UpdateAllianceFromMatch(3, [game.MaxTeamsPerAlliance]int{1503, 188, 296}))
 	alliance2, err := db.GetAllianceById(3)
 	assert.Nil(t, err)
 	assert.Equal(t, []int{254, 1114, 296, 1503, 188}, alliance2.TeamIds)
-	assert.Equal(t, [3]int{1503, 188, 296}, alliance2.Lineup)
+	assert.Equal(t, [game.MaxTeamsPerAlliance]int{1503, 188, 296}, alliance2.Lineup)
 }
 
 func TestTruncateAllianceTeams(t *testing.T) {
 	db := setupTestDb(t)
 	defer db.Close()
 
-	alliance := Alliance{Id: 1, TeamIds: []int{148, 118, 125}, Lineup: [3]int{118, 148, 125}}
+	alliance := Alliance{Id: 1, TeamIds: []int{148, 118, 125}, Lineup: [game.MaxTeamsPerAlliance]int{118, 148, 125}}
 	assert.Nil(t, db.CreateAlliance(&alliance))
 	assert.Nil(t, db.TruncateAlliances())
 	alliance2, err := db.GetAllianceById(1)
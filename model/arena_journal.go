@@ -0,0 +1,72 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Model and datastore methods for the journal of in-memory arena state, from which the arena can be recovered after
+// the process dies unexpectedly.
+
+package model
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"time"
+)
+
+type ArenaJournal struct {
+	Id int `db:"id"`
+	// ID of the field whose arena wrote the journal, or 0 if the server is running only a single field.
+	FieldId int
+	SavedAt time.Time
+	// Copy of the loaded match, including any team substitutions that haven't been saved to its database record.
+	CurrentMatch Match
+	// Value of the arena's field.MatchState at the time the journal was written.
+	MatchState     int
+	MatchStartTime time.Time
+	MatchTimeSec   float64
+	RedScore       *game.Score
+	BlueScore      *game.Score
+	RedCards       map[string]string
+	BlueCards      map[string]string
+	ScoreTimeline  []game.ScoreTimelineSample
+	// Bypass state of each alliance station, keyed by station.
+	Bypasses map[string]bool
+	// ID of the match whose results are loaded into the display buffer, or 0 if there is none.
+	SavedMatchId               int
+	AllianceSelectionAlliances []Alliance
+}
+
+// Returns the most recently written journal for the arena of the given field, or nil if there isn't one.
+func (database *Database) GetArenaJournal(fieldId int) (*ArenaJournal, error) {
+	arenaJournals, err := database.arenaJournalTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, arenaJournal := range arenaJournals {
+		if arenaJournal.FieldId == fieldId {
+			return &arenaJournal, nil
+		}
+	}
+	return nil, nil
+}
+
+// Replaces any existing journal for the same field with the given one and flushes it to disk immediately, since the
+// database is otherwise opened without syncing after each write.
+func (database *Database) SaveArenaJournal(arenaJournal *ArenaJournal) error {
+	existingJournal, err := database.GetArenaJournal(arenaJournal.FieldId)
+	if err != nil {
+		return err
+	}
+	if existingJournal == nil {
+		arenaJournal.Id = 0
+		err = database.arenaJournalTable.create(arenaJournal)
+	} else {
+		arenaJournal.Id = existingJournal.Id
+		err = database.arenaJournalTable.update(arenaJournal)
+	}
+	if err != nil {
+		return err
+	}
+	return database.bolt.Sync()
+}
+
+func (database *Database) TruncateArenaJournals() error {
+	return database.arenaJournalTable.truncate()
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestArenaJournal(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	arenaJournal, err := db.GetArenaJournal(0)
+	assert.Nil(t, err)
+	assert.Nil(t, arenaJournal)
+
+	journal := ArenaJournal{
+		SavedAt:        time.Unix(1000, 0).UTC(),
+		CurrentMatch:   Match{Id: 5, Type: "qualification", DisplayName: "5", Red1: 254},
+		MatchState:     4,
+		MatchStartTime: time.Unix(900, 0).UTC(),
+		MatchTimeSec:   42.5,
+		RedScore:       game.TestScore1(),
+		BlueScore:      game.TestScore2(),
+		RedCards:       map[string]string{"254": "yellow"},
+		BlueCards:      map[string]string{},
+		Bypasses:       map[string]bool{"R1": true, "B2": false},
+		SavedMatchId:   4,
+	}
+	assert.Nil(t, db.SaveArenaJournal(&journal))
+	arenaJournal, err = db.GetArenaJournal(0)
+	assert.Nil(t, err)
+	assert.Equal(t, journal, *arenaJournal)
+
+	// Check that saving again replaces the existing journal rather than adding another.
+	journal.MatchTimeSec = 60
+	journal.AllianceSelectionAlliances = []Alliance{{Id: 1, TeamIds: []int{254, 0, 0}}}
+	assert.Nil(t, db.SaveArenaJournal(&journal))
+	arenaJournals, _ := db.arenaJournalTable.getAll()
+	assert.Equal(t, 1, len(arenaJournals))
+	arenaJournal, _ = db.GetArenaJournal(0)
+	assert.Equal(t, 60.0, arenaJournal.MatchTimeSec)
+	assert.Equal(t, journal.AllianceSelectionAlliances, arenaJournal.AllianceSelectionAlliances)
+
+	// Check that each field's arena has its own journal.
+	journal2 := ArenaJournal{FieldId: 2, CurrentMatch: Match{Id: 6, Type: "qualification", DisplayName: "6"}}
+	assert.Nil(t, db.SaveArenaJournal(&journal2))
+	arenaJournal, _ = db.GetArenaJournal(2)
+	if assert.NotNil(t, arenaJournal) {
+		assert.Equal(t, 6, arenaJournal.CurrentMatch.Id)
+	}
+	arenaJournal, _ = db.GetArenaJournal(0)
+	assert.Equal(t, 5, arenaJournal.CurrentMatch.Id)
+	arenaJournal, _ = db.GetArenaJournal(1)
+	assert.Nil(t, arenaJournal)
+
+	assert.Nil(t, db.TruncateArenaJournals())
+	arenaJournal, err = db.GetArenaJournal(0)
+	assert.Nil(t, err)
+	assert.Nil(t, arenaJournal)
+}
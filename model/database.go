@@ -21,19 +21,25 @@ const backupsDir = "db/backups"
 var BaseDir = "." // Mutable for testing
 
 type Database struct {
-	Path               string
-	bolt               *bbolt.DB
-	allianceTable      *table[Alliance]
-	awardTable         *table[Award]
-	eventSettingsTable *table[EventSettings]
-	lowerThirdTable    *table[LowerThird]
-	matchTable         *table[Match]
-	matchResultTable   *table[MatchResult]
-	rankingTable       *table[game.Ranking]
-	scheduleBlockTable *table[ScheduleBlock]
-	sponsorSlideTable  *table[SponsorSlide]
-	teamTable          *table[Team]
-	userSessionTable   *table[UserSession]
+	Path                string
+	bolt                *bbolt.DB
+	allianceTable       *table[Alliance]
+	arenaJournalTable   *table[ArenaJournal]
+	awardTable          *table[Award]
+	eventSettingsTable  *table[EventSettings]
+	fieldTable          *table[Field]
+	lowerThirdTable     *table[LowerThird]
+	matchTable          *table[Match]
+	matchReplayTable    *table[MatchReplay]
+	matchResultTable    *table[MatchResult]
+	operatorActionTable *table[OperatorAction]
+	rankingTable        *table[game.Ranking]
+	scheduleBlockTable  *table[ScheduleBlock]
+	scoreEventTable     *table[ScoreEvent]
+	sponsorSlideTable   *table[SponsorSlide]
+	teamTable           *table[Team]
+	timingProfileTable  *table[TimingProfile]
+	userSessionTable    *table[UserSession]
 }
 
 // Opens the Bolt database at the given path, creating it if it doesn't exist.
@@ -49,33 +55,51 @@ func OpenDatabase(filename string) (*Database, error) {
 	if database.allianceTable, err = newTable[Alliance](&database); err != nil {
 		return nil, err
 	}
+	if database.arenaJournalTable, err = newTable[ArenaJournal](&database); err != nil {
+		return nil, err
+	}
 	if database.awardTable, err = newTable[Award](&database); err != nil {
 		return nil, err
 	}
 	if database.eventSettingsTable, err = newTable[EventSettings](&database); err != nil {
 		return nil, err
 	}
+	if database.fieldTable, err = newTable[Field](&database); err != nil {
+		return nil, err
+	}
 	if database.lowerThirdTable, err = newTable[LowerThird](&database); err != nil {
 		return nil, err
 	}
 	if database.matchTable, err = newTable[Match](&database); err != nil {
 		return nil, err
 	}
+	if database.matchReplayTable, err = newTable[MatchReplay](&database); err != nil {
+		return nil, err
+	}
 	if database.matchResultTable, err = newTable[MatchResult](&database); err != nil {
 		return nil, err
 	}
+	if database.operatorActionTable, err = newTable[OperatorAction](&database); err != nil {
+		return nil, err
+	}
 	if database.rankingTable, err = newTable[game.Ranking](&database); err != nil {
 		return nil, err
 	}
 	if database.scheduleBlockTable, err = newTable[ScheduleBlock](&database); err != nil {
 		return nil, err
 	}
+	if database.scoreEventTable, err = newTable[ScoreEvent](&database); err != nil {
+		return nil, err
+	}
 	if database.sponsorSlideTable, err = newTable[SponsorSlide](&database); err != nil {
 		return nil, err
 	}
 	if database.teamTable, err = newTable[Team](&database); err != nil {
 		return nil, err
 	}
+	if database.timingProfileTable, err = newTable[TimingProfile](&database); err != nil {
+		return nil, err
+	}
 	if database.userSessionTable, err = newTable[UserSession](&database); err != nil {
 		return nil, err
 	}
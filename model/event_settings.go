@@ -5,7 +5,10 @@
 
 package model
 
-import "github.com/FRCTeam1987/crimson-arena/game"
+import (
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"path/filepath"
+)
 
 type EventSettings struct {
 	Id                          int `db:"id"`
@@ -39,14 +42,52 @@ type EventSettings struct {
 	PauseDurationSec            int
 	TeleopDurationSec           int
 	WarningRemainingDurationSec int
+	GameDefinitionFile          string
+	RankingTiebreakers          string
+	PlayoffTiebreakers          string
+	RequireHeadRefApproval      bool
+	NumMatchesBeforeReplay      int
+	// Number of teams on each alliance in a match, up to game.MaxTeamsPerAlliance.
+	TeamsPerAlliance int
+	// Number of scoring panels per alliance that must commit their scores before the match results can be committed.
+	ScoringPanelsPerAlliance int
+	// ID of the timing profile to use for each match type; match types without one use the durations above.
+	TimingProfileIds map[string]int
+	// How strictly each match-start readiness rule is enforced, keyed by rule ID; rules without an entry are required.
+	ReadinessRuleModes map[string]ReadinessRuleMode
+	// Robot battery voltage below which, and driver station to robot trip time above which, an alert is raised for the
+	// FTA during a match.
+	DsAlertBatteryVoltage float64
+	DsAlertTripTimeMs     int
+	// Length of the connection history kept for each alliance station.
+	DsHistoryDurationSec int
 }
 
+type ReadinessRuleMode string
+
+const (
+	// The match can't be started while the check is failing.
+	ReadinessRuleRequired ReadinessRuleMode = "required"
+	// The FTA can start the match despite the check failing after giving a reason for overriding it.
+	ReadinessRuleOverridable ReadinessRuleMode = "overridable"
+	// The check is skipped entirely.
+	ReadinessRuleDisabled ReadinessRuleMode = "disabled"
+)
+
+// The valid readiness rule modes, in the order in which they should be offered.
+var ReadinessRuleModes = []ReadinessRuleMode{ReadinessRuleRequired, ReadinessRuleOverridable, ReadinessRuleDisabled}
+
 func (database *Database) GetEventSettings() (*EventSettings, error) {
 	allEventSettings, err := database.eventSettingsTable.getAll()
 	if err != nil {
 		return nil, err
 	}
 	if len(allEventSettings) == 1 {
+		if allEventSettings[0].TeamsPerAlliance == 0 {
+			// The record predates the setting; default to the standard alliance size.
+			allEventSettings[0].TeamsPerAlliance = 3
+		}
+		allEventSettings[0].setDsAlertDefaults()
 		return &allEventSettings[0], nil
 	}
 
@@ -67,7 +108,10 @@ func (database *Database) GetEventSettings() (*EventSettings, error) {
 		PauseDurationSec:            game.MatchTiming.PauseDurationSec,
 		TeleopDurationSec:           game.MatchTiming.TeleopDurationSec,
 		WarningRemainingDurationSec: game.MatchTiming.WarningRemainingDurationSec,
+		NumMatchesBeforeReplay:      2,
+		TeamsPerAlliance:            3,
 	}
+	eventSettings.setDsAlertDefaults()
 
 	if err := database.eventSettingsTable.create(&eventSettings); err != nil {
 		return nil, err
@@ -78,3 +122,38 @@ func (database *Database) GetEventSettings() (*EventSettings, error) {
 func (database *Database) UpdateEventSettings(eventSettings *EventSettings) error {
 	return database.eventSettingsTable.update(eventSettings)
 }
+
+// Fills in the default for each driver station alert setting that is unset, either because the record predates the
+// setting or because it was left blank.
+func (eventSettings *EventSettings) setDsAlertDefaults() {
+	if eventSettings.DsAlertBatteryVoltage == 0 {
+		eventSettings.DsAlertBatteryVoltage = 7.0
+	}
+	if eventSettings.DsAlertTripTimeMs == 0 {
+		eventSettings.DsAlertTripTimeMs = 50
+	}
+	if eventSettings.DsHistoryDurationSec == 0 {
+		eventSettings.DsHistoryDurationSec = 60
+	}
+}
+
+// Returns how strictly the readiness rule with the given ID is to be enforced.
+func (eventSettings *EventSettings) GetReadinessRuleMode(ruleId string) ReadinessRuleMode {
+	if mode, ok := eventSettings.ReadinessRuleModes[ruleId]; ok {
+		return mode
+	}
+	return ReadinessRuleRequired
+}
+
+// Loads the game definition file configured for the event, or returns the default definition if there is none.
+// Relative paths are resolved against the base directory.
+func (eventSettings *EventSettings) LoadGameDefinition() (*game.GameDefinition, error) {
+	if eventSettings.GameDefinitionFile == "" {
+		return game.DefaultGameDefinition(), nil
+	}
+	path := eventSettings.GameDefinitionFile
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(BaseDir, path)
+	}
+	return game.LoadGameDefinition(path)
+}
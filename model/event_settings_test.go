@@ -4,6 +4,7 @@
 package model
 
 import (
+	"github.com/FRCTeam1987/crimson-arena/game"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -32,6 +33,11 @@ func TestEventSettingsReadWrite(t *testing.T) {
 			PauseDurationSec:            2,
 			TeleopDurationSec:           135,
 			WarningRemainingDurationSec: 30,
+			NumMatchesBeforeReplay:      2,
+			TeamsPerAlliance:            3,
+			DsAlertBatteryVoltage:       7.0,
+			DsAlertTripTimeMs:           50,
+			DsHistoryDurationSec:        60,
 		},
 		*eventSettings,
 	)
@@ -45,4 +51,29 @@ func TestEventSettingsReadWrite(t *testing.T) {
 	eventSettings2, err := db.GetEventSettings()
 	assert.Nil(t, err)
 	assert.Equal(t, eventSettings, eventSettings2)
+
+	// Check that settings saved before the number of teams per alliance was configurable get the standard number.
+	eventSettings.TeamsPerAlliance = 0
+	assert.Nil(t, db.UpdateEventSettings(eventSettings))
+	eventSettings2, err = db.GetEventSettings()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, eventSettings2.TeamsPerAlliance)
+}
+
+func TestEventSettingsLoadGameDefinition(t *testing.T) {
+	BaseDir = ".."
+	eventSettings := EventSettings{}
+	definition, err := eventSettings.LoadGameDefinition()
+	assert.Nil(t, err)
+	assert.Equal(t, game.DefaultGameDefinition(), definition)
+
+	eventSettings.GameDefinitionFile = "games/reefscape_2025.yaml"
+	definition, err = eventSettings.LoadGameDefinition()
+	if assert.Nil(t, err) {
+		assert.Equal(t, "REEFSCAPE", definition.Name)
+	}
+
+	eventSettings.GameDefinitionFile = "games/nonexistent.yaml"
+	_, err = eventSettings.LoadGameDefinition()
+	assert.NotNil(t, err)
 }
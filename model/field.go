@@ -0,0 +1,52 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Model and datastore CRUD methods for a playing field run from this server, when there is more than one.
+
+package model
+
+import "sort"
+
+type Field struct {
+	Id   int `db:"id"`
+	Name string
+	// Address of this server on the field's network, to which its driver stations connect.
+	ServerIpAddress string
+	ApAddress       string
+	ApUsername      string
+	ApPassword      string
+	ApTeamChannel   int
+	SwitchAddress   string
+	SwitchPassword  string
+	PlcAddress      string
+}
+
+func (database *Database) CreateField(field *Field) error {
+	return database.fieldTable.create(field)
+}
+
+func (database *Database) GetFieldById(id int) (*Field, error) {
+	return database.fieldTable.getById(id)
+}
+
+func (database *Database) UpdateField(field *Field) error {
+	return database.fieldTable.update(field)
+}
+
+func (database *Database) DeleteField(id int) error {
+	return database.fieldTable.delete(id)
+}
+
+func (database *Database) TruncateFields() error {
+	return database.fieldTable.truncate()
+}
+
+func (database *Database) GetAllFields() ([]Field, error) {
+	fields, err := database.fieldTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Id < fields[j].Id
+	})
+	return fields, nil
+}
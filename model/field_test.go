@@ -0,0 +1,56 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetNonexistentField(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	field, err := db.GetFieldById(1114)
+	assert.Nil(t, err)
+	assert.Nil(t, field)
+}
+
+func TestFieldCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	field := Field{Name: "Field 1", ServerIpAddress: "10.0.100.5", ApAddress: "10.0.100.2", ApUsername: "root",
+		ApPassword: "password", ApTeamChannel: 157, SwitchAddress: "10.0.100.3", SwitchPassword: "cisco",
+		PlcAddress: "10.0.100.10"}
+	assert.Nil(t, db.CreateField(&field))
+	field2, err := db.GetFieldById(1)
+	assert.Nil(t, err)
+	assert.Equal(t, field, *field2)
+
+	field2.Id = 0
+	field2.Name = "Field 2"
+	field2.ServerIpAddress = "10.0.200.5"
+	assert.Nil(t, db.CreateField(field2))
+	fields, err := db.GetAllFields()
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(fields)) {
+		assert.Equal(t, field, fields[0])
+		assert.Equal(t, *field2, fields[1])
+	}
+
+	field.PlcAddress = "10.0.100.11"
+	assert.Nil(t, db.UpdateField(&field))
+	field2, err = db.GetFieldById(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.100.11", field2.PlcAddress)
+
+	assert.Nil(t, db.DeleteField(field.Id))
+	field2, err = db.GetFieldById(1)
+	assert.Nil(t, err)
+	assert.Nil(t, field2)
+
+	assert.Nil(t, db.TruncateFields())
+	fields, _ = db.GetAllFields()
+	assert.Empty(t, fields)
+}
@@ -6,6 +6,7 @@
 package model
 
 import (
+	"fmt"
 	"github.com/FRCTeam1987/crimson-arena/game"
 	"sort"
 	"strings"
@@ -37,6 +38,41 @@ type Match struct {
 	StartedAt        time.Time
 	ScoreCommittedAt time.Time
 	Status           game.MatchStatus
+	// Description of the playoff tiebreak criterion that decided the match, if the final scores were tied.
+	TiebreakReason string
+	// Game-specific data sent to each alliance's driver stations during the match.
+	RedGameData  string
+	BlueGameData string
+	// Stoppages of the match due to field faults, in the order in which they occurred.
+	FieldFaults []FieldFault
+	// ID of the field on which the match is scheduled to be played, or 0 if it can be played on any field.
+	FieldId int
+	// Teams in the fourth station of each alliance, which is only used at events with four teams per alliance.
+	Red4             int
+	Red4IsSurrogate  bool
+	Blue4            int
+	Blue4IsSurrogate bool
+	// Failing match-start readiness checks that were overridden to allow the match to start.
+	ReadinessOverrides []ReadinessOverride
+}
+
+// A stoppage of a running match during which all robots were disabled and the match clock was frozen.
+type FieldFault struct {
+	StartedAt time.Time
+	// Zero if the match was aborted rather than resumed.
+	ResumedAt    time.Time
+	MatchTimeSec float64
+	PeriodName   string
+	Reason       string
+}
+
+// A failing match-start readiness check that the FTA chose to override, along with who approved it and why.
+type ReadinessOverride struct {
+	RuleId       string
+	Failure      string
+	Reason       string
+	OverriddenBy string
+	OverriddenAt time.Time
 }
 
 func (database *Database) CreateMatch(match *Match) error {
@@ -120,6 +156,109 @@ func (match *Match) IsComplete() bool {
 	return match.Status != game.MatchNotPlayed
 }
 
+// Returns true if the match can be played on the field with the given ID, where 0 denotes a server running only a
+// single field.
+func (match *Match) IsOnField(fieldId int) bool {
+	return fieldId == 0 || match.FieldId == 0 || match.FieldId == fieldId
+}
+
+// Returns the IDs of the teams in the red alliance's stations, in order, with zero for empty or unused stations.
+func (match *Match) RedTeamIds() [game.MaxTeamsPerAlliance]int {
+	return [game.MaxTeamsPerAlliance]int{match.Red1, match.Red2, match.Red3, match.Red4}
+}
+
+// Returns the IDs of the teams in the blue alliance's stations, in order, with zero for empty or unused stations.
+func (match *Match) BlueTeamIds() [game.MaxTeamsPerAlliance]int {
+	return [game.MaxTeamsPerAlliance]int{match.Blue1, match.Blue2, match.Blue3, match.Blue4}
+}
+
+// Returns whether the team in each of the red alliance's stations is a surrogate, in order.
+func (match *Match) RedSurrogates() [game.MaxTeamsPerAlliance]bool {
+	return [game.MaxTeamsPerAlliance]bool{
+		match.Red1IsSurrogate, match.Red2IsSurrogate, match.Red3IsSurrogate, match.Red4IsSurrogate,
+	}
+}
+
+// Returns whether the team in each of the blue alliance's stations is a surrogate, in order.
+func (match *Match) BlueSurrogates() [game.MaxTeamsPerAlliance]bool {
+	return [game.MaxTeamsPerAlliance]bool{
+		match.Blue1IsSurrogate, match.Blue2IsSurrogate, match.Blue3IsSurrogate, match.Blue4IsSurrogate,
+	}
+}
+
+// Returns the IDs of the teams in each station used when there are the given number of teams per alliance, in the same
+// order as AllianceStationNames.
+func (match *Match) TeamIds(teamsPerAlliance int) []int {
+	redTeamIds := match.RedTeamIds()
+	blueTeamIds := match.BlueTeamIds()
+	return append(append([]int{}, redTeamIds[:teamsPerAlliance]...), blueTeamIds[:teamsPerAlliance]...)
+}
+
+// Returns the ID of the team in the given alliance station (e.g. "R1"), or zero if it is empty or invalid.
+func (match *Match) TeamIdForStation(station string) int {
+	if teamId, _ := match.stationFields(station); teamId != nil {
+		return *teamId
+	}
+	return 0
+}
+
+// Returns true if the team in the given alliance station (e.g. "R1") is a surrogate.
+func (match *Match) IsSurrogateForStation(station string) bool {
+	if _, isSurrogate := match.stationFields(station); isSurrogate != nil {
+		return *isSurrogate
+	}
+	return false
+}
+
+// Sets the team in the given alliance station (e.g. "R1"), doing nothing if the station is invalid.
+func (match *Match) SetTeamIdForStation(station string, teamId int) {
+	if stationTeamId, _ := match.stationFields(station); stationTeamId != nil {
+		*stationTeamId = teamId
+	}
+}
+
+// Sets whether the team in the given alliance station (e.g. "R1") is a surrogate, doing nothing if the station is
+// invalid.
+func (match *Match) SetIsSurrogateForStation(station string, isSurrogate bool) {
+	if _, stationIsSurrogate := match.stationFields(station); stationIsSurrogate != nil {
+		*stationIsSurrogate = isSurrogate
+	}
+}
+
+// Returns pointers to the fields holding the team ID and surrogate flag for the given alliance station, or nils if it
+// is invalid.
+func (match *Match) stationFields(station string) (*int, *bool) {
+	switch station {
+	case "R1":
+		return &match.Red1, &match.Red1IsSurrogate
+	case "R2":
+		return &match.Red2, &match.Red2IsSurrogate
+	case "R3":
+		return &match.Red3, &match.Red3IsSurrogate
+	case "R4":
+		return &match.Red4, &match.Red4IsSurrogate
+	case "B1":
+		return &match.Blue1, &match.Blue1IsSurrogate
+	case "B2":
+		return &match.Blue2, &match.Blue2IsSurrogate
+	case "B3":
+		return &match.Blue3, &match.Blue3IsSurrogate
+	case "B4":
+		return &match.Blue4, &match.Blue4IsSurrogate
+	}
+	return nil, nil
+}
+
+// Returns the names of the alliance stations used when there are the given number of teams per alliance, red first.
+func AllianceStationNames(teamsPerAlliance int) []string {
+	var redStations, blueStations []string
+	for i := 1; i <= teamsPerAlliance; i++ {
+		redStations = append(redStations, fmt.Sprintf("R%d", i))
+		blueStations = append(blueStations, fmt.Sprintf("B%d", i))
+	}
+	return append(redStations, blueStations...)
+}
+
 func (match *Match) CapitalizedType() string {
 	if match.Type == "" || match.Type == "test" {
 		return ""
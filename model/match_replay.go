@@ -0,0 +1,200 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Model and datastore CRUD methods for a request to replay a match that has already been played.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+type ReplayReason string
+
+const (
+	FieldFaultReplay      ReplayReason = "fieldFault"
+	RefereeDecisionReplay ReplayReason = "refereeDecision"
+	ArenaFaultReplay      ReplayReason = "arenaFault"
+)
+
+// The valid replay reasons, in the order in which they should be offered.
+var ReplayReasons = []ReplayReason{FieldFaultReplay, RefereeDecisionReplay, ArenaFaultReplay}
+
+var replayReasonNames = map[ReplayReason]string{
+	FieldFaultReplay:      "Field fault",
+	RefereeDecisionReplay: "Referee decision",
+	ArenaFaultReplay:      "Arena fault",
+}
+
+type MatchReplay struct {
+	Id          int `db:"id"`
+	MatchId     int
+	Reason      ReplayReason
+	RequestedBy string
+	Notes       string
+	RequestedAt time.Time
+	// ID of the match after which the replay is to be run, or zero to run it next.
+	AfterMatchId int
+	// When the replay was played and the play number of the result it produced; zero while it is pending.
+	CompletedAt time.Time
+	PlayNumber  int
+}
+
+// Returns true if the given reason is one of the recognized ones.
+func IsValidReplayReason(reason ReplayReason) bool {
+	_, ok := replayReasonNames[reason]
+	return ok
+}
+
+// Returns a human-readable description of the reason.
+func (reason ReplayReason) DisplayName() string {
+	if name, ok := replayReasonNames[reason]; ok {
+		return name
+	}
+	return string(reason)
+}
+
+func (database *Database) CreateMatchReplay(matchReplay *MatchReplay) error {
+	return database.matchReplayTable.create(matchReplay)
+}
+
+func (database *Database) GetMatchReplayById(id int) (*MatchReplay, error) {
+	return database.matchReplayTable.getById(id)
+}
+
+func (database *Database) UpdateMatchReplay(matchReplay *MatchReplay) error {
+	return database.matchReplayTable.update(matchReplay)
+}
+
+func (database *Database) DeleteMatchReplay(id int) error {
+	return database.matchReplayTable.delete(id)
+}
+
+func (database *Database) TruncateMatchReplays() error {
+	return database.matchReplayTable.truncate()
+}
+
+// Returns all replays, whether pending or completed, in the order in which they were requested.
+func (database *Database) GetAllMatchReplays() ([]MatchReplay, error) {
+	matchReplays, err := database.matchReplayTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(matchReplays, func(i, j int) bool {
+		return matchReplays[i].Id < matchReplays[j].Id
+	})
+	return matchReplays, nil
+}
+
+// Returns the replay of the given match that has yet to be played, or nil if there isn't one.
+func (database *Database) GetPendingMatchReplay(matchId int) (*MatchReplay, error) {
+	matchReplays, err := database.GetAllMatchReplays()
+	if err != nil {
+		return nil, err
+	}
+	for i, matchReplay := range matchReplays {
+		if matchReplay.MatchId == matchId && matchReplay.IsPending() {
+			return &matchReplays[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Marks the pending replay of the given match, if there is one, as having been played with the given play number.
+func (database *Database) CompletePendingMatchReplay(matchId, playNumber int) error {
+	matchReplay, err := database.GetPendingMatchReplay(matchId)
+	if err != nil || matchReplay == nil {
+		return err
+	}
+	matchReplay.CompletedAt = time.Now()
+	matchReplay.PlayNumber = playNumber
+	return database.UpdateMatchReplay(matchReplay)
+}
+
+// Saves the given replay request, slotting it into the queue of matches of the given type after the given number of
+// unplayed matches on the replayed match's field. The replay goes after the last unplayed match if there are fewer
+// than that many.
+func (database *Database) QueueMatchReplay(matchReplay *MatchReplay, matchType string, numMatchesBefore int) error {
+	queuedMatches, queuedReplays, err := database.GetMatchQueue(matchType)
+	if err != nil {
+		return err
+	}
+
+	// Only count matches on the replayed match's field, since the other fields' matches don't hold it up.
+	replayedMatch, err := database.GetMatchById(matchReplay.MatchId)
+	if err != nil {
+		return err
+	}
+	fieldId := 0
+	if replayedMatch != nil {
+		fieldId = replayedMatch.FieldId
+	}
+
+	matchReplay.AfterMatchId = 0
+	for i, match := range queuedMatches {
+		if numMatchesBefore <= 0 {
+			break
+		}
+		if queuedReplays[i] == nil && match.IsOnField(fieldId) {
+			matchReplay.AfterMatchId = match.Id
+			numMatchesBefore--
+		}
+	}
+	return database.CreateMatchReplay(matchReplay)
+}
+
+// Returns the unplayed matches of the given type in the order in which they are to be run, with each pending replay
+// slotted in after the match it was queued behind. Replays whose slot has already passed come first. The returned
+// replays are parallel to the matches, and nil for matches that aren't replays.
+func (database *Database) GetMatchQueue(matchType string) ([]Match, []*MatchReplay, error) {
+	matches, err := database.GetMatchesByType(matchType)
+	if err != nil {
+		return nil, nil, err
+	}
+	matchReplays, err := database.GetAllMatchReplays()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matchesById := make(map[int]Match, len(matches))
+	for _, match := range matches {
+		matchesById[match.Id] = match
+	}
+	replaysByAfterMatchId := make(map[int][]*MatchReplay)
+	hasPendingReplay := make(map[int]bool)
+	for i, matchReplay := range matchReplays {
+		if _, ok := matchesById[matchReplay.MatchId]; !ok || !matchReplay.IsPending() {
+			continue
+		}
+		hasPendingReplay[matchReplay.MatchId] = true
+		afterMatchId := matchReplay.AfterMatchId
+		if afterMatch, ok := matchesById[afterMatchId]; !ok || afterMatch.IsComplete() {
+			afterMatchId = 0
+		}
+		replaysByAfterMatchId[afterMatchId] = append(replaysByAfterMatchId[afterMatchId], &matchReplays[i])
+	}
+
+	var queuedMatches []Match
+	var queuedReplays []*MatchReplay
+	appendReplays := func(afterMatchId int) {
+		for _, matchReplay := range replaysByAfterMatchId[afterMatchId] {
+			queuedMatches = append(queuedMatches, matchesById[matchReplay.MatchId])
+			queuedReplays = append(queuedReplays, matchReplay)
+		}
+	}
+	appendReplays(0)
+	for _, match := range matches {
+		if match.IsComplete() || hasPendingReplay[match.Id] {
+			continue
+		}
+		queuedMatches = append(queuedMatches, match)
+		queuedReplays = append(queuedReplays, nil)
+		appendReplays(match.Id)
+	}
+	return queuedMatches, queuedReplays, nil
+}
+
+// Returns true if the replay has yet to be played.
+func (matchReplay *MatchReplay) IsPending() bool {
+	return matchReplay.CompletedAt.IsZero()
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetNonexistentMatchReplay(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	matchReplay, err := db.GetMatchReplayById(1114)
+	assert.Nil(t, err)
+	assert.Nil(t, matchReplay)
+	matchReplay, err = db.GetPendingMatchReplay(1114)
+	assert.Nil(t, err)
+	assert.Nil(t, matchReplay)
+}
+
+func TestMatchReplayCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	matchReplay := MatchReplay{MatchId: 254, Reason: FieldFaultReplay, RequestedBy: "FTA", Notes: "Field didn't start",
+		RequestedAt: time.Unix(1000, 0).UTC()}
+	assert.Nil(t, db.CreateMatchReplay(&matchReplay))
+	matchReplay2, err := db.GetMatchReplayById(matchReplay.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, matchReplay, *matchReplay2)
+	matchReplay2, err = db.GetPendingMatchReplay(254)
+	assert.Nil(t, err)
+	assert.Equal(t, matchReplay, *matchReplay2)
+
+	assert.Nil(t, db.CompletePendingMatchReplay(254, 2))
+	matchReplay2, _ = db.GetMatchReplayById(matchReplay.Id)
+	assert.False(t, matchReplay2.IsPending())
+	assert.Equal(t, 2, matchReplay2.PlayNumber)
+	matchReplay2, err = db.GetPendingMatchReplay(254)
+	assert.Nil(t, err)
+	assert.Nil(t, matchReplay2)
+
+	assert.Nil(t, db.DeleteMatchReplay(matchReplay.Id))
+	matchReplay2, err = db.GetMatchReplayById(matchReplay.Id)
+	assert.Nil(t, err)
+	assert.Nil(t, matchReplay2)
+}
+
+func TestTruncateMatchReplays(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	assert.Nil(t, db.CreateMatchReplay(&MatchReplay{MatchId: 254, Reason: ArenaFaultReplay}))
+	assert.Nil(t, db.TruncateMatchReplays())
+	matchReplays, err := db.GetAllMatchReplays()
+	assert.Nil(t, err)
+	assert.Empty(t, matchReplays)
+}
+
+func TestReplayReasons(t *testing.T) {
+	assert.True(t, IsValidReplayReason(RefereeDecisionReplay))
+	assert.False(t, IsValidReplayReason("bored"))
+	assert.Equal(t, "Referee decision", RefereeDecisionReplay.DisplayName())
+	assert.Equal(t, "bored", ReplayReason("bored").DisplayName())
+}
+
+func TestMatchQueue(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	for _, displayName := range []string{"1", "2", "3", "4", "5"} {
+		db.CreateMatch(&Match{Type: "qualification", DisplayName: displayName})
+	}
+	db.CreateMatch(&Match{Type: "practice", DisplayName: "1"})
+	for _, id := range []int{1, 2} {
+		match, _ := db.GetMatchById(id)
+		match.Status = game.RedWonMatch
+		db.UpdateMatch(match)
+	}
+	assertQueue := func(expectedMatchIds []int, expectedReplayMatchIds []int) {
+		matches, replays, err := db.GetMatchQueue("qualification")
+		assert.Nil(t, err)
+		var matchIds, replayMatchIds []int
+		for i, match := range matches {
+			matchIds = append(matchIds, match.Id)
+			if replays[i] != nil {
+				replayMatchIds = append(replayMatchIds, replays[i].MatchId)
+			}
+		}
+		assert.Equal(t, expectedMatchIds, matchIds)
+		assert.Equal(t, expectedReplayMatchIds, replayMatchIds)
+	}
+	assertQueue([]int{3, 4, 5}, nil)
+
+	// Queue replays at various slots.
+	assert.Nil(t, db.QueueMatchReplay(&MatchReplay{MatchId: 1, Reason: FieldFaultReplay}, "qualification", 2))
+	assertQueue([]int{3, 4, 1, 5}, []int{1})
+	assert.Nil(t, db.QueueMatchReplay(&MatchReplay{MatchId: 2, Reason: ArenaFaultReplay}, "qualification", 0))
+	assertQueue([]int{2, 3, 4, 1, 5}, []int{2, 1})
+	assert.Nil(t, db.QueueMatchReplay(&MatchReplay{MatchId: 6, Reason: ArenaFaultReplay}, "practice", 10))
+	assertQueue([]int{2, 3, 4, 1, 5}, []int{2, 1})
+
+	// Check that a replay moves to the front once the match it was queued behind has been played, keeping the replays
+	// there in the order in which they were requested.
+	match, _ := db.GetMatchById(4)
+	match.Status = game.BlueWonMatch
+	db.UpdateMatch(match)
+	assertQueue([]int{1, 2, 3, 5}, []int{1, 2})
+
+	// Check that a completed replay drops out of the queue.
+	assert.Nil(t, db.CompletePendingMatchReplay(2, 2))
+	assertQueue([]int{1, 3, 5}, []int{1})
+
+	// Check that a replay queued beyond the end of the schedule goes last.
+	assert.Nil(t, db.CompletePendingMatchReplay(1, 2))
+	assert.Nil(t, db.QueueMatchReplay(&MatchReplay{MatchId: 4, Reason: RefereeDecisionReplay}, "qualification", 5))
+	assertQueue([]int{3, 5, 4}, []int{4})
+}
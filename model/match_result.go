@@ -7,6 +7,14 @@ package model
 
 import (
 	"github.com/FRCTeam1987/crimson-arena/game"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	YellowCard = "yellow"
+	RedCard    = "red"
 )
 
 type MatchResult struct {
@@ -16,6 +24,18 @@ type MatchResult struct {
 	MatchType  string
 	RedScore   *game.Score
 	BlueScore  *game.Score
+	RedCards   map[string]string
+	BlueCards  map[string]string
+	// Name of the head referee who signed off on the result and when; blank if it was committed without approval.
+	ApprovedBy string
+	ApprovedAt time.Time
+	// Samples of both alliances' scores taken over the course of the match; empty if it wasn't played on the field.
+	ScoreTimeline []game.ScoreTimelineSample
+	// When the result was last committed or edited.
+	CommittedAt time.Time
+	// Number of the earlier play that this one was copied from when it was promoted back to the official result; zero
+	// if the match was actually played.
+	RestoredFromPlay int
 }
 
 // Returns a new match result object with empty slices instead of nil.
@@ -23,6 +43,8 @@ func NewMatchResult() *MatchResult {
 	matchResult := new(MatchResult)
 	matchResult.RedScore = new(game.Score)
 	matchResult.BlueScore = new(game.Score)
+	matchResult.RedCards = make(map[string]string)
+	matchResult.BlueCards = make(map[string]string)
 	return matchResult
 }
 
@@ -46,6 +68,25 @@ func (database *Database) GetMatchResultForMatch(matchId int) (*MatchResult, err
 	return mostRecentMatchResult, nil
 }
 
+// Returns every recorded play of the given match, in order of play number. The last one is the official result.
+func (database *Database) GetMatchResultsForMatch(matchId int) ([]MatchResult, error) {
+	matchResults, err := database.matchResultTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var matchingMatchResults []MatchResult
+	for _, matchResult := range matchResults {
+		if matchResult.MatchId == matchId {
+			matchingMatchResults = append(matchingMatchResults, matchResult)
+		}
+	}
+	sort.Slice(matchingMatchResults, func(i, j int) bool {
+		return matchingMatchResults[i].PlayNumber < matchingMatchResults[j].PlayNumber
+	})
+	return matchingMatchResults, nil
+}
+
 func (database *Database) UpdateMatchResult(matchResult *MatchResult) error {
 	return database.matchResultTable.update(matchResult)
 }
@@ -54,16 +95,93 @@ func (database *Database) DeleteMatchResult(id int) error {
 	return database.matchResultTable.delete(id)
 }
 
+// Deletes every recorded play of the given match.
+func (database *Database) DeleteMatchResultsForMatch(matchId int) error {
+	matchResults, err := database.matchResultTable.getAll()
+	if err != nil {
+		return err
+	}
+
+	for _, matchResult := range matchResults {
+		if matchResult.MatchId == matchId {
+			if err = database.DeleteMatchResult(matchResult.Id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (database *Database) TruncateMatchResults() error {
 	return database.matchResultTable.truncate()
 }
 
 // Calculates and returns the summary fields used for ranking and display for the red alliance.
 func (matchResult *MatchResult) RedScoreSummary() *game.ScoreSummary {
-	return matchResult.RedScore.Summarize()
+	return matchResult.RedScore.Summarize(matchResult.BlueScore)
 }
 
 // Calculates and returns the summary fields used for ranking and display for the blue alliance.
 func (matchResult *MatchResult) BlueScoreSummary() *game.ScoreSummary {
-	return matchResult.BlueScore.Summarize()
+	return matchResult.BlueScore.Summarize(matchResult.RedScore)
+}
+
+// Returns true if the given team received a red card in the match.
+func (matchResult *MatchResult) IsTeamDisqualified(teamId int) bool {
+	teamIdString := strconv.Itoa(teamId)
+	return matchResult.RedCards[teamIdString] == RedCard || matchResult.BlueCards[teamIdString] == RedCard
+}
+
+// Marks an alliance as disqualified from a playoff match if any of its teams received a red card.
+func (matchResult *MatchResult) CorrectPlayoffScore() {
+	matchResult.RedScore.PlayoffDq = hasRedCard(matchResult.RedCards)
+	matchResult.BlueScore.PlayoffDq = hasRedCard(matchResult.BlueCards)
+}
+
+// Returns a copy of the match result, sharing its scores, in which any yellow card issued to a team in the given set
+// of teams already carrying a yellow card is escalated to a red card, as per FRC rules. The escalation is worked out
+// whenever it is needed rather than saved, so that changing the cards of an earlier match can undo it.
+func (matchResult *MatchResult) WithCardCarryover(carriedYellowCards map[int]bool) *MatchResult {
+	escalate := func(cards map[string]string) map[string]string {
+		escalatedCards := make(map[string]string, len(cards))
+		for teamIdString, card := range cards {
+			teamId, _ := strconv.Atoi(teamIdString)
+			if card == YellowCard && carriedYellowCards[teamId] {
+				card = RedCard
+			}
+			escalatedCards[teamIdString] = card
+		}
+		return escalatedCards
+	}
+	carriedMatchResult := *matchResult
+	carriedMatchResult.RedCards = escalate(matchResult.RedCards)
+	carriedMatchResult.BlueCards = escalate(matchResult.BlueCards)
+	return &carriedMatchResult
+}
+
+// Adds each team that received a card in the match to the given set of teams carrying a yellow card, since a team
+// that has received either card carries a yellow card thereafter.
+func (matchResult *MatchResult) AddCarriedYellowCards(carriedYellowCards map[int]bool) {
+	for _, cards := range []map[string]string{matchResult.RedCards, matchResult.BlueCards} {
+		for teamIdString, card := range cards {
+			if IsValidCard(card) {
+				teamId, _ := strconv.Atoi(teamIdString)
+				carriedYellowCards[teamId] = true
+			}
+		}
+	}
+}
+
+// Returns true if the given card is one that can be issued to a team.
+func IsValidCard(card string) bool {
+	return card == YellowCard || card == RedCard
+}
+
+func hasRedCard(cards map[string]string) bool {
+	for _, card := range cards {
+		if card == RedCard {
+			return true
+		}
+	}
+	return false
 }
@@ -27,7 +27,7 @@ func TestMatchResultCrud(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, matchResult, matchResult2)
 
-	matchResult.BlueScore.EndgamePoints = 1234
+	matchResult.BlueScore.ElementCounts["endgame"] = 1234
 	assert.Nil(t, db.UpdateMatchResult(matchResult))
 	matchResult2, err = db.GetMatchResultForMatch(254)
 	assert.Nil(t, err)
@@ -67,3 +67,61 @@ func TestGetMatchResultForMatch(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, matchResult2, matchResult4)
 }
+
+func TestGetMatchResultsForMatch(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	matchResults, err := db.GetMatchResultsForMatch(254)
+	assert.Nil(t, err)
+	assert.Empty(t, matchResults)
+
+	matchResult := BuildTestMatchResult(254, 2)
+	assert.Nil(t, db.CreateMatchResult(matchResult))
+	matchResult2 := BuildTestMatchResult(1114, 1)
+	assert.Nil(t, db.CreateMatchResult(matchResult2))
+	matchResult3 := BuildTestMatchResult(254, 1)
+	assert.Nil(t, db.CreateMatchResult(matchResult3))
+
+	matchResults, err = db.GetMatchResultsForMatch(254)
+	assert.Nil(t, err)
+	assert.Equal(t, []MatchResult{*matchResult3, *matchResult}, matchResults)
+}
+
+func TestDeleteMatchResultsForMatch(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	assert.Nil(t, db.CreateMatchResult(BuildTestMatchResult(254, 1)))
+	assert.Nil(t, db.CreateMatchResult(BuildTestMatchResult(254, 2)))
+	otherMatchResult := BuildTestMatchResult(1114, 1)
+	assert.Nil(t, db.CreateMatchResult(otherMatchResult))
+
+	assert.Nil(t, db.DeleteMatchResultsForMatch(254))
+	matchResult, err := db.GetMatchResultForMatch(254)
+	assert.Nil(t, err)
+	assert.Nil(t, matchResult)
+	matchResult, err = db.GetMatchResultForMatch(1114)
+	assert.Nil(t, err)
+	assert.Equal(t, otherMatchResult, matchResult)
+}
+
+func TestMatchResultCards(t *testing.T) {
+	matchResult := BuildTestMatchResult(254, 1)
+	assert.False(t, matchResult.IsTeamDisqualified(1868))
+	matchResult.BlueCards["1114"] = "red"
+	assert.True(t, matchResult.IsTeamDisqualified(1114))
+	assert.False(t, matchResult.IsTeamDisqualified(254))
+
+	// A red card in the playoffs forfeits the alliance's points.
+	matchResult.CorrectPlayoffScore()
+	assert.False(t, matchResult.RedScore.PlayoffDq)
+	assert.True(t, matchResult.BlueScore.PlayoffDq)
+	assert.Equal(t, 0, matchResult.BlueScoreSummary().Score)
+	assert.Equal(t, 155, matchResult.RedScoreSummary().Score)
+
+	delete(matchResult.BlueCards, "1114")
+	matchResult.CorrectPlayoffScore()
+	assert.False(t, matchResult.BlueScore.PlayoffDq)
+	assert.Equal(t, 80, matchResult.BlueScoreSummary().Score)
+}
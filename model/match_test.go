@@ -23,8 +23,9 @@ func TestMatchCrud(t *testing.T) {
 	db := setupTestDb(t)
 	defer db.Close()
 
-	match := Match{0, "qualification", "254", time.Now().UTC(), 0, 0, 0, 0, 0, 1, false, 2, false, 3, false, 4, false,
-		5, false, 6, false, time.Now().UTC(), time.Now().UTC(), game.MatchNotPlayed}
+	match := Match{Type: "qualification", DisplayName: "254", Time: time.Now().UTC(), Red1: 1, Red2: 2, Red3: 3,
+		Blue1: 4, Blue2: 5, Blue3: 6, StartedAt: time.Now().UTC(), ScoreCommittedAt: time.Now().UTC(),
+		Status: game.MatchNotPlayed}
 	db.CreateMatch(&match)
 	match2, err := db.GetMatchById(1)
 	assert.Nil(t, err)
@@ -49,8 +50,9 @@ func TestTruncateMatches(t *testing.T) {
 	db := setupTestDb(t)
 	defer db.Close()
 
-	match := Match{0, "qualification", "254", time.Now().UTC(), 0, 0, 0, 0, 0, 1, false, 2, false, 3, false, 4, false,
-		5, false, 6, false, time.Now().UTC(), time.Now().UTC(), game.MatchNotPlayed}
+	match := Match{Type: "qualification", DisplayName: "254", Time: time.Now().UTC(), Red1: 1, Red2: 2, Red3: 3,
+		Blue1: 4, Blue2: 5, Blue3: 6, StartedAt: time.Now().UTC(), ScoreCommittedAt: time.Now().UTC(),
+		Status: game.MatchNotPlayed}
 	db.CreateMatch(&match)
 	db.TruncateMatches()
 	match2, err := db.GetMatchById(1)
@@ -92,14 +94,17 @@ func TestGetMatchesByType(t *testing.T) {
 	db := setupTestDb(t)
 	defer db.Close()
 
-	match := Match{0, "qualification", "1", time.Now().UTC(), 0, 0, 0, 0, 0, 1, false, 2, false, 3, false, 4, false,
-		5, false, 6, false, time.Now().UTC(), time.Now().UTC(), game.MatchNotPlayed}
+	match := Match{Type: "qualification", DisplayName: "1", Time: time.Now().UTC(), Red1: 1, Red2: 2, Red3: 3,
+		Blue1: 4, Blue2: 5, Blue3: 6, StartedAt: time.Now().UTC(), ScoreCommittedAt: time.Now().UTC(),
+		Status: game.MatchNotPlayed}
 	db.CreateMatch(&match)
-	match2 := Match{0, "practice", "1", time.Now().UTC(), 0, 0, 0, 0, 0, 1, false, 2, false, 3, false, 4, false, 5,
-		false, 6, false, time.Now().UTC(), time.Now().UTC(), game.MatchNotPlayed}
+	match2 := Match{Type: "practice", DisplayName: "1", Time: time.Now().UTC(), Red1: 1, Red2: 2, Red3: 3,
+		Blue1: 4, Blue2: 5, Blue3: 6, StartedAt: time.Now().UTC(), ScoreCommittedAt: time.Now().UTC(),
+		Status: game.MatchNotPlayed}
 	db.CreateMatch(&match2)
-	match3 := Match{0, "practice", "2", time.Now().UTC(), 0, 0, 0, 0, 0, 1, false, 2, false, 3, false, 4, false, 5,
-		false, 6, false, time.Now().UTC(), time.Now().UTC(), game.MatchNotPlayed}
+	match3 := Match{Type: "practice", DisplayName: "2", Time: time.Now().UTC(), Red1: 1, Red2: 2, Red3: 3,
+		Blue1: 4, Blue2: 5, Blue3: 6, StartedAt: time.Now().UTC(), ScoreCommittedAt: time.Now().UTC(),
+		Status: game.MatchNotPlayed}
 	db.CreateMatch(&match3)
 
 	matches, err := db.GetMatchesByType("test")
@@ -112,3 +117,34 @@ func TestGetMatchesByType(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 1, len(matches))
 }
+
+func TestMatchIsOnField(t *testing.T) {
+	match := Match{FieldId: 2}
+	assert.True(t, match.IsOnField(0))
+	assert.False(t, match.IsOnField(1))
+	assert.True(t, match.IsOnField(2))
+
+	match.FieldId = 0
+	assert.True(t, match.IsOnField(0))
+	assert.True(t, match.IsOnField(1))
+}
+
+func TestMatchTeamIds(t *testing.T) {
+	assert.Equal(t, []string{"R1", "R2", "B1", "B2"}, AllianceStationNames(2))
+	assert.Equal(t, []string{"R1", "R2", "R3", "R4", "B1", "B2", "B3", "B4"}, AllianceStationNames(4))
+
+	match := Match{Red1: 254, Red2: 1114, Red3: 2056, Blue1: 1678, Blue2: 148, Blue3: 118}
+	assert.Equal(t, []int{254, 1114, 1678, 148}, match.TeamIds(2))
+	assert.Equal(t, []int{254, 1114, 2056, 0, 1678, 148, 118, 0}, match.TeamIds(4))
+
+	match.SetTeamIdForStation("R4", 971)
+	match.SetTeamIdForStation("B2", 604)
+	match.SetTeamIdForStation("X1", 1)
+	match.SetIsSurrogateForStation("B2", true)
+	assert.Equal(t, 971, match.TeamIdForStation("R4"))
+	assert.Equal(t, 604, match.TeamIdForStation("B2"))
+	assert.Equal(t, 0, match.TeamIdForStation("X1"))
+	assert.True(t, match.Blue2IsSurrogate)
+	assert.Equal(t, [game.MaxTeamsPerAlliance]int{254, 1114, 2056, 971}, match.RedTeamIds())
+	assert.Equal(t, [game.MaxTeamsPerAlliance]int{1678, 604, 118, 0}, match.BlueTeamIds())
+}
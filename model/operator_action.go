@@ -0,0 +1,70 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Model and datastore CRUD methods for an audit record of a command issued by the scorekeeper or FTA.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+type OperatorAction struct {
+	Id   int `db:"id"`
+	Time time.Time
+	// Name of the logged-in user, which is empty when authentication is disabled, and the address the command came
+	// from.
+	Username string
+	Address  string
+	FieldId  int
+	MatchId  int
+	// Name of the match as it was displayed at the time, so that the record still makes sense if it is renamed.
+	MatchName string
+	Action    string
+	// The alliance station or other item that the command applied to, if any.
+	Target string
+	// The value of whatever the command changed, before and after it was handled.
+	Before string
+	After  string
+	// Error with which the command was rejected, or empty if it succeeded.
+	Error string
+}
+
+// Criteria for selecting operator actions; fields left empty match any action.
+type OperatorActionFilter struct {
+	Username  string
+	MatchName string
+	Action    string
+	Target    string
+}
+
+func (database *Database) CreateOperatorAction(action *OperatorAction) error {
+	return database.operatorActionTable.create(action)
+}
+
+// Returns the operator actions that match the given filter, in the order in which they were recorded.
+func (database *Database) GetOperatorActions(filter OperatorActionFilter) ([]OperatorAction, error) {
+	actions, err := database.operatorActionTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var matchingActions []OperatorAction
+	for _, action := range actions {
+		if filter.Username != "" && action.Username != filter.Username ||
+			filter.MatchName != "" && action.MatchName != filter.MatchName ||
+			filter.Action != "" && action.Action != filter.Action ||
+			filter.Target != "" && action.Target != filter.Target {
+			continue
+		}
+		matchingActions = append(matchingActions, action)
+	}
+	sort.Slice(matchingActions, func(i, j int) bool {
+		return matchingActions[i].Id < matchingActions[j].Id
+	})
+	return matchingActions, nil
+}
+
+func (database *Database) TruncateOperatorActions() error {
+	return database.operatorActionTable.truncate()
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestOperatorActionCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	actions, err := db.GetOperatorActions(OperatorActionFilter{})
+	assert.Nil(t, err)
+	assert.Empty(t, actions)
+
+	action1 := OperatorAction{Time: time.Now().UTC(), Username: "admin", MatchName: "Q12", Action: "toggleBypass",
+		Target: "R2", Before: "false", After: "true"}
+	action2 := OperatorAction{Time: time.Now().UTC(), Username: "admin", MatchName: "Q12", Action: "startMatch",
+		Before: "PRE_MATCH", After: "START_MATCH"}
+	action3 := OperatorAction{Time: time.Now().UTC(), MatchName: "Q13", Action: "toggleBypass", Target: "B1",
+		Error: "Invalid alliance station"}
+	assert.Nil(t, db.CreateOperatorAction(&action1))
+	assert.Nil(t, db.CreateOperatorAction(&action2))
+	assert.Nil(t, db.CreateOperatorAction(&action3))
+
+	actions, err = db.GetOperatorActions(OperatorActionFilter{})
+	assert.Nil(t, err)
+	assert.Equal(t, []OperatorAction{action1, action2, action3}, actions)
+	actions, _ = db.GetOperatorActions(OperatorActionFilter{Action: "toggleBypass"})
+	assert.Equal(t, []OperatorAction{action1, action3}, actions)
+	actions, _ = db.GetOperatorActions(OperatorActionFilter{Username: "admin", MatchName: "Q12"})
+	assert.Equal(t, []OperatorAction{action1, action2}, actions)
+	actions, _ = db.GetOperatorActions(OperatorActionFilter{Target: "B1"})
+	assert.Equal(t, []OperatorAction{action3}, actions)
+
+	assert.Nil(t, db.TruncateOperatorActions())
+	actions, _ = db.GetOperatorActions(OperatorActionFilter{})
+	assert.Empty(t, actions)
+}
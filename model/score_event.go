@@ -0,0 +1,117 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Model and datastore CRUD methods for an audit record of a single change to an alliance's score.
+
+package model
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"sort"
+	"time"
+)
+
+type ScoreEventSource string
+
+const (
+	ApiScoreEventSource   ScoreEventSource = "api"
+	PanelScoreEventSource ScoreEventSource = "panel"
+	UserScoreEventSource  ScoreEventSource = "user"
+)
+
+type ScoreEvent struct {
+	Id           int `db:"id"`
+	MatchId      int
+	PlayNumber   int
+	MatchTimeSec float64
+	Time         time.Time
+	Source       ScoreEventSource
+	// Further identification of the source, such as the API client's address or the page the change was made from.
+	SourceDetail string
+	Alliance     string
+	Deltas       []game.ScoreDelta
+	// Change in the number of fouls committed by the alliance.
+	FoulsDelta int
+	// Change in the points the alliance scored itself, and in the points it conceded to its opponent through fouls.
+	PointsDelta     int
+	FoulPointsDelta int
+	// The alliance's complete score after the change, from which the log can be replayed.
+	Score *game.Score
+}
+
+// Returns a new event describing the change to the given alliance's score, or nil if nothing changed. The caller is
+// responsible for filling in the match and source.
+func NewScoreEvent(alliance string, previousScore, score *game.Score) *ScoreEvent {
+	if score.Equals(previousScore) {
+		return nil
+	}
+
+	event := ScoreEvent{
+		Time:       time.Now(),
+		Alliance:   alliance,
+		Deltas:     score.Deltas(previousScore),
+		FoulsDelta: len(score.Fouls) - len(previousScore.Fouls),
+		Score:      score.Copy(),
+	}
+
+	// Summarize each score against an empty one to separate the points scored from the foul points conceded.
+	emptyScore := new(game.Score)
+	previousSummary, summary := previousScore.Summarize(emptyScore), score.Summarize(emptyScore)
+	event.PointsDelta = summary.Score - previousSummary.Score
+	event.FoulPointsDelta = emptyScore.Summarize(score).FoulPoints - emptyScore.Summarize(previousScore).FoulPoints
+	return &event
+}
+
+func (database *Database) CreateScoreEvent(event *ScoreEvent) error {
+	return database.scoreEventTable.create(event)
+}
+
+// Creates an event for each alliance whose score differs between the previous and current versions of the result,
+// attributed to the match and play number of the current version.
+func (database *Database) CreateScoreEvents(
+	previousResult, matchResult *MatchResult, matchTimeSec float64, source ScoreEventSource, sourceDetail string,
+) error {
+	for _, alliance := range []struct {
+		name                 string
+		previousScore, score *game.Score
+	}{
+		{"red", previousResult.RedScore, matchResult.RedScore},
+		{"blue", previousResult.BlueScore, matchResult.BlueScore},
+	} {
+		event := NewScoreEvent(alliance.name, alliance.previousScore, alliance.score)
+		if event == nil {
+			continue
+		}
+		event.MatchId = matchResult.MatchId
+		event.PlayNumber = matchResult.PlayNumber
+		event.MatchTimeSec = matchTimeSec
+		event.Source = source
+		event.SourceDetail = sourceDetail
+		if err := database.CreateScoreEvent(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns all score events for the given match across all of its plays, in the order in which they were recorded.
+func (database *Database) GetScoreEventsForMatch(matchId int) ([]ScoreEvent, error) {
+	events, err := database.scoreEventTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var matchingEvents []ScoreEvent
+	for _, event := range events {
+		if event.MatchId == matchId {
+			matchingEvents = append(matchingEvents, event)
+		}
+	}
+	sort.Slice(matchingEvents, func(i, j int) bool {
+		return matchingEvents[i].Id < matchingEvents[j].Id
+	})
+	return matchingEvents, nil
+}
+
+func (database *Database) TruncateScoreEvents() error {
+	return database.scoreEventTable.truncate()
+}
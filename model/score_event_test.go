@@ -0,0 +1,58 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewScoreEvent(t *testing.T) {
+	previousScore := game.TestScore1()
+	assert.Nil(t, NewScoreEvent("red", previousScore, previousScore.Copy()))
+
+	score := previousScore.Copy()
+	score.SetElementCount("auto", 55)
+	score.Fouls = []game.Foul{{Type: game.MajorFoul, TeamId: 254}}
+	event := NewScoreEvent("red", previousScore, score)
+	if assert.NotNil(t, event) {
+		assert.Equal(t, "red", event.Alliance)
+		assert.Equal(t, []game.ScoreDelta{{ElementId: "auto", Delta: 10}}, event.Deltas)
+		assert.Equal(t, 1, event.FoulsDelta)
+		assert.Equal(t, 10, event.PointsDelta)
+		assert.Equal(t, 6, event.FoulPointsDelta)
+		assert.Equal(t, score, event.Score)
+	}
+}
+
+func TestScoreEventCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	previousResult := BuildTestMatchResult(254, 2)
+	matchResult := BuildTestMatchResult(254, 2)
+	matchResult.BlueScore.SetElementCount("teleop", 0)
+	assert.Nil(t, db.CreateScoreEvents(previousResult, matchResult, 12.5, ApiScoreEventSource, "10.0.100.5"))
+	matchResult.RedScore.SetElementCount("endgame", 0)
+	assert.Nil(t, db.CreateScoreEvents(previousResult, matchResult, 0, UserScoreEventSource, "match review"))
+	assert.Nil(t, db.CreateScoreEvents(matchResult, BuildTestMatchResult(1114, 1), 0, UserScoreEventSource, ""))
+
+	events, err := db.GetScoreEventsForMatch(254)
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(events)) {
+		assert.Equal(t, "blue", events[0].Alliance)
+		assert.Equal(t, 2, events[0].PlayNumber)
+		assert.Equal(t, 12.5, events[0].MatchTimeSec)
+		assert.Equal(t, ApiScoreEventSource, events[0].Source)
+		assert.Equal(t, "10.0.100.5", events[0].SourceDetail)
+		assert.Equal(t, "red", events[1].Alliance)
+		assert.Equal(t, "blue", events[2].Alliance)
+		assert.Equal(t, UserScoreEventSource, events[2].Source)
+	}
+
+	assert.Nil(t, db.TruncateScoreEvents())
+	events, err = db.GetScoreEventsForMatch(254)
+	assert.Nil(t, err)
+	assert.Empty(t, events)
+}
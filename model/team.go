@@ -20,6 +20,7 @@ type Team struct {
 	WpaKey          string
 	HasConnected    bool
 	FtaNotes        string
+	YellowCard      bool
 }
 
 func (database *Database) CreateTeam(team *Team) error {
@@ -27,10 +27,18 @@ func BuildTestMatchResult(matchId int, playNumber int) *MatchResult {
 	matchResult := &MatchResult{MatchId: matchId, PlayNumber: playNumber, MatchType: "qualification"}
 	matchResult.RedScore = game.TestScore1()
 	matchResult.BlueScore = game.TestScore2()
+	matchResult.RedCards = map[string]string{"1868": "yellow"}
+	matchResult.BlueCards = map[string]string{}
 	return matchResult
 }
 
 func BuildTestAlliances(database *Database) {
-	database.CreateAlliance(&Alliance{Id: 2, TeamIds: []int{1718, 2451, 1619}, Lineup: [3]int{2451, 1718, 1619}})
-	database.CreateAlliance(&Alliance{Id: 1, TeamIds: []int{254, 469, 2848, 74, 3175}, Lineup: [3]int{469, 254, 2848}})
+	database.CreateAlliance(
+		&Alliance{Id: 2, TeamIds: []int{1718, 2451, 1619}, Lineup: [game.MaxTeamsPerAlliance]int{2451, 1718, 1619}},
+	)
+	database.CreateAlliance(
+		&Alliance{
+			Id: 1, TeamIds: []int{254, 469, 2848, 74, 3175}, Lineup: [game.MaxTeamsPerAlliance]int{469, 254, 2848},
+		},
+	)
 }
@@ -0,0 +1,75 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Model and datastore CRUD methods for a named set of match durations that can be assigned to a match type.
+
+package model
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"sort"
+)
+
+// Match types to which a timing profile can be assigned.
+var TimingProfileMatchTypes = []string{"practice", "qualification", "elimination", "test", "exhibition"}
+
+type TimingProfile struct {
+	Id                          int `db:"id"`
+	Name                        string
+	WarmupDurationSec           int
+	AutoDurationSec             int
+	PauseDurationSec            int
+	TeleopDurationSec           int
+	WarningRemainingDurationSec int
+	// Durations by name of the game definition's own periods, which are used instead of the above if it has any.
+	PeriodDurationSecs map[string]int
+}
+
+func (database *Database) CreateTimingProfile(timingProfile *TimingProfile) error {
+	return database.timingProfileTable.create(timingProfile)
+}
+
+func (database *Database) GetTimingProfileById(id int) (*TimingProfile, error) {
+	return database.timingProfileTable.getById(id)
+}
+
+func (database *Database) UpdateTimingProfile(timingProfile *TimingProfile) error {
+	return database.timingProfileTable.update(timingProfile)
+}
+
+func (database *Database) DeleteTimingProfile(id int) error {
+	return database.timingProfileTable.delete(id)
+}
+
+func (database *Database) TruncateTimingProfiles() error {
+	return database.timingProfileTable.truncate()
+}
+
+func (database *Database) GetAllTimingProfiles() ([]TimingProfile, error) {
+	timingProfiles, err := database.timingProfileTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(timingProfiles, func(i, j int) bool {
+		return timingProfiles[i].Id < timingProfiles[j].Id
+	})
+	return timingProfiles, nil
+}
+
+// Returns a copy of the given timing with the profile's durations substituted in.
+func (timingProfile *TimingProfile) Apply(timing game.Timing) game.Timing {
+	timing.WarmupDurationSec = timingProfile.WarmupDurationSec
+	timing.AutoDurationSec = timingProfile.AutoDurationSec
+	timing.PauseDurationSec = timingProfile.PauseDurationSec
+	timing.TeleopDurationSec = timingProfile.TeleopDurationSec
+	timing.WarningRemainingDurationSec = timingProfile.WarningRemainingDurationSec
+	timing.PeriodDurationSecs = timingProfile.PeriodDurationSecs
+	return timing
+}
+
+// Returns the duration the profile gives to the given game-defined period, or its default duration if none is set.
+func (timingProfile *TimingProfile) GetPeriodDurationSec(period game.MatchPeriod) int {
+	if durationSec, ok := timingProfile.PeriodDurationSecs[period.Name]; ok {
+		return durationSec
+	}
+	return period.DurationSec
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package model
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetNonexistentTimingProfile(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	timingProfile, err := db.GetTimingProfileById(1114)
+	assert.Nil(t, err)
+	assert.Nil(t, timingProfile)
+}
+
+func TestTimingProfileCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	timingProfile := TimingProfile{
+		Name:                        "Practice",
+		AutoDurationSec:             10,
+		PauseDurationSec:            3,
+		TeleopDurationSec:           100,
+		WarningRemainingDurationSec: 20,
+		PeriodDurationSecs:          map[string]int{"Endgame": 20},
+	}
+	assert.Nil(t, db.CreateTimingProfile(&timingProfile))
+	timingProfile2, err := db.GetTimingProfileById(1)
+	assert.Nil(t, err)
+	assert.Equal(t, timingProfile, *timingProfile2)
+
+	timingProfile2.Id = 0
+	timingProfile2.Name = "Playoff"
+	assert.Nil(t, db.CreateTimingProfile(timingProfile2))
+	timingProfiles, err := db.GetAllTimingProfiles()
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(timingProfiles)) {
+		assert.Equal(t, timingProfile, timingProfiles[0])
+		assert.Equal(t, *timingProfile2, timingProfiles[1])
+	}
+
+	timingProfile.TeleopDurationSec = 120
+	assert.Nil(t, db.UpdateTimingProfile(&timingProfile))
+	timingProfile2, err = db.GetTimingProfileById(1)
+	assert.Nil(t, err)
+	assert.Equal(t, 120, timingProfile2.TeleopDurationSec)
+
+	assert.Nil(t, db.DeleteTimingProfile(timingProfile.Id))
+	timingProfile2, err = db.GetTimingProfileById(1)
+	assert.Nil(t, err)
+	assert.Nil(t, timingProfile2)
+}
+
+func TestTimingProfileApply(t *testing.T) {
+	timingProfile := TimingProfile{
+		Name:                        "Practice",
+		WarmupDurationSec:           1,
+		AutoDurationSec:             10,
+		PauseDurationSec:            3,
+		TeleopDurationSec:           100,
+		WarningRemainingDurationSec: 20,
+		PeriodDurationSecs:          map[string]int{"Endgame": 20},
+	}
+	timing := timingProfile.Apply(
+		game.Timing{
+			WarmupDurationSec:                  0,
+			AutoDurationSec:                    15,
+			PauseDurationSec:                   2,
+			TeleopDurationSec:                  135,
+			WarningRemainingDurationSec:        30,
+			TimeoutDurationSec:                 45,
+			TimeoutWarningRemainingDurationSec: 60,
+		},
+	)
+	assert.Equal(
+		t,
+		game.Timing{
+			WarmupDurationSec:                  1,
+			AutoDurationSec:                    10,
+			PauseDurationSec:                   3,
+			TeleopDurationSec:                  100,
+			WarningRemainingDurationSec:        20,
+			TimeoutDurationSec:                 45,
+			TimeoutWarningRemainingDurationSec: 60,
+			PeriodDurationSecs:                 map[string]int{"Endgame": 20},
+		},
+		timing,
+	)
+
+	assert.Equal(t, 20, timingProfile.GetPeriodDurationSec(game.MatchPeriod{Name: "Endgame", DurationSec: 30}))
+	assert.Equal(t, 105, timingProfile.GetPeriodDurationSec(game.MatchPeriod{Name: "Teleop", DurationSec: 105}))
+}
@@ -23,8 +23,13 @@ const (
 	accessPointPollPeriodSec          = 3
 	accessPointRequestBufferSize      = 10
 	accessPointConfigRetryIntervalSec = 5
+	accessPointTeamNetworks           = 6
 )
 
+// Largest number of teams per alliance for which a single access point serving both alliances has enough team
+// networks; more need a second access point so that each alliance has its own.
+const MaxAccessPointTeamsPerAlliance = accessPointTeamNetworks / 2
+
 type AccessPoint struct {
 	address                string
 	username               string
@@ -33,11 +38,18 @@ type AccessPoint struct {
 	adminChannel           int
 	adminWpaKey            string
 	networkSecurityEnabled bool
-	configRequestChan      chan [6]*model.Team
-	TeamWifiStatuses       [6]TeamWifiStatus
+	configRequestChan      chan [accessPointTeamNetworks]TeamNetwork
+	TeamWifiStatuses       [accessPointTeamNetworks]TeamWifiStatus
 	initialStatusesFetched bool
 }
 
+// A team network on the access point: the team whose SSID it broadcasts, or nil for none, and the VLAN it is bridged
+// onto.
+type TeamNetwork struct {
+	Team *model.Team
+	Vlan int
+}
+
 type TeamWifiStatus struct {
 	TeamId      int
 	RadioLinked bool
@@ -60,7 +72,7 @@ func (ap *AccessPoint) SetSettings(address, username, password string, teamChann
 
 	// Create config channel the first time this method is called.
 	if ap.configRequestChan == nil {
-		ap.configRequestChan = make(chan [6]*model.Team, accessPointRequestBufferSize)
+		ap.configRequestChan = make(chan [accessPointTeamNetworks]TeamNetwork, accessPointRequestBufferSize)
 	}
 }
 
@@ -82,11 +94,18 @@ func (ap *AccessPoint) Run() {
 	}
 }
 
-// Adds a request to set up wireless networks for the given set of teams to the asynchronous queue.
-func (ap *AccessPoint) ConfigureTeamWifi(teams [6]*model.Team) error {
+// Adds a request to set up wireless networks for the given set of teams to the asynchronous queue. The teams are
+// listed with the red alliance's stations followed by the blue alliance's, and the alliance is that of the stations the
+// access point serves, or empty if it serves both.
+func (ap *AccessPoint) ConfigureTeamWifi(teams []*model.Team, alliance string) error {
+	networks, err := TeamNetworks(teams, alliance)
+	if err != nil {
+		return err
+	}
+
 	// Use a channel to serialize configuration requests; the monitoring goroutine will service them.
 	select {
-	case ap.configRequestChan <- teams:
+	case ap.configRequestChan <- networks:
 		return nil
 	default:
 		return fmt.Errorf("WiFi config request buffer full")
@@ -114,17 +133,17 @@ func (ap *AccessPoint) ConfigureAdminWifi() error {
 	return err
 }
 
-func (ap *AccessPoint) handleTeamWifiConfiguration(teams [6]*model.Team) {
+func (ap *AccessPoint) handleTeamWifiConfiguration(networks [accessPointTeamNetworks]TeamNetwork) {
 	if !ap.networkSecurityEnabled {
 		return
 	}
 
-	if ap.configIsCorrectForTeams(teams) {
+	if ap.configIsCorrectForTeams(networks) {
 		return
 	}
 
 	// Generate the configuration command.
-	config, err := generateAccessPointConfig(teams)
+	config, err := generateAccessPointConfig(networks)
 	if err != nil {
 		fmt.Printf("Failed to configure team WiFi: %v", err)
 		return
@@ -142,7 +161,7 @@ func (ap *AccessPoint) handleTeamWifiConfiguration(teams [6]*model.Team) {
 
 		if err == nil {
 			err = ap.updateTeamWifiStatuses()
-			if err == nil && ap.configIsCorrectForTeams(teams) {
+			if err == nil && ap.configIsCorrectForTeams(networks) {
 				log.Printf("Successfully configured WiFi after %d attempts.", attemptCount)
 				return
 			}
@@ -158,15 +177,15 @@ func (ap *AccessPoint) handleTeamWifiConfiguration(teams [6]*model.Team) {
 }
 
 // Returns true if the configured networks as read from the access point match the given teams.
-func (ap *AccessPoint) configIsCorrectForTeams(teams [6]*model.Team) bool {
+func (ap *AccessPoint) configIsCorrectForTeams(networks [accessPointTeamNetworks]TeamNetwork) bool {
 	if !ap.initialStatusesFetched {
 		return false
 	}
 
-	for i, team := range teams {
+	for i, network := range networks {
 		expectedTeamId := 0
-		if team != nil {
-			expectedTeamId = team.Id
+		if network.Team != nil {
+			expectedTeamId = network.Team.Id
 		}
 		if ap.TeamWifiStatuses[i].TeamId != expectedTeamId {
 			return false
@@ -230,11 +249,56 @@ func (ap *AccessPoint) runCommand(command string) (string, error) {
 	}
 }
 
-// Verifies WPA key validity and produces the configuration command for the given list of teams.
-func generateAccessPointConfig(teams [6]*model.Team) (string, error) {
-	commands := &[]string{}
+// Returns the index of the access point team network for the station at the given index within a list of the given
+// number of teams, red stations first. An access point serving both alliances has the red stations on its first three
+// networks and the blue stations on the last three, while one serving a single alliance has that alliance's stations
+// on its first networks. Returns -1 if the station isn't served by the access point or there is no network for it.
+func TeamNetworkIndex(index, numTeams int, alliance string) int {
+	teamsPerAlliance := numTeams / 2
+	station := index % teamsPerAlliance
+	isRed := index < teamsPerAlliance
+	switch {
+	case alliance == "" && station < MaxAccessPointTeamsPerAlliance:
+		if isRed {
+			return station
+		}
+		return MaxAccessPointTeamsPerAlliance + station
+	case alliance == "red" && isRed, alliance == "blue" && !isRed:
+		return station
+	}
+	return -1
+}
+
+// Lays out the given teams, listed red stations first, onto the team networks of an access point serving the given
+// alliance, or both if it is empty. Each team's network is bridged onto the same VLAN as its switch port, and any
+// networks left over keep the VLANs of the standard access point setup.
+func TeamNetworks(teams []*model.Team, alliance string) ([accessPointTeamNetworks]TeamNetwork, error) {
+	var networks [accessPointTeamNetworks]TeamNetwork
+	for i := range networks {
+		networks[i].Vlan = 10 * (i + 1)
+	}
 	for i, team := range teams {
+		networkIndex := TeamNetworkIndex(i, len(teams), alliance)
+		if networkIndex >= 0 {
+			networks[networkIndex] = TeamNetwork{Team: team, Vlan: teamVlan(i, len(teams))}
+		} else if alliance == "" {
+			return networks, fmt.Errorf(
+				"A single access point only has networks for %d teams per alliance; can't configure %d.",
+				MaxAccessPointTeamsPerAlliance, len(teams)/2,
+			)
+		}
+	}
+	return networks, nil
+}
+
+// Verifies WPA key validity and produces the configuration command for the given team networks.
+func generateAccessPointConfig(networks [accessPointTeamNetworks]TeamNetwork) (string, error) {
+	commands := &[]string{}
+	for i, network := range networks {
 		position := i + 1
+		team := network.Team
+		*commands = append(*commands, fmt.Sprintf("set wireless.@wifi-iface[%d].network='vlan%d'", position,
+			network.Vlan))
 		if team == nil {
 			*commands = append(*commands, fmt.Sprintf("set wireless.@wifi-iface[%d].disabled='0'", position),
 				fmt.Sprintf("set wireless.@wifi-iface[%d].ssid='no-team-%d'", position, position),
@@ -260,10 +324,10 @@ func decodeWifiInfo(wifiInfo string, statuses []TeamWifiStatus) error {
 	linkQualityRe := regexp.MustCompile("Link Quality: ([-\\w ]+)/([-\\w ]+)")
 	linkQualities := linkQualityRe.FindAllStringSubmatch(wifiInfo, -1)
 
-	// There should be at least six networks present -- one for each team on the 5GHz radio, plus one on the 2.4GHz
-	// radio if the admin network is enabled.
-	if len(ssids) < 6 || len(linkQualities) < 6 {
-		return fmt.Errorf("Could not parse wifi info; expected 6 team networks, got %d.", len(ssids))
+	// There should be at least one network present for each team on the 5GHz radio, plus one on the 2.4GHz radio if
+	// the admin network is enabled.
+	if len(ssids) < len(statuses) || len(linkQualities) < len(statuses) {
+		return fmt.Errorf("Could not parse wifi info; expected %d team networks, got %d.", len(statuses), len(ssids))
 	}
 
 	for i := range statuses {
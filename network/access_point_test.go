@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"regexp"
+	"strconv"
 	"testing"
 )
 
@@ -20,7 +21,7 @@ func TestConfigureAccessPoint(t *testing.T) {
 	wpaKeyRe := regexp.MustCompile("key='([-\\w ]*)'")
 
 	// Should put dummy values for all team SSIDs if there are no teams.
-	config, _ := generateAccessPointConfig([6]*model.Team{nil, nil, nil, nil, nil, nil})
+	config, _ := generateTestAccessPointConfig([]*model.Team{nil, nil, nil, nil, nil, nil})
 	disableds := disabledRe.FindAllStringSubmatch(config, -1)
 	ssids := ssidRe.FindAllStringSubmatch(config, -1)
 	wpaKeys := wpaKeyRe.FindAllStringSubmatch(config, -1)
@@ -33,7 +34,7 @@ func TestConfigureAccessPoint(t *testing.T) {
 	}
 
 	// Should configure two SSIDs for two teams and put dummy values for the rest.
-	config, _ = generateAccessPointConfig([6]*model.Team{{Id: 254, WpaKey: "aaaaaaaa"}, nil, nil, nil, nil,
+	config, _ = generateTestAccessPointConfig([]*model.Team{{Id: 254, WpaKey: "aaaaaaaa"}, nil, nil, nil, nil,
 		{Id: 1114, WpaKey: "bbbbbbbb"}})
 	disableds = disabledRe.FindAllStringSubmatch(config, -1)
 	ssids = ssidRe.FindAllStringSubmatch(config, -1)
@@ -53,7 +54,7 @@ func TestConfigureAccessPoint(t *testing.T) {
 	}
 
 	// Should configure all SSIDs for six teams.
-	config, _ = generateAccessPointConfig([6]*model.Team{{Id: 1, WpaKey: "11111111"}, {Id: 2, WpaKey: "22222222"},
+	config, _ = generateTestAccessPointConfig([]*model.Team{{Id: 1, WpaKey: "11111111"}, {Id: 2, WpaKey: "22222222"},
 		{Id: 3, WpaKey: "33333333"}, {Id: 4, WpaKey: "44444444"}, {Id: 5, WpaKey: "55555555"},
 		{Id: 6, WpaKey: "66666666"}})
 	disableds = disabledRe.FindAllStringSubmatch(config, -1)
@@ -78,12 +79,72 @@ func TestConfigureAccessPoint(t *testing.T) {
 	}
 
 	// Should reject a missing WPA key.
-	_, err := generateAccessPointConfig([6]*model.Team{{Id: 254}, nil, nil, nil, nil, nil})
+	_, err := generateTestAccessPointConfig([]*model.Team{{Id: 254}, nil, nil, nil, nil, nil})
 	if assert.NotNil(t, err) {
 		assert.Contains(t, err.Error(), "Invalid WPA key")
 	}
 }
 
+func TestAccessPointTeamNetworks(t *testing.T) {
+	ssidRe := regexp.MustCompile("ssid='([-\\w ]*)'")
+	networkRe := regexp.MustCompile("network='vlan(\\d+)'")
+	teams := make([]*model.Team, 8)
+	for i := range teams {
+		teams[i] = &model.Team{Id: 101 + i, WpaKey: "aaaaaaaa"}
+	}
+
+	// Returns the VLAN each team's SSID is bridged onto in the configuration for the given access point.
+	ssidVlans := func(teams []*model.Team, alliance string) map[string]int {
+		networks, err := TeamNetworks(teams, alliance)
+		assert.Nil(t, err)
+		config, err := generateAccessPointConfig(networks)
+		assert.Nil(t, err)
+		ssids := ssidRe.FindAllStringSubmatch(config, -1)
+		vlans := networkRe.FindAllStringSubmatch(config, -1)
+		ssidVlans := make(map[string]int)
+		if assert.Equal(t, 6, len(ssids)) && assert.Equal(t, 6, len(vlans)) {
+			for i := range ssids {
+				ssidVlans[ssids[i][1]], _ = strconv.Atoi(vlans[i][1])
+			}
+		}
+		return ssidVlans
+	}
+
+	// Check that each team's SSID is on the same VLAN as its switch port for two teams per alliance.
+	twoTeams := []*model.Team{teams[0], teams[1], teams[2], teams[3]}
+	vlans := ssidVlans(twoTeams, "")
+	for i, team := range twoTeams {
+		assert.Equal(t, teamVlan(i, len(twoTeams)), vlans[strconv.Itoa(team.Id)])
+	}
+	assert.Equal(t, map[string]int{"101": 10, "102": 20, "no-team-3": 30, "103": 40, "104": 50, "no-team-6": 60}, vlans)
+
+	// Check the same for four teams per alliance, which needs an access point for each alliance.
+	_, err := TeamNetworks(teams, "")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "only has networks for 3 teams per alliance")
+	}
+	redVlans := ssidVlans(teams, "red")
+	blueVlans := ssidVlans(teams, "blue")
+	for i, team := range teams {
+		vlans := redVlans
+		if i >= 4 {
+			vlans = blueVlans
+		}
+		assert.Equal(t, teamVlan(i, len(teams)), vlans[strconv.Itoa(team.Id)])
+	}
+	assert.Equal(t, 70, redVlans["104"])
+	assert.Equal(t, 80, blueVlans["108"])
+}
+
+// Builds the access point configuration for the given teams, listed red stations first, on a single access point.
+func generateTestAccessPointConfig(teams []*model.Team) (string, error) {
+	networks, err := TeamNetworks(teams, "")
+	if err != nil {
+		return "", err
+	}
+	return generateAccessPointConfig(networks)
+}
+
 func TestDecodeWifiInfo(t *testing.T) {
 	var statuses [6]TeamWifiStatus
 
@@ -123,6 +184,13 @@ func TestDecodeWifiInfo(t *testing.T) {
 		assertTeamWifiStatus(t, 2471, true, statuses[5])
 	}
 
+	// Test with fewer team networks in use.
+	output, err = ioutil.ReadFile("testdata/iwinfo_6_teams.txt")
+	if assert.Nil(t, err) {
+		assert.Nil(t, decodeWifiInfo(string(output), statuses[:4]))
+		assertTeamWifiStatus(t, 604, false, statuses[3])
+	}
+
 	// Test with invalid input.
 	assert.NotNil(t, decodeWifiInfo("", statuses[:]))
 	output, err = ioutil.ReadFile("testdata/iwinfo_invalid.txt")
@@ -27,7 +27,7 @@ func NewDnsMasq() *DnsMasq {
 	return &DnsMasq{}
 }
 
-func (dm *DnsMasq) ConfigureTeamEthernet(teams [6]*model.Team) error {
+func (dm *DnsMasq) ConfigureTeamEthernet(teams []*model.Team) error {
 	// Make sure multiple configurations aren't being set at the same time.
 	dm.mutex.Lock()
 	defer dm.mutex.Unlock()
@@ -59,12 +59,9 @@ func (dm *DnsMasq) ConfigureTeamEthernet(teams [6]*model.Team) error {
 			}
 		}
 	}
-	replaceTeamVlan(teams[0], red1Vlan)
-	replaceTeamVlan(teams[1], red2Vlan)
-	replaceTeamVlan(teams[2], red3Vlan)
-	replaceTeamVlan(teams[3], blue1Vlan)
-	replaceTeamVlan(teams[4], blue2Vlan)
-	replaceTeamVlan(teams[5], blue3Vlan)
+	for i, team := range teams {
+		replaceTeamVlan(team, teamVlan(i, len(teams)))
+	}
 
 	// Remove configuration files for VLANs no longer needed
 	for _, vlan := range oldTeamVlans {
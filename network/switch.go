@@ -9,6 +9,7 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"github.com/FRCTeam1987/crimson-arena/game"
 	"github.com/FRCTeam1987/crimson-arena/model"
 	"net"
 	"regexp"
@@ -18,30 +19,31 @@ import (
 
 const switchTelnetPort = 23
 
-const (
-	red1Vlan  = 10
-	red2Vlan  = 20
-	red3Vlan  = 30
-	blue1Vlan = 40
-	blue2Vlan = 50
-	blue3Vlan = 60
+// VLANs for each alliance's stations, in order. The fourth stations, used only at events with four teams per alliance,
+// take the next VLANs after the standard six.
+var (
+	redVlans  = [game.MaxTeamsPerAlliance]int{10, 20, 30, 70}
+	blueVlans = [game.MaxTeamsPerAlliance]int{40, 50, 60, 80}
 )
 
 type Switch struct {
 	address  string
 	port     int
 	password string
-	mutex    sync.Mutex
+	// Address of the server to which team traffic is allowed.
+	serverIpAddress string
+	mutex           sync.Mutex
 }
 
 var ServerIpAddress = "10.0.100.5" // The DS will try to connect to this address only.
 
-func NewSwitch(address, password string) *Switch {
-	return &Switch{address: address, port: switchTelnetPort, password: password}
+func NewSwitch(address, password, serverIpAddress string) *Switch {
+	return &Switch{address: address, port: switchTelnetPort, password: password, serverIpAddress: serverIpAddress}
 }
 
-// Sets up wired networks for the given set of teams.
-func (sw *Switch) ConfigureTeamEthernet(teams [6]*model.Team) error {
+// Sets up wired networks for the given set of teams, which holds the red alliance's stations followed by the blue
+// alliance's.
+func (sw *Switch) ConfigureTeamEthernet(teams []*model.Team) error {
 	// Make sure multiple configurations aren't being set at the same time.
 	sw.mutex.Lock()
 	defer sw.mutex.Unlock()
@@ -64,16 +66,13 @@ func (sw *Switch) ConfigureTeamEthernet(teams [6]*model.Team) error {
 					"access-list 1%d permit ip 10.%d.%d.0 0.0.0.255 host %s\n"+
 					"access-list 1%d permit udp any eq bootpc any eq bootps\n"+
 					"interface Vlan%d\nip address 10.%d.%d.61 255.255.255.0\n",
-				vlan, vlan, team.Id/100, team.Id%100, ServerIpAddress, vlan, vlan, team.Id/100,
+				vlan, vlan, team.Id/100, team.Id%100, sw.serverIpAddress, vlan, vlan, team.Id/100,
 				team.Id%100)
 		}
 	}
-	replaceTeamVlan(teams[0], red1Vlan)
-	replaceTeamVlan(teams[1], red2Vlan)
-	replaceTeamVlan(teams[2], red3Vlan)
-	replaceTeamVlan(teams[3], blue1Vlan)
-	replaceTeamVlan(teams[4], blue2Vlan)
-	replaceTeamVlan(teams[5], blue3Vlan)
+	for i, team := range teams {
+		replaceTeamVlan(team, teamVlan(i, len(teams)))
+	}
 
 	// Build the command to remove the team VLANs that are no longer needed.
 	removeTeamVlansCommand := ""
@@ -93,6 +92,15 @@ func (sw *Switch) ConfigureTeamEthernet(teams [6]*model.Team) error {
 	return nil
 }
 
+// Returns the VLAN for the station at the given index within a list of the given number of teams, red stations first.
+func teamVlan(index, numTeams int) int {
+	teamsPerAlliance := numTeams / 2
+	if index < teamsPerAlliance {
+		return redVlans[index]
+	}
+	return blueVlans[index-teamsPerAlliance]
+}
+
 // Returns a map of currently-configured teams to VLANs.
 func (sw *Switch) getTeamVlans() (map[int]int, error) {
 	// Get the entire config dump.
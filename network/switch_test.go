@@ -14,27 +14,27 @@ import (
 )
 
 func TestConfigureSwitch(t *testing.T) {
-	sw := NewSwitch("127.0.0.1", "password")
+	sw := NewSwitch("127.0.0.1", "password", ServerIpAddress)
 	sw.port = 9050
 	var command string
 
 	// Should do nothing if current configuration is blank.
 	mockTelnet(t, sw.port, "", &command)
-	assert.Nil(t, sw.ConfigureTeamEthernet([6]*model.Team{nil, nil, nil, nil, nil, nil}))
+	assert.Nil(t, sw.ConfigureTeamEthernet([]*model.Team{nil, nil, nil, nil, nil, nil}))
 	assert.Equal(t, "", command)
 
 	// Should remove any existing teams but not other SSIDs.
 	sw.port += 1
 	mockTelnet(t, sw.port,
 		"interface Vlan100\nip address 10.0.100.2\ninterface Vlan50\nip address 10.2.54.61\n", &command)
-	assert.Nil(t, sw.ConfigureTeamEthernet([6]*model.Team{nil, nil, nil, nil, nil, nil}))
+	assert.Nil(t, sw.ConfigureTeamEthernet([]*model.Team{nil, nil, nil, nil, nil, nil}))
 	assert.Equal(t, "password\nenable\npassword\nterminal length 0\nconfig terminal\ninterface Vlan50\nno ip"+
 		" address\nno access-list 150\nend\ncopy running-config startup-config\n\nexit\n", command)
 
 	// Should configure new teams and leave existing ones alone if still needed.
 	sw.port += 1
 	mockTelnet(t, sw.port, "interface Vlan50\nip address 10.2.54.61\n", &command)
-	assert.Nil(t, sw.ConfigureTeamEthernet([6]*model.Team{nil, &model.Team{Id: 1114}, nil, nil, &model.Team{Id: 254},
+	assert.Nil(t, sw.ConfigureTeamEthernet([]*model.Team{nil, &model.Team{Id: 1114}, nil, nil, &model.Team{Id: 254},
 		nil}))
 	assert.Equal(t, "password\nenable\npassword\nterminal length 0\nconfig terminal\n"+
 		"ip dhcp excluded-address 10.11.14.1 10.11.14.100\nno ip dhcp pool dhcp20\nip dhcp pool dhcp20\n"+
@@ -44,6 +44,15 @@ func TestConfigureSwitch(t *testing.T) {
 		"ip address 10.11.14.61 255.255.255.0\nend\ncopy running-config startup-config\n\nexit\n", command)
 }
 
+func TestTeamVlan(t *testing.T) {
+	assert.Equal(t, []int{10, 20, 30, 40, 50, 60}, []int{teamVlan(0, 6), teamVlan(1, 6), teamVlan(2, 6),
+		teamVlan(3, 6), teamVlan(4, 6), teamVlan(5, 6)})
+	assert.Equal(t, []int{10, 20, 40, 50}, []int{teamVlan(0, 4), teamVlan(1, 4), teamVlan(2, 4), teamVlan(3, 4)})
+	assert.Equal(t, 70, teamVlan(3, 8))
+	assert.Equal(t, 40, teamVlan(4, 8))
+	assert.Equal(t, 80, teamVlan(7, 8))
+}
+
 func mockTelnet(t *testing.T, port int, response string, command *string) {
 	go func() {
 		// Fake the first connection which should just get the configuration.
@@ -11,6 +11,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"github.com/FRCTeam1987/crimson-arena/game"
 	"github.com/FRCTeam1987/crimson-arena/model"
 	"io/ioutil"
 	"net/http"
@@ -33,13 +34,14 @@ type TbaClient struct {
 }
 
 type TbaMatch struct {
-	CompLevel   string                  `json:"comp_level"`
-	SetNumber   int                     `json:"set_number"`
-	MatchNumber int                     `json:"match_number"`
-	Alliances   map[string]*TbaAlliance `json:"alliances"`
-	TimeString  string                  `json:"time_string"`
-	TimeUtc     string                  `json:"time_utc"`
-	DisplayName string                  `json:"display_name"`
+	CompLevel      string                    `json:"comp_level"`
+	SetNumber      int                       `json:"set_number"`
+	MatchNumber    int                       `json:"match_number"`
+	Alliances      map[string]*TbaAlliance   `json:"alliances"`
+	ScoreBreakdown map[string]map[string]int `json:"score_breakdown,omitempty"`
+	TimeString     string                    `json:"time_string"`
+	TimeUtc        string                    `json:"time_utc"`
+	DisplayName    string                    `json:"display_name"`
 }
 
 type TbaAlliance struct {
@@ -52,15 +54,29 @@ type TbaAlliance struct {
 type TbaRanking struct {
 	TeamKey string `json:"team_key"`
 	Rank    int    `json:"rank"`
-	RP      float32
-	Auto    int
-	Endgame int
-	Teleop  int
-	Wins    int `json:"wins"`
-	Losses  int `json:"losses"`
-	Ties    int `json:"ties"`
-	Dqs     int `json:"dqs"`
-	Played  int `json:"played"`
+	Wins    int    `json:"wins"`
+	Losses  int    `json:"losses"`
+	Ties    int    `json:"ties"`
+	Dqs     int    `json:"dqs"`
+	Played  int    `json:"played"`
+	// Value of each breakdown column, keyed by its name; flattened into the ranking object for TBA.
+	Breakdowns map[string]float32 `json:"-"`
+}
+
+func (ranking TbaRanking) MarshalJSON() ([]byte, error) {
+	fields := map[string]interface{}{
+		"team_key": ranking.TeamKey,
+		"rank":     ranking.Rank,
+		"wins":     ranking.Wins,
+		"losses":   ranking.Losses,
+		"ties":     ranking.Ties,
+		"dqs":      ranking.Dqs,
+		"played":   ranking.Played,
+	}
+	for name, value := range ranking.Breakdowns {
+		fields[name] = value
+	}
+	return json.Marshal(fields)
 }
 
 type TbaRankings struct {
@@ -304,42 +320,46 @@ func (client *TbaClient) PublishMatches(database *model.Database) error {
 	matches := append(qualMatches, elimMatches...)
 	tbaMatches := make([]TbaMatch, len(matches))
 
-	// Build a JSON array of TBA-format matches.
+	// Build a JSON array of TBA-format matches, tracking the yellow cards carried by each team within each match type.
+	carriedYellowCards := map[string]map[int]bool{"qualification": {}, "elimination": {}}
 	for i, match := range matches {
 		matchNumber, _ := strconv.Atoi(match.DisplayName)
 
 		// Fill in scores if the match has been played.
-		var redScoreSummary, blueScoreSummary int
 		var redScore, blueScore *int
+		var redCards, blueCards map[string]string
+		var scoreBreakdown map[string]map[string]int
 		if match.IsComplete() {
 			matchResult, err := database.GetMatchResultForMatch(match.Id)
 			if err != nil {
 				return err
 			}
 			if matchResult != nil {
-				redScoreSummary = matchResult.RedScore.AutoPoints +
-					matchResult.RedScore.TeleopPoints +
-					matchResult.RedScore.EndgamePoints
-				blueScoreSummary = matchResult.BlueScore.AutoPoints +
-					matchResult.BlueScore.TeleopPoints +
-					matchResult.BlueScore.EndgamePoints
-				redScore = &redScoreSummary
-				blueScore = &blueScoreSummary
+				redScore = &matchResult.RedScoreSummary().Score
+				blueScore = &matchResult.BlueScoreSummary().Score
+				// Publish any second yellow card as the red card it becomes, which isn't saved on the result.
+				carriedMatchResult := matchResult.WithCardCarryover(carriedYellowCards[match.Type])
+				matchResult.AddCarriedYellowCards(carriedYellowCards[match.Type])
+				redCards = carriedMatchResult.RedCards
+				blueCards = carriedMatchResult.BlueCards
+				scoreBreakdown = map[string]map[string]int{
+					"red":  createTbaScoreBreakdown(matchResult.RedScore, matchResult.BlueScore),
+					"blue": createTbaScoreBreakdown(matchResult.BlueScore, matchResult.RedScore),
+				}
 			}
 		}
 		alliances := make(map[string]*TbaAlliance)
-		alliances["red"] = createTbaAlliance([3]int{match.Red1, match.Red2, match.Red3}, [3]bool{match.Red1IsSurrogate,
-			match.Red2IsSurrogate, match.Red3IsSurrogate}, redScore)
-		alliances["blue"] = createTbaAlliance([3]int{match.Blue1, match.Blue2, match.Blue3},
-			[3]bool{match.Blue1IsSurrogate, match.Blue2IsSurrogate, match.Blue3IsSurrogate}, blueScore)
+		alliances["red"] = createTbaAlliance(match.RedTeamIds(), match.RedSurrogates(), redScore, redCards)
+		alliances["blue"] = createTbaAlliance(match.BlueTeamIds(), match.BlueSurrogates(), blueScore, blueCards)
 
 		tbaMatches[i] = TbaMatch{
-			CompLevel:   "qm",
-			SetNumber:   0,
-			MatchNumber: matchNumber,
-			Alliances:   alliances,
-			TimeString:  match.Time.Local().Format("3:04 PM"),
-			TimeUtc:     match.Time.UTC().Format("2006-01-02T15:04:05"),
+			CompLevel:      "qm",
+			SetNumber:      0,
+			MatchNumber:    matchNumber,
+			Alliances:      alliances,
+			ScoreBreakdown: scoreBreakdown,
+			TimeString:     match.Time.Local().Format("3:04 PM"),
+			TimeUtc:        match.Time.UTC().Format("2006-01-02T15:04:05"),
 		}
 		if match.Type == "elimination" {
 			setElimMatchKey(&tbaMatches[i], &match, eventSettings.ElimType)
@@ -368,22 +388,34 @@ func (client *TbaClient) PublishRankings(database *model.Database) error {
 	if err != nil {
 		return err
 	}
+	eventSettings, err := database.GetEventSettings()
+	if err != nil {
+		return err
+	}
+	columns, err := getTbaRankingColumns(eventSettings)
+	if err != nil {
+		return err
+	}
 
 	// Build a JSON object of TBA-format rankings.
-	breakdowns := []string{"RP", "Auto", "Endgame", "Teleop"}
+	breakdowns := make([]string, len(columns))
+	for i, column := range columns {
+		breakdowns[i] = column.DisplayName()
+	}
 	tbaRankings := make([]TbaRanking, len(rankings))
 	for i, ranking := range rankings {
 		tbaRankings[i] = TbaRanking{
-			TeamKey: getTbaTeam(ranking.TeamId),
-			Rank:    ranking.Rank,
-			RP:      float32(ranking.RankingPoints) / float32(ranking.Played),
-			Auto:    ranking.AutoPoints,
-			Endgame: ranking.EndgamePoints,
-			Teleop:  ranking.TeleopPoints,
-			Wins:    ranking.Wins,
-			Losses:  ranking.Losses,
-			Ties:    ranking.Ties,
-			Played:  ranking.Played,
+			TeamKey:    getTbaTeam(ranking.TeamId),
+			Rank:       ranking.Rank,
+			Wins:       ranking.Wins,
+			Losses:     ranking.Losses,
+			Ties:       ranking.Ties,
+			Dqs:        ranking.Disqualifications,
+			Played:     ranking.Played,
+			Breakdowns: make(map[string]float32),
+		}
+		for j, column := range columns {
+			tbaRankings[i].Breakdowns[breakdowns[j]] = float32(column.DisplayValue(&ranking.RankingFields))
 		}
 	}
 	jsonBody, err := json.Marshal(TbaRankings{breakdowns, tbaRankings})
@@ -403,6 +435,30 @@ func (client *TbaClient) PublishRankings(database *model.Database) error {
 	return nil
 }
 
+// Returns the criteria the event ranks by that can be published as ranking breakdown columns, which excludes
+// head-to-head since it has no per-team value. Bonus ranking points are added if the game awards them.
+func getTbaRankingColumns(eventSettings *model.EventSettings) ([]game.Tiebreaker, error) {
+	tiebreakers, err := game.ParseTiebreakers(eventSettings.RankingTiebreakers, game.CurrentGame)
+	if err != nil {
+		return nil, err
+	}
+	var columns []game.Tiebreaker
+	hasBonusRankingPoints := false
+	for _, tiebreaker := range tiebreakers {
+		if tiebreaker.Criterion == game.HeadToHeadTiebreaker {
+			continue
+		}
+		if tiebreaker.Criterion == game.BonusRankingPointsTiebreaker {
+			hasBonusRankingPoints = true
+		}
+		columns = append(columns, tiebreaker)
+	}
+	if len(game.CurrentGame.BonusRankingPoints) > 0 && !hasBonusRankingPoints {
+		columns = append(columns, game.Tiebreaker{Criterion: game.BonusRankingPointsTiebreaker, Total: true})
+	}
+	return columns, nil
+}
+
 // Uploads the alliances selection results to The Blue Alliance.
 func (client *TbaClient) PublishAlliances(database *model.Database) error {
 	alliances, err := database.GetAllAlliances()
@@ -505,19 +561,44 @@ func (client *TbaClient) postRequest(resource string, action string, body []byte
 	return httpClient.Do(request)
 }
 
-func createTbaAlliance(teamIds [3]int, surrogates [3]bool, score *int) *TbaAlliance {
+func createTbaAlliance(teamIds [game.MaxTeamsPerAlliance]int, surrogates [game.MaxTeamsPerAlliance]bool, score *int,
+	cards map[string]string) *TbaAlliance {
 	alliance := TbaAlliance{Surrogates: []string{}, Dqs: []string{}, Score: score}
 	for i, teamId := range teamIds {
+		if teamId == 0 {
+			// Stations beyond the number of teams per alliance are left empty.
+			continue
+		}
 		teamKey := getTbaTeam(teamId)
 		alliance.Teams = append(alliance.Teams, teamKey)
 		if surrogates[i] {
 			alliance.Surrogates = append(alliance.Surrogates, teamKey)
 		}
+		if cards[strconv.Itoa(teamId)] == model.RedCard {
+			alliance.Dqs = append(alliance.Dqs, teamKey)
+		}
 	}
 
 	return &alliance
 }
 
+// Builds the TBA score breakdown for an alliance from the counts of each scoring element in the game definition and
+// the resulting per-period point totals.
+func createTbaScoreBreakdown(score, opponentScore *game.Score) map[string]int {
+	breakdown := make(map[string]int)
+	for _, elementScore := range score.ElementScores() {
+		breakdown[elementScore.Id] = elementScore.Count
+	}
+	summary := score.Summarize(opponentScore)
+	breakdown["autoPoints"] = summary.AutoPoints
+	breakdown["teleopPoints"] = summary.TeleopPoints
+	breakdown["endgamePoints"] = summary.EndgamePoints
+	breakdown["foulPoints"] = summary.FoulPoints
+	breakdown["foulCount"] = len(score.Fouls)
+	breakdown["totalPoints"] = summary.Score
+	return breakdown
+}
+
 // Uploads the awards to The Blue Alliance.
 func (client *TbaClient) PublishAwards(database *model.Database) error {
 	awards, err := database.GetAllAwards()
@@ -47,16 +47,33 @@ func TestPublishMatches(t *testing.T) {
 	database.CreateMatch(&match1)
 	database.CreateMatch(&match2)
 	matchResult1 := model.BuildTestMatchResult(match1.Id, 1)
+	matchResult1.BlueCards = map[string]string{"11": "red", "12": "yellow"}
 	database.CreateMatchResult(matchResult1)
+	match3 := model.Match{Type: "qualification", DisplayName: "3", Time: time.Unix(1200, 0), Red1: 10, Red2: 11,
+		Red3: 12, Blue1: 7, Blue2: 8, Blue3: 9, Status: game.RedWonMatch}
+	database.CreateMatch(&match3)
+	matchResult3 := model.BuildTestMatchResult(match3.Id, 1)
+	matchResult3.RedCards = map[string]string{"12": "yellow"}
+	database.CreateMatchResult(matchResult3)
 
 	// Mock the TBA server.
 	tbaServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		body, _ := ioutil.ReadAll(r.Body)
 		var matches []*TbaMatch
 		json.Unmarshal(body, &matches)
-		assert.Equal(t, 2, len(matches))
+		if !assert.Equal(t, 3, len(matches)) {
+			return
+		}
 		assert.Equal(t, "qm", matches[0].CompLevel)
-		assert.Equal(t, "sf", matches[1].CompLevel)
+		assert.Equal(t, "sf", matches[2].CompLevel)
+		assert.Equal(t, []string{}, matches[0].Alliances["red"].Dqs)
+		assert.Equal(t, []string{"frc11"}, matches[0].Alliances["blue"].Dqs)
+		assert.Equal(t, 155, *matches[0].Alliances["red"].Score)
+		assert.Equal(t, 45, matches[0].ScoreBreakdown["red"]["auto"])
+		assert.Nil(t, matches[2].ScoreBreakdown)
+
+		// A second yellow card should be published as a DQ.
+		assert.Equal(t, []string{"frc12"}, matches[1].Alliances["red"].Dqs)
 	}))
 	defer tbaServer.Close()
 	client := NewTbaClient("my_event_code", "my_secret_id", "my_secret")
@@ -72,19 +89,46 @@ func TestPublishRankings(t *testing.T) {
 	database.CreateRanking(game.TestRanking1())
 
 	// Mock the TBA server.
+	var response struct {
+		Breakdowns []string                 `json:"breakdowns"`
+		Rankings   []map[string]interface{} `json:"rankings"`
+	}
 	tbaServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		body, _ := ioutil.ReadAll(r.Body)
-		var response TbaRankings
 		json.Unmarshal(body, &response)
-		assert.Equal(t, 2, len(response.Rankings))
-		assert.Equal(t, "frc254", response.Rankings[0].TeamKey)
-		assert.Equal(t, "frc1114", response.Rankings[1].TeamKey)
 	}))
 	defer tbaServer.Close()
 	client := NewTbaClient("my_event_code", "my_secret_id", "my_secret")
 	client.BaseUrl = tbaServer.URL
 
+	// Check that the breakdowns follow the default tiebreakers.
 	assert.Nil(t, client.PublishRankings(database))
+	if assert.Equal(t, 2, len(response.Rankings)) {
+		assert.Equal(t, "frc254", response.Rankings[0]["team_key"])
+		assert.Equal(t, "frc1114", response.Rankings[1]["team_key"])
+		assert.Equal(t, []string{"Avg RP", "Avg Auto", "Avg Endgame", "Avg Teleop"}, response.Breakdowns)
+		assert.Equal(t, 2.0, response.Rankings[0]["Avg RP"])
+		assert.Equal(t, 62.5, response.Rankings[0]["Avg Auto"])
+		assert.Equal(t, 3.0, response.Rankings[0]["wins"])
+	}
+
+	// Check that the breakdowns follow the configured tiebreakers and the game's bonus ranking points.
+	defer func(definition *game.GameDefinition) { game.CurrentGame = definition }(game.CurrentGame)
+	definition := *game.CurrentGame
+	definition.BonusRankingPoints = []game.BonusRankingPointRule{{Id: "auto", Metric: game.AutoPointsMetric}}
+	game.CurrentGame = &definition
+	eventSettings, _ := database.GetEventSettings()
+	eventSettings.RankingTiebreakers = "total:rankingPoints, headToHead, wins"
+	assert.Nil(t, database.UpdateEventSettings(eventSettings))
+	response.Rankings = nil
+	assert.Nil(t, client.PublishRankings(database))
+	if assert.Equal(t, 2, len(response.Rankings)) {
+		assert.Equal(t, []string{"Total RP", "Avg Wins", "Total Bonus RP"}, response.Breakdowns)
+		assert.Equal(t, 20.0, response.Rankings[0]["Total RP"])
+		assert.Equal(t, 0.3, response.Rankings[0]["Avg Wins"])
+		assert.Equal(t, 3.0, response.Rankings[1]["Total Bonus RP"])
+		assert.NotContains(t, response.Rankings[0], "Head-to-Head")
+	}
 }
 
 func TestPublishAlliances(t *testing.T) {
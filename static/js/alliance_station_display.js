@@ -27,6 +27,7 @@ var handleAllianceStationDisplayMode = function(targetScreen) {
           body.attr("data-position", "middle");
           break;
         case "3":
+        case "4":
           body.attr("data-position", "left");
           break;
       }
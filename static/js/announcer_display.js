@@ -33,9 +33,9 @@ var handleMatchLoad = function(data) {
   if (data.Match.Type === "elimination") {
     teams.append(createAllianceElement("red", data.Match.ElimRedAlliance));
   }
-  teams.append(createTeamElement("red", data.Teams["R1"], false));
-  teams.append(createTeamElement("red", data.Teams["R2"], false));
-  teams.append(createTeamElement("red", data.Teams["R3"], false));
+  for (var position = 1; position <= data.TeamsPerAlliance; position++) {
+    teams.append(createTeamElement("red", data.Teams["R" + position], false));
+  }
   for (team of data.RedOffFieldTeams) {
     teams.append(createTeamElement("red", team, true));
   }
@@ -43,9 +43,9 @@ var handleMatchLoad = function(data) {
   if (data.Match.Type === "elimination") {
     teams.append(createAllianceElement("blue", data.Match.ElimBlueAlliance));
   }
-  teams.append(createTeamElement("blue", data.Teams["B1"], false));
-  teams.append(createTeamElement("blue", data.Teams["B2"], false));
-  teams.append(createTeamElement("blue", data.Teams["B3"], false));
+  for (var position = 1; position <= data.TeamsPerAlliance; position++) {
+    teams.append(createTeamElement("blue", data.Teams["B" + position], false));
+  }
   for (team of data.BlueOffFieldTeams) {
     teams.append(createTeamElement("blue", team, true));
   }
@@ -55,7 +55,7 @@ var handleMatchLoad = function(data) {
 var handleMatchTime = function(data) {
   translateMatchTime(data, function(matchState, matchStateText, countdownSec) {
     $("#matchState").text(matchStateText);
-    $("#matchTime").text(getCountdown(data.MatchState, data.MatchTimeSec));
+    $("#matchTime").text(getCountdown(data));
   });
 };
 
@@ -67,18 +67,29 @@ var handleRealtimeScore = function(data) {
 
 // Handles a websocket message to populate the final score data.
 var handleScorePosted = function(data) {
+  var redTeams = [];
+  var blueTeams = [];
   var redRankings = {};
-  redRankings[data.Match.Red1] = getRankingText(data.Match.Red1, data.Rankings);
-  redRankings[data.Match.Red2] = getRankingText(data.Match.Red2, data.Rankings);
-  redRankings[data.Match.Red3] = getRankingText(data.Match.Red3, data.Rankings);
   var blueRankings = {};
-  blueRankings[data.Match.Blue1] = getRankingText(data.Match.Blue1, data.Rankings);
-  blueRankings[data.Match.Blue2] = getRankingText(data.Match.Blue2, data.Rankings);
-  blueRankings[data.Match.Blue3] = getRankingText(data.Match.Blue3, data.Rankings);
+  for (var position = 1; position <= data.TeamsPerAlliance; position++) {
+    var redTeam = data.Match["Red" + position];
+    var blueTeam = data.Match["Blue" + position];
+    redTeams.push(redTeam);
+    blueTeams.push(blueTeam);
+    redRankings[redTeam] = getRankingText(redTeam, data.Rankings);
+    blueRankings[blueTeam] = getRankingText(blueTeam, data.Rankings);
+  }
 
   $("#scoreMatchName").text(data.MatchType + " Match " + data.Match.DisplayName);
-  $("#redScoreDetails").html(matchResultTemplate({score: data.RedScoreSummary, rankings: redRankings}));
-  $("#blueScoreDetails").html(matchResultTemplate({score: data.BlueScoreSummary, rankings: blueRankings}));
+  var isQualification = data.MatchType === "Qualification";
+  $("#redScoreDetails").html(matchResultTemplate({score: data.RedScoreSummary, elements: data.RedElementScores,
+      bonusRankingPoints: isQualification ? data.RedBonusRankingPoints : [],
+      contributions: getContributions(redTeams, data.RedRobotPoints, data.TeamContributions),
+      rankings: redRankings}));
+  $("#blueScoreDetails").html(matchResultTemplate({score: data.BlueScoreSummary, elements: data.BlueElementScores,
+      bonusRankingPoints: isQualification ? data.BlueBonusRankingPoints : [],
+      contributions: getContributions(blueTeams, data.BlueRobotPoints, data.TeamContributions),
+      rankings: blueRankings}));
   $("#matchResult").modal("show");
 };
 
@@ -103,6 +114,23 @@ var formatTeam = function(team) {
   return team;
 };
 
+// Returns the points each of the given teams contributed in the match and across the event, or null if the game
+// doesn't have any per-robot scoring elements.
+var getContributions = function(teamIds, robotPoints, teamContributions) {
+  if (!teamContributions) {
+    return null;
+  }
+  var contributions = [];
+  $.each(teamIds, function(i, teamId) {
+    if (teamId > 0) {
+      var contribution = teamContributions[teamId];
+      contributions.push({teamId: teamId, matchPoints: robotPoints[i],
+          totalPoints: contribution ? contribution.Points : 0});
+    }
+  });
+  return contributions;
+};
+
 // Returns the string to be displayed to indicate change in rank.
 var getRankingText = function(teamId, rankings) {
   var ranking = rankings[teamId];
@@ -85,18 +85,14 @@ const executeTransitionQueue = function() {
 // Handles a websocket message to update the teams for the current match.
 var handleMatchLoad = function(data) {
   currentMatch = data.Match;
-  $("#" + redSide + "Team1").text(currentMatch.Red1);
-  $("#" + redSide + "Team2").text(currentMatch.Red2);
-  $("#" + redSide + "Team3").text(currentMatch.Red3);
-  $("#" + redSide + "Team1Avatar").attr("src", getAvatarUrl(currentMatch.Red1));
-  $("#" + redSide + "Team2Avatar").attr("src", getAvatarUrl(currentMatch.Red2));
-  $("#" + redSide + "Team3Avatar").attr("src", getAvatarUrl(currentMatch.Red3));
-  $("#" + blueSide + "Team1").text(currentMatch.Blue1);
-  $("#" + blueSide + "Team2").text(currentMatch.Blue2);
-  $("#" + blueSide + "Team3").text(currentMatch.Blue3);
-  $("#" + blueSide + "Team1Avatar").attr("src", getAvatarUrl(currentMatch.Blue1));
-  $("#" + blueSide + "Team2Avatar").attr("src", getAvatarUrl(currentMatch.Blue2));
-  $("#" + blueSide + "Team3Avatar").attr("src", getAvatarUrl(currentMatch.Blue3));
+  for (var position = 1; position <= data.TeamsPerAlliance; position++) {
+    var redTeam = currentMatch["Red" + position];
+    var blueTeam = currentMatch["Blue" + position];
+    $("#" + redSide + "Team" + position).text(redTeam);
+    $("#" + redSide + "Team" + position + "Avatar").attr("src", getAvatarUrl(redTeam));
+    $("#" + blueSide + "Team" + position).text(blueTeam);
+    $("#" + blueSide + "Team" + position + "Avatar").attr("src", getAvatarUrl(blueTeam));
+  }
 
   // Show alliance numbers if this is an elimination match.
   if (currentMatch.Type === "elimination") {
@@ -147,29 +143,40 @@ var handleRealtimeScore = function(data) {
 // Handles a websocket message to populate the final score data.
 var handleScorePosted = function(data) {
   $("#" + redSide + "FinalScore").text(data.RedScoreSummary.Score);
-  $("#" + redSide + "FinalTeam1").html(getRankingText(data.Match.Red1, data.Rankings) + "" + data.Match.Red1);
-  $("#" + redSide + "FinalTeam2").html(getRankingText(data.Match.Red2, data.Rankings) + "" + data.Match.Red2);
-  $("#" + redSide + "FinalTeam3").html(getRankingText(data.Match.Red3, data.Rankings) + "" + data.Match.Red3);
-  $("#" + redSide + "FinalTeam1Avatar").attr("src", getAvatarUrl(data.Match.Red1));
-  $("#" + redSide + "FinalTeam2Avatar").attr("src", getAvatarUrl(data.Match.Red2));
-  $("#" + redSide + "FinalTeam3Avatar").attr("src", getAvatarUrl(data.Match.Red3));
+  for (var position = 1; position <= data.TeamsPerAlliance; position++) {
+    var redTeam = data.Match["Red" + position];
+    $("#" + redSide + "FinalTeam" + position).html(getRankingText(redTeam, data.Rankings) + "" + redTeam +
+        getCardText(redTeam, data.RedCards));
+    $("#" + redSide + "FinalTeam" + position + "Avatar").attr("src", getAvatarUrl(redTeam));
+  }
   $("#" + redSide + "FinalAutoPoints").text(data.RedScoreSummary.AutoPoints);
   $("#" + redSide + "FinalTeleopPoints").text(data.RedScoreSummary.TeleopPoints);
   $("#" + redSide + "FinalEndgamePoints").text(data.RedScoreSummary.EndgamePoints);
+  $("#" + redSide + "FinalFoulPoints").text(data.RedScoreSummary.FoulPoints);
+  $("#" + redSide + "FinalBonusRankingPoints").text(data.RedScoreSummary.BonusRankingPoints);
   $("#" + blueSide + "FinalScore").text(data.BlueScoreSummary.Score);
-  $("#" + blueSide + "FinalTeam1").html(getRankingText(data.Match.Blue1, data.Rankings) + "" + data.Match.Blue1);
-  $("#" + blueSide + "FinalTeam2").html(getRankingText(data.Match.Blue2, data.Rankings) + "" + data.Match.Blue2);
-  $("#" + blueSide + "FinalTeam3").html(getRankingText(data.Match.Blue3, data.Rankings) + "" + data.Match.Blue3);
-  $("#" + blueSide + "FinalTeam1Avatar").attr("src", getAvatarUrl(data.Match.Blue1));
-  $("#" + blueSide + "FinalTeam2Avatar").attr("src", getAvatarUrl(data.Match.Blue2));
-  $("#" + blueSide + "FinalTeam3Avatar").attr("src", getAvatarUrl(data.Match.Blue3));
+  for (var position = 1; position <= data.TeamsPerAlliance; position++) {
+    var blueTeam = data.Match["Blue" + position];
+    $("#" + blueSide + "FinalTeam" + position).html(getRankingText(blueTeam, data.Rankings) + "" + blueTeam +
+        getCardText(blueTeam, data.BlueCards));
+    $("#" + blueSide + "FinalTeam" + position + "Avatar").attr("src", getAvatarUrl(blueTeam));
+  }
   $("#" + blueSide + "FinalAutoPoints").text(data.BlueScoreSummary.AutoPoints);
   $("#" + blueSide + "FinalTeleopPoints").text(data.BlueScoreSummary.TeleopPoints);
   $("#" + blueSide + "FinalEndgamePoints").text(data.BlueScoreSummary.EndgamePoints);
+  $("#" + blueSide + "FinalFoulPoints").text(data.BlueScoreSummary.FoulPoints);
+  $("#" + blueSide + "FinalBonusRankingPoints").text(data.BlueScoreSummary.BonusRankingPoints);
+  $(".final-bonus-rp").toggle(data.MatchType === "Qualification");
   $("#finalSeriesStatus").text(data.SeriesStatus);
   $("#finalSeriesStatus").attr("data-leader", data.SeriesLeader);
   $("#finalMatchName").text(data.MatchType + " " + data.Match.DisplayName);
 
+  // Show how the scores progressed over the course of the match, if the match was played on the field.
+  $("#finalScoreTimeline").toggle(data.HasScoreTimeline);
+  if (data.HasScoreTimeline) {
+    $("#finalScoreTimelineSvg").attr("src", "/api/score_timeline/svg?matchId=saved&v=" + new Date().getTime());
+  }
+
   // Reload the bracket to reflect any changes.
   $("#bracketSvg").attr("src", "/api/bracket/svg?activeMatch=saved&v=" + new Date().getTime());
 };
@@ -662,6 +669,14 @@ var getRankingText = function(teamId, rankings) {
   return "<div class='rank-box rank-same'>" + ranking.Rank + "</div>";
 };
 
+// Returns the HTML for the card, if any, that the given team received in the match.
+var getCardText = function(teamId, cards) {
+  if (cards === null || cards === undefined || !cards[teamId]) {
+    return "";
+  }
+  return "<div class='penalty-card penalty-card-" + cards[teamId] + "'></div>";
+};
+
 $(function() {
   // Read the configuration for this display from the URL query string.
   var urlParams = new URLSearchParams(window.location.search);
@@ -128,6 +128,26 @@ var handleEventStatus = function(data) {
   $("#earlyLateMessage").text(data.EarlyLateMessage);
 };
 
+// Handles a websocket message to show the most recent driver station alert and flag the teams that have had any.
+var handleDsAlerts = function(data) {
+  $(".team-id").attr("data-alert", "");
+  if (!data.Alerts || data.Alerts.length === 0) {
+    $("#dsAlertMessage").text("");
+    return;
+  }
+  $.each(data.Alerts, function(i, alert) {
+    var side = alert.Station[0] === "R" ? redSide : blueSide;
+    $("#" + side + "Team" + alert.Station[1] + "Id").attr("data-alert", "true");
+  });
+  var alert = data.Alerts[data.Alerts.length - 1];
+  var message = alert.Station + " " + alert.TeamId + ": " + alert.Message;
+  message += " at " + alert.MatchTimeSec.toFixed(1) + "s";
+  if (data.Alerts.length > 1) {
+    message += " (+" + (data.Alerts.length - 1) + " more)";
+  }
+  $("#dsAlertMessage").text(message);
+};
+
 // Makes the team notes section editable and handles saving edits to the server.
 var editFtaNotes = function(element) {
   var teamNotesTextElement = $(element);
@@ -161,6 +181,7 @@ $(function() {
   // Set up the websocket back to the server.
   websocket = new CheesyWebsocket("/displays/field_monitor/websocket", {
     arenaStatus: function(event) { handleArenaStatus(event.data); },
+    dsAlerts: function(event) { handleDsAlerts(event.data); },
     eventStatus: function(event) { handleEventStatus(event.data); },
   });
 });
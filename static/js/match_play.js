@@ -6,6 +6,9 @@
 var websocket;
 var currentMatchId;
 var lowBatteryThreshold = 8;
+var currentMatchState;
+var scoringPanelsCommitted = true;
+var headRefApproved = true;
 
 // Sends a websocket message to load a team into an alliance station.
 var substituteTeam = function(team, position) {
@@ -23,11 +26,36 @@ var startMatch = function() {
       { muteMatchSounds: $("#muteMatchSounds").prop("checked") });
 };
 
+// Sends a websocket message to allow the match to start despite the given readiness check failing.
+var overrideReadinessRule = function(ruleId) {
+  var reason = prompt("Reason for overriding this check:");
+  if (reason === null) {
+    return;
+  }
+  var overriddenBy = prompt("Name of the person approving the override (leave blank to use the logged-in user):");
+  if (overriddenBy !== null) {
+    websocket.send("overrideReadinessRule", { ruleId: ruleId, reason: reason, overriddenBy: overriddenBy });
+  }
+};
+
 // Sends a websocket message to abort the match.
 var abortMatch = function() {
   websocket.send("abortMatch");
 };
 
+// Sends a websocket message to disable all robots and freeze the match clock due to a field fault.
+var signalFieldFault = function() {
+  var reason = prompt("Reason for the field fault (optional):");
+  if (reason !== null) {
+    websocket.send("fieldFault", reason);
+  }
+};
+
+// Sends a websocket message to start the countdown to resuming a match paused for a field fault.
+var resumeMatch = function() {
+  websocket.send("resumeMatch");
+};
+
 // Sends a websocket message to signal to the volunteers that they may enter the field.
 var signalVolunteers = function() {
   websocket.send("signalVolunteers");
@@ -75,20 +103,20 @@ var startTimeout = function() {
 
 // Sends a websocket message to update the realtime score
 var updateRealtimeScore = function() {
-  websocket.send("updateRealtimeScore", {
-    blueAuto: parseInt($("#blueAutoScore").val()),
-    redAuto: parseInt($("#redAutoScore").val()),
-    blueTeleop: parseInt($("#blueTeleopScore").val()),
-    redTeleop: parseInt($("#redTeleopScore").val()),
-    blueEndgame: parseInt($("#blueEndgameScore").val()),
-    redEndgame: parseInt($("#redEndgameScore").val())
-  })
+  var scores = {red: {}, blue: {}};
+  $(".score-input").each(function() {
+    scores[$(this).attr("data-alliance")][$(this).attr("data-element")] = parseInt($(this).val());
+  });
+  websocket.send("updateRealtimeScore", scores);
 };
 
+// Moves the focus to the next score input when the enter key is pressed.
 var scoreKeyHandler = function(e) {
   var keycode = (event.keyCode ? event.keyCode : event.which);
   if (keycode == 13) {
-    $('#' + $(event.target).attr("data-next")).focus().select();
+    var scoreInputs = $(".score-input");
+    var nextIndex = (scoreInputs.index(event.target) + 1) % scoreInputs.length;
+    scoreInputs.eq(nextIndex).focus().select();
   }
 };
 
@@ -169,14 +197,20 @@ var handleArenaStatus = function(data) {
   });
 
   // Enable/disable the buttons based on the current match state.
-  switch (matchStates[data.MatchState]) {
+  currentMatchState = matchStates[data.MatchState];
+  switch (currentMatchState) {
     case "PRE_MATCH":
       $("#startMatch").prop("disabled", !data.CanStartMatch);
       if (data.CanStartMatchReason.length < 1) {
         $("#matchStartReason").hide();
       } else {
         $("#matchStartReason").show();
-        $("#matchStartReason").html(data.CanStartMatchReason);
+        $("#matchStartReason").text(data.CanStartMatchReason);
+        if (data.OverridableRuleId) {
+          $("<button type='button' class='btn btn-default btn-xs'>Override</button>").css("margin-left", "1em")
+              .click(function() { overrideReadinessRule(data.OverridableRuleId); })
+              .appendTo("#matchStartReason");
+        }
       }
       $("#abortMatch").prop("disabled", true);
       $("#signalVolunteers").prop("disabled", true);
@@ -185,18 +219,8 @@ var handleArenaStatus = function(data) {
       $("#discardResults").prop("disabled", true);
       $("#editResults").prop("disabled", true);
       $("#startTimeout").prop("disabled", false);
-      $("#blueAutoScore").val("0");
-      $("#redAutoScore").val("0");
-      $("#blueTeleopScore").val("0");
-      $("#redTeleopScore").val("0");
-      $("#blueEndgameScore").val("0");
-      $("#redEndgameScore").val("0");
-      $("#blueAutoScore").prop("disabled", true);
-      $("#redAutoScore").prop("disabled", true);
-      $("#blueTeleopScore").prop("disabled", true);
-      $("#redTeleopScore").prop("disabled", true);
-      $("#blueEndgameScore").prop("disabled", true);
-      $("#redEndgameScore").prop("disabled", true);
+      $(".score-input, .robot-score-total").val("0");
+      $(".score-input").prop("disabled", true);
       break;
     case "START_MATCH":
     case "WARMUP_PERIOD":
@@ -211,28 +235,29 @@ var handleArenaStatus = function(data) {
       $("#discardResults").prop("disabled", true);
       $("#editResults").prop("disabled", true);
       $("#startTimeout").prop("disabled", true);
-      $("#blueAutoScore").prop("disabled", false);
-      $("#redAutoScore").prop("disabled", false);
-      $("#blueTeleopScore").prop("disabled", false);
-      $("#redTeleopScore").prop("disabled", false);
-      $("#blueEndgameScore").prop("disabled", false);
-      $("#redEndgameScore").prop("disabled", false);
+      $(".score-input").prop("disabled", false);
       break;
     case "POST_MATCH":
       $("#startMatch").prop("disabled", true);
       $("#abortMatch").prop("disabled", true);
       $("#signalVolunteers").prop("disabled", false);
       $("#signalReset").prop("disabled", false);
-      $("#commitResults").prop("disabled", false);
+      $("#commitResults").prop("disabled", !scoringPanelsCommitted || !headRefApproved);
       $("#discardResults").prop("disabled", false);
       $("#editResults").prop("disabled", false);
       $("#startTimeout").prop("disabled", true);
-      $("#blueAutoScore").prop("disabled", false);
-      $("#redAutoScore").prop("disabled", false);
-      $("#blueTeleopScore").prop("disabled", false);
-      $("#redTeleopScore").prop("disabled", false);
-      $("#blueEndgameScore").prop("disabled", false);
-      $("#redEndgameScore").prop("disabled", false);
+      $(".score-input").prop("disabled", false);
+      break;
+    case "FIELD_FAULT":
+      $("#startMatch").prop("disabled", true);
+      $("#abortMatch").prop("disabled", false);
+      $("#signalVolunteers").prop("disabled", true);
+      $("#signalReset").prop("disabled", true);
+      $("#commitResults").prop("disabled", true);
+      $("#discardResults").prop("disabled", true);
+      $("#editResults").prop("disabled", true);
+      $("#startTimeout").prop("disabled", true);
+      $(".score-input").prop("disabled", false);
       break;
     case "TIMEOUT_ACTIVE":
       $("#startMatch").prop("disabled", true);
@@ -243,12 +268,7 @@ var handleArenaStatus = function(data) {
       $("#discardResults").prop("disabled", true);
       $("#editResults").prop("disabled", true);
       $("#startTimeout").prop("disabled", true);
-      $("#blueAutoScore").prop("disabled", false);
-      $("#redAutoScore").prop("disabled", false);
-      $("#blueTeleopScore").prop("disabled", false);
-      $("#redTeleopScore").prop("disabled", false);
-      $("#blueEndgameScore").prop("disabled", false);
-      $("#redEndgameScore").prop("disabled", false);
+      $(".score-input").prop("disabled", false);
       break;
     case "POST_TIMEOUT":
       $("#startMatch").prop("disabled", true);
@@ -259,15 +279,16 @@ var handleArenaStatus = function(data) {
       $("#discardResults").prop("disabled", true);
       $("#editResults").prop("disabled", true);
       $("#startTimeout").prop("disabled", true);
-      $("#blueAutoScore").prop("disabled", false);
-      $("#redAutoScore").prop("disabled", false);
-      $("#blueTeleopScore").prop("disabled", false);
-      $("#redTeleopScore").prop("disabled", false);
-      $("#blueEndgameScore").prop("disabled", false);
-      $("#redEndgameScore").prop("disabled", false);
+      $(".score-input").prop("disabled", false);
       break;
   }
 
+  // Swap the field fault button for the resume button while the match is paused for a field fault.
+  var matchIsRunning = ["WARMUP_PERIOD", "AUTO_PERIOD", "PAUSE_PERIOD", "TELEOP_PERIOD"].includes(currentMatchState);
+  $("#fieldFault").prop("disabled", !matchIsRunning);
+  $("#fieldFault").toggle(currentMatchState !== "FIELD_FAULT");
+  $("#resumeMatch").toggle(currentMatchState === "FIELD_FAULT");
+
   if (data.PlcIsHealthy) {
     $("#plcStatus").text("Connected");
     $("#plcStatus").attr("data-ready", true);
@@ -309,24 +330,14 @@ var handleMatchTime = function(data) {
 var handleRealtimeScore = function(data) {
   $("#redScore").text(data.Red.ScoreSummary.Score);
   $("#blueScore").text(data.Blue.ScoreSummary.Score);
-  if (parseInt($("#redAutoScore").val()) != data.Red.Score.AutoPoints) {
-    $("#redAutoScore").val(data.Red.Score.AutoPoints);
-  }
-  if (parseInt($("#redTeleopScore").val()) != data.Red.Score.TeleopPoints) {
-    $("#redTeleopScore").val(data.Red.Score.TeleopPoints);
-  }
-  if (parseInt($("#redEndgameScore").val()) != data.Red.Score.EndgamePoints) {
-    $("#redEndgameScore").val(data.Red.Score.EndgamePoints);
-  }
-  if (parseInt($("#blueAutoScore").val()) != data.Blue.Score.AutoPoints) {
-    $("#blueAutoScore").val(data.Blue.Score.AutoPoints);
-  }
-  if (parseInt($("#blueTeleopScore").val()) != data.Blue.Score.TeleopPoints) {
-    $("#blueTeleopScore").val(data.Blue.Score.TeleopPoints);
-  }
-  if (parseInt($("#blueEndgameScore").val()) != data.Blue.Score.EndgamePoints) {
-    $("#blueEndgameScore").val(data.Blue.Score.EndgamePoints);
-  }
+  $(".score-input, .robot-score-total").each(function() {
+    var allianceScore = $(this).attr("data-alliance") === "red" ? data.Red : data.Blue;
+    var elementCounts = allianceScore.Score.ElementCounts || {};
+    var count = elementCounts[$(this).attr("data-element")] || 0;
+    if (parseInt($(this).val()) !== count) {
+      $(this).val(count);
+    }
+  });
 }
 
 // Handles a websocket message to update the audience display screen selector.
@@ -347,6 +358,39 @@ var handleFieldLights = function(data) {
   $("input[name=fieldLights][value=" + data.Lights + "]").prop("checked", true);
 };
 
+// Handles a websocket message to update the number of scoring panels that have committed their final scores.
+var handleScoringStatus = function(data) {
+  scoringPanelsCommitted = data.AllScoringPanelsCommitted;
+
+  // Count any required panels that aren't connected as not yet ready.
+  var numRedPanels = Math.max(data.NumRedScoringPanels, data.NumScoringPanelsRequired);
+  var numBluePanels = Math.max(data.NumBlueScoringPanels, data.NumScoringPanelsRequired);
+  $("#redScoringStatus").text(data.NumRedScoringPanelsReady + "/" + numRedPanels);
+  $("#redScoringStatus").attr("data-ready", data.NumRedScoringPanelsReady === numRedPanels);
+  $("#blueScoringStatus").text(data.NumBlueScoringPanelsReady + "/" + numBluePanels);
+  $("#blueScoringStatus").attr("data-ready", data.NumBlueScoringPanelsReady === numBluePanels);
+  if (currentMatchState === "POST_MATCH") {
+    $("#commitResults").prop("disabled", !scoringPanelsCommitted || !headRefApproved);
+  }
+};
+
+// Handles a websocket message to update the head referee's approval state of the pending result.
+var handleHeadRefStatus = function(data) {
+  headRefApproved = data.Approved || !data.ApprovalRequired;
+  var status = $("#headRefStatus");
+  if (data.Approved) {
+    status.text("Approved by " + data.ApprovedBy);
+  } else if (data.ReturnReason !== "") {
+    status.text("Sent back: " + data.ReturnReason);
+  } else {
+    status.text(data.ApprovalRequired ? "Awaiting Approval" : "Not Required");
+  }
+  status.attr("data-ready", headRefApproved && data.ReturnReason === "");
+  if (currentMatchState === "POST_MATCH") {
+    $("#commitResults").prop("disabled", !scoringPanelsCommitted || !headRefApproved);
+  }
+};
+
 // Handles a websocket message to update the event status message.
 var handleEventStatus = function(data) {
   if (data.CycleTime === "") {
@@ -367,9 +411,11 @@ $(function() {
     arenaStatus: function(event) { handleArenaStatus(event.data); },
     audienceDisplayMode: function(event) { handleAudienceDisplayMode(event.data); },
     eventStatus: function(event) { handleEventStatus(event.data); },
+    headRefStatus: function(event) { handleHeadRefStatus(event.data); },
     matchTime: function(event) { handleMatchTime(event.data); },
     matchTiming: function(event) { handleMatchTiming(event.data); },
     realtimeScore: function(event) { handleRealtimeScore(event.data); },
+    scoringStatus: function(event) { handleScoringStatus(event.data); },
     fieldLights: function(event) { handleFieldLights(event.data); },
   });
 });
@@ -14,6 +14,8 @@ $("form").submit(function() {
 
   matchResult.RedScore = allianceResults["red"].score;
   matchResult.BlueScore = allianceResults["blue"].score;
+  matchResult.RedCards = allianceResults["red"].cards;
+  matchResult.BlueCards = allianceResults["blue"].cards;
   var matchResultJson = JSON.stringify(matchResult);
 
   // Inject the JSON data into the form as hidden inputs.
@@ -25,12 +27,36 @@ $("form").submit(function() {
 // Draws the match-editing form for one alliance based on the cached result data.
 var renderResults = function(alliance) {
   var result = allianceResults[alliance];
+  if (!result.score.Fouls) {
+    result.score.Fouls = [];
+  }
+  if (!result.cards) {
+    result.cards = {};
+  }
   var scoreContent = scoreTemplate(result);
   $("#" + alliance + "Score").html(scoreContent);
 
-  getInputElement(alliance, "AutoPoints").val(result.score.AutoPoints);
-  getInputElement(alliance, "TeleopPoints").val(result.score.TeleopPoints);
-  getInputElement(alliance, "EndgamePoints").val(result.score.EndgamePoints);
+  var elementCounts = result.score.ElementCounts || {};
+  var robotElementCounts = result.score.RobotElementCounts || [];
+  $("#" + alliance + "Score input[data-element]").each(function() {
+    var counts = elementCounts;
+    if ($(this).is("[data-robot]")) {
+      counts = robotElementCounts[$(this).attr("data-robot")] || {};
+    }
+    $(this).val(counts[$(this).attr("data-element")] || 0);
+  });
+
+  $.each(result.score.Fouls, function(k, foul) {
+    getFoulInputElement(alliance, k, "Team").val(foul.TeamId);
+    getFoulInputElement(alliance, k, "Type").val(foul.Type);
+    getFoulInputElement(alliance, k, "RuleId").val(foul.RuleId);
+    getFoulInputElement(alliance, k, "Time").val(foul.TimeInMatchSec);
+  });
+
+  $.each(getAlliancePositions(), function(i, position) {
+    var card = result.cards[result["team" + position]] || "";
+    $("[name=" + alliance + "Team" + position + "Card]").val(card);
+  });
 };
 
 // Converts the current form values back into JSON structures and caches them.
@@ -41,16 +67,64 @@ var updateResults = function(alliance) {
     formData[v.name] = v.value;
   });
 
-  result.score.AutoPoints = parseInt(formData[alliance + "AutoPoints"]);
-  result.score.TeleopPoints = parseInt(formData[alliance + "TeleopPoints"]);
-  result.score.EndgamePoints = parseInt(formData[alliance + "EndgamePoints"]);
+  // The alliance totals of the per-robot elements are recalculated by the server.
+  result.score.ElementCounts = {};
+  result.score.RobotElementCounts = $.map(getAlliancePositions(), function() {
+    return {};
+  });
+  $("#" + alliance + "Score input[data-element]").each(function() {
+    var elementId = $(this).attr("data-element");
+    if ($(this).is("[data-robot]")) {
+      var robotIndex = parseInt($(this).attr("data-robot"));
+      result.score.RobotElementCounts[robotIndex][elementId] =
+          parseInt(formData[alliance + "-" + elementId + "-" + robotIndex]);
+    } else {
+      result.score.ElementCounts[elementId] = parseInt(formData[alliance + "-" + elementId]);
+    }
+  });
+
+  $.each(result.score.Fouls, function(k, foul) {
+    foul.TeamId = parseInt(formData[alliance + "Foul" + k + "Team"]);
+    foul.Type = formData[alliance + "Foul" + k + "Type"];
+    foul.RuleId = parseInt(formData[alliance + "Foul" + k + "RuleId"]);
+    foul.TimeInMatchSec = parseFloat(formData[alliance + "Foul" + k + "Time"]) || 0;
+  });
+
+  result.cards = {};
+  $.each(getAlliancePositions(), function(i, position) {
+    var card = formData[alliance + "Team" + position + "Card"];
+    if (card) {
+      result.cards[result["team" + position]] = card;
+    }
+  });
 };
 
-// Returns the form input element having the given parameters.
-var getInputElement = function(alliance, name, value) {
-  var selector = "input[name=" + alliance + name + "]";
-  if (value !== undefined) {
-    selector += "[value=" + value + "]";
+// Returns the list of robot positions within an alliance, starting from 1.
+var getAlliancePositions = function() {
+  var positions = [];
+  for (var position = 1; position <= teamsPerAlliance; position++) {
+    positions.push(position);
   }
-  return $(selector);
+  return positions;
+};
+
+// Appends a blank foul to the given alliance's list and redraws the form.
+var addFoul = function(alliance) {
+  updateResults(alliance);
+  var result = allianceResults[alliance];
+  result.score.Fouls.push({TeamId: result.team1, Type: "minor", RuleId: 0, TimeInMatchSec: 0});
+  renderResults(alliance);
 };
+
+// Removes the given foul from the given alliance's list and redraws the form.
+var deleteFoul = function(alliance, index) {
+  updateResults(alliance);
+  allianceResults[alliance].score.Fouls.splice(index, 1);
+  renderResults(alliance);
+};
+
+// Returns the form input element for the given field of the given foul.
+var getFoulInputElement = function(alliance, index, name) {
+  return $("[name=" + alliance + "Foul" + index + name + "]");
+};
+
@@ -12,7 +12,8 @@ var matchStates = {
   5: "TELEOP_PERIOD",
   6: "POST_MATCH",
   7: "TIMEOUT_ACTIVE",
-  8: "POST_TIMEOUT"
+  8: "POST_TIMEOUT",
+  9: "FIELD_FAULT"
 };
 var matchTiming;
 
@@ -30,17 +31,13 @@ var translateMatchTime = function(data, callback) {
       matchStateText = "PRE-MATCH";
       break;
     case "START_MATCH":
-    case "WARMUP_PERIOD":
-      matchStateText = "WARMUP";
+      matchStateText = getPeriodName(0);
       break;
+    case "WARMUP_PERIOD":
     case "AUTO_PERIOD":
-      matchStateText = "AUTONOMOUS";
-      break;
     case "PAUSE_PERIOD":
-      matchStateText = "PAUSE";
-      break;
     case "TELEOP_PERIOD":
-      matchStateText = "TELEOPERATED";
+      matchStateText = getPeriodName(data.MatchPeriodIndex);
       break;
     case "POST_MATCH":
       matchStateText = "POST-MATCH";
@@ -49,24 +46,55 @@ var translateMatchTime = function(data, callback) {
     case "POST_TIMEOUT":
       matchStateText = "TIMEOUT";
       break;
+    case "FIELD_FAULT":
+      matchStateText = data.ResumeCountdownSec > 0 ? "RESUMING IN " + data.ResumeCountdownSec : "FIELD FAULT";
+      break;
   }
-  callback(matchStates[data.MatchState], matchStateText, getCountdown(data.MatchState, data.MatchTimeSec));
+  callback(matchStates[data.MatchState], matchStateText, getCountdown(data));
 };
 
-// Returns the per-period countdown for the given match state and overall time into the match.
-var getCountdown = function(matchState, matchTimeSec) {
-  switch (matchStates[matchState]) {
-    case "PRE_MATCH":
-    case "START_MATCH":
+// Returns the display name of the match period with the given index.
+var getPeriodName = function(periodIndex) {
+  var period = matchTiming.Periods[periodIndex];
+  return period ? period.Name.toUpperCase() : "";
+};
+
+// Returns the index of the first period at or after the given one in which robots are enabled, or -1 if none.
+var getNextEnabledPeriodIndex = function(periodIndex) {
+  for (var i = periodIndex; i < matchTiming.Periods.length; i++) {
+    if (matchTiming.Periods[i].Enabled) {
+      return i;
+    }
+  }
+  return -1;
+};
+
+// Returns the per-period countdown for the given match time message. During a period in which robots are disabled,
+// shows the full length of the next period in which they will be enabled.
+var getCountdown = function(data) {
+  var periodIndex = 0;
+  switch (matchStates[data.MatchState]) {
     case "WARMUP_PERIOD":
-      return matchTiming.AutoDurationSec;
     case "AUTO_PERIOD":
-      return matchTiming.WarmupDurationSec + matchTiming.AutoDurationSec - matchTimeSec;
+    case "PAUSE_PERIOD":
     case "TELEOP_PERIOD":
-      return matchTiming.WarmupDurationSec + matchTiming.AutoDurationSec + matchTiming.TeleopDurationSec +
-          matchTiming.PauseDurationSec - matchTimeSec;
+    case "FIELD_FAULT":
+      periodIndex = data.MatchPeriodIndex;
+      var period = matchTiming.Periods[periodIndex];
+      if (period && period.Enabled) {
+        var periodEndSec = 0;
+        for (var i = 0; i <= periodIndex; i++) {
+          periodEndSec += matchTiming.Periods[i].DurationSec;
+        }
+        return periodEndSec - data.MatchTimeSec;
+      }
+      // Fall through to show the length of the next enabled period.
+    case "PRE_MATCH":
+    case "START_MATCH":
+      var nextIndex = getNextEnabledPeriodIndex(periodIndex);
+      return nextIndex >= 0 ? matchTiming.Periods[nextIndex].DurationSec : 0;
     case "TIMEOUT_ACTIVE":
-      return matchTiming.TimeoutDurationSec - matchTimeSec;
+      return matchTiming.TimeoutDurationSec - data.MatchTimeSec;
     default:
       return 0;
   }
@@ -0,0 +1,93 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Client-side logic for the head referee review panel.
+
+var websocket;
+
+// Sends a websocket message to approve the pending match result.
+var approveResult = function() {
+  websocket.send("approveResult", $("#approvedBy").val());
+};
+
+// Sends a websocket message to send the pending match result back to the scorekeeper for correction.
+var returnResult = function() {
+  websocket.send("returnResult", $("#returnReason").val());
+  $("#returnReason").val("");
+};
+
+// Handles a websocket message to update the teams for the current match.
+var handleMatchLoad = function(data) {
+  $("#matchName").text(data.MatchType + " Match " + data.Match.DisplayName);
+};
+
+// Handles a websocket message to update the match status.
+var handleMatchTime = function(data) {
+  translateMatchTime(data, function(matchState, matchStateText, countdownSec) {
+    $("#matchState").text(matchStateText);
+    $("#reviewControls").toggle(matchState === "POST_MATCH");
+  });
+};
+
+// Handles a websocket message to update the pending scores and fouls of each alliance.
+var handleRealtimeScore = function(data) {
+  updateAllianceResult($("#redResult"), data.Red);
+  updateAllianceResult($("#blueResult"), data.Blue);
+};
+
+var updateAllianceResult = function(container, allianceScore) {
+  var summary = allianceScore.ScoreSummary;
+  container.find(".total-score").text(summary.Score);
+  container.find(".auto-points").text(summary.AutoPoints);
+  container.find(".teleop-points").text(summary.TeleopPoints);
+  container.find(".endgame-points").text(summary.EndgamePoints);
+  container.find(".foul-points").text(summary.FoulPoints);
+
+  var elementRows = container.find(".element-scores tbody").empty();
+  $.each(allianceScore.ElementScores || [], function(i, elementScore) {
+    elementRows.append($("<tr>").append($("<td>").text(elementScore.Name),
+        $("<td>").text(elementScore.Count + " (" + elementScore.TotalPoints + " pts)")));
+  });
+
+  var foulRows = container.find(".fouls tbody").empty();
+  $.each(allianceScore.Score.Fouls || [], function(i, foul) {
+    var rule = rules[foul.RuleId];
+    foulRows.append($("<tr>").append($("<td>").text(foul.TeamId || ""), $("<td>").text(foul.Type),
+        $("<td>").text(rule ? rule.RuleNumber : "").attr("title", rule ? rule.Description : ""),
+        $("<td>").text(foul.TimeInMatchSec.toFixed(1))));
+  });
+};
+
+// Handles a websocket message to update the cards and the approval state of the pending result.
+var handleHeadRefStatus = function(data) {
+  updateCards($("#redResult"), data.RedCards);
+  updateCards($("#blueResult"), data.BlueCards);
+
+  var status = $("#approvalStatus");
+  status.removeClass("approved returned");
+  if (data.Approved) {
+    status.addClass("approved").text("Approved by " + data.ApprovedBy + " at " +
+        new Date(data.ApprovedAt).toLocaleTimeString());
+  } else if (data.ReturnReason !== "") {
+    status.addClass("returned").text("Sent back: " + data.ReturnReason);
+  } else {
+    status.text("Awaiting approval" + (data.ApprovalRequired ? " (required to commit)" : ""));
+  }
+};
+
+var updateCards = function(container, cards) {
+  var cardRows = container.find(".cards tbody").empty();
+  $.each(cards || {}, function(teamId, card) {
+    cardRows.append($("<tr>").append($("<td>").text(teamId), $("<td>").text(card)));
+  });
+};
+
+$(function() {
+  // Set up the websocket back to the server.
+  websocket = new CheesyWebsocket("/panels/referee/websocket", {
+    headRefStatus: function(event) { handleHeadRefStatus(event.data); },
+    matchLoad: function(event) { handleMatchLoad(event.data); },
+    matchTime: function(event) { handleMatchTime(event.data); },
+    matchTiming: function(event) { handleMatchTiming(event.data); },
+    realtimeScore: function(event) { handleRealtimeScore(event.data); }
+  });
+});
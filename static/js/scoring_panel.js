@@ -0,0 +1,75 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Client-side logic for the realtime scoring panel.
+
+var websocket;
+var currentMatchId;
+var scoreCommitted = false;
+
+// Sends a websocket message to add the given amount to the count of a scoring element. The position is that of the
+// robot for per-robot elements, or zero for alliance-level elements.
+var score = function(elementId, position, delta) {
+  if (!scoreCommitted) {
+    websocket.send("score", {ElementId: elementId, Position: position, Delta: delta});
+  }
+};
+
+// Sends a websocket message to indicate that the score for this panel is final.
+var commitMatchScore = function() {
+  websocket.send("commitMatch");
+  scoreCommitted = true;
+  $("#commitMatchScore").hide();
+  $("#postMatchMessage").css("display", "flex");
+};
+
+// Handles a websocket message to update the teams for the current match.
+var handleMatchLoad = function(data) {
+  $("#matchName").text(data.MatchType + " Match " + data.Match.DisplayName);
+  var prefix = alliance === "red" ? "R" : "B";
+  for (var position = 1; position <= data.TeamsPerAlliance; position++) {
+    var team = data.Teams[prefix + position];
+    $("#team" + position).text(team ? team.Id : "");
+  }
+
+  // The committed state only resets once a different match is loaded, not when teams are substituted.
+  if (data.Match.Id !== currentMatchId) {
+    currentMatchId = data.Match.Id;
+    scoreCommitted = false;
+    $("#postMatchMessage").hide();
+  }
+};
+
+// Handles a websocket message to update the match status.
+var handleMatchTime = function(data) {
+  translateMatchTime(data, function(matchState, matchStateText, countdownSec) {
+    $("#matchState").text(matchStateText);
+    if (matchState === "POST_MATCH" && !scoreCommitted) {
+      $("#commitMatchScore").css("display", "flex");
+    } else {
+      $("#commitMatchScore").hide();
+    }
+  });
+};
+
+// Handles a websocket message to update the realtime scoring fields.
+var handleRealtimeScore = function(data) {
+  var allianceScore = alliance === "red" ? data.Red.Score : data.Blue.Score;
+  var elementCounts = allianceScore.ElementCounts || {};
+  var robotElementCounts = allianceScore.RobotElementCounts || [];
+  $(".count").each(function() {
+    var elementId = $(this).attr("data-element");
+    var position = parseInt($(this).attr("data-position"));
+    var counts = position > 0 ? robotElementCounts[position - 1] || {} : elementCounts;
+    $(this).text(counts[elementId] || 0);
+  });
+};
+
+$(function() {
+  // Set up the websocket back to the server.
+  websocket = new CheesyWebsocket("/panels/scoring/" + alliance + "/websocket", {
+    matchLoad: function(event) { handleMatchLoad(event.data); },
+    matchTime: function(event) { handleMatchTime(event.data); },
+    matchTiming: function(event) { handleMatchTiming(event.data); },
+    realtimeScore: function(event) { handleRealtimeScore(event.data); }
+  });
+});
@@ -105,19 +105,9 @@ func CreateOrUpdateWinnerAndFinalistAwards(database *model.Database, winnerAllia
 	}
 
 	// Clear out any awards that may exist if the final match was scored more than once.
-	winnerAwards, err := database.GetAwardsByType(model.WinnerAward)
-	if err != nil {
+	if err = DeleteWinnerAndFinalistAwards(database); err != nil {
 		return err
 	}
-	finalistAwards, err := database.GetAwardsByType(model.FinalistAward)
-	if err != nil {
-		return err
-	}
-	for _, award := range append(winnerAwards, finalistAwards...) {
-		if err = DeleteAward(database, award.Id); err != nil {
-			return err
-		}
-	}
 
 	// Create the finalist awards first since they're usually presented first.
 	finalistAward := model.Award{
@@ -156,6 +146,24 @@ func CreateOrUpdateWinnerAndFinalistAwards(database *model.Database, winnerAllia
 	return nil
 }
 
+// Deletes the awards and lower thirds for the tournament winners and finalists, if they have been generated.
+func DeleteWinnerAndFinalistAwards(database *model.Database) error {
+	winnerAwards, err := database.GetAwardsByType(model.WinnerAward)
+	if err != nil {
+		return err
+	}
+	finalistAwards, err := database.GetAwardsByType(model.FinalistAward)
+	if err != nil {
+		return err
+	}
+	for _, award := range append(winnerAwards, finalistAwards...) {
+		if err = DeleteAward(database, award.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func createOrUpdateAwardLowerThird(database *model.Database, lowerThird *model.LowerThird,
 	existingLowerThirds []model.LowerThird, index int) error {
 	if index < len(existingLowerThirds) {
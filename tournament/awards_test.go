@@ -149,4 +149,13 @@ func TestCreateOrUpdateWinnerAndFinalistAwards(t *testing.T) {
 		assert.Equal(t, "Winner", lowerThirds[6].TopText)
 		assert.Equal(t, "Team 101, ", lowerThirds[6].BottomText)
 	}
+
+	database.CreateAward(&model.Award{Type: model.JudgedAward, AwardName: "Safety Award", TeamId: 101})
+	assert.Nil(t, DeleteWinnerAndFinalistAwards(database))
+	awards, _ = database.GetAllAwards()
+	if assert.Equal(t, 1, len(awards)) {
+		assert.Equal(t, model.JudgedAward, awards[0].Type)
+	}
+	lowerThirds, _ = database.GetAllLowerThirds()
+	assert.Equal(t, 0, len(lowerThirds))
 }
@@ -8,42 +8,49 @@ package tournament
 import (
 	"github.com/FRCTeam1987/crimson-arena/game"
 	"github.com/FRCTeam1987/crimson-arena/model"
-	"sort"
 )
 
 // Determines the rankings from the stored match results, and saves them to the database.
 func CalculateRankings(database *model.Database, preservePreviousRank bool) (game.Rankings, error) {
+	eventSettings, err := database.GetEventSettings()
+	if err != nil {
+		return nil, err
+	}
+	tiebreakers, err := game.ParseTiebreakers(eventSettings.RankingTiebreakers, game.CurrentGame)
+	if err != nil {
+		return nil, err
+	}
+
 	matches, err := database.GetMatchesByType("qualification")
 	if err != nil {
 		return nil, err
 	}
 	rankings := make(map[int]*game.Ranking)
+	headToHead := make(game.HeadToHeadRecords)
+	carriedYellowCards := make(map[int]bool)
 	for _, match := range matches {
 		if !match.IsComplete() {
 			continue
 		}
-		matchResult, err := database.GetMatchResultForMatch(match.Id)
+		issuedMatchResult, err := database.GetMatchResultForMatch(match.Id)
 		if err != nil {
 			return nil, err
 		}
-		if !match.Red1IsSurrogate {
-			addMatchResultToRankings(rankings, match.Red1, matchResult, true)
-		}
-		if !match.Red2IsSurrogate {
-			addMatchResultToRankings(rankings, match.Red2, matchResult, true)
-		}
-		if !match.Red3IsSurrogate {
-			addMatchResultToRankings(rankings, match.Red3, matchResult, true)
-		}
-		if !match.Blue1IsSurrogate {
-			addMatchResultToRankings(rankings, match.Blue1, matchResult, false)
-		}
-		if !match.Blue2IsSurrogate {
-			addMatchResultToRankings(rankings, match.Blue2, matchResult, false)
+		// Rank with any second yellow card counted as the red card it becomes, which isn't saved on the result.
+		matchResult := issuedMatchResult.WithCardCarryover(carriedYellowCards)
+		issuedMatchResult.AddCarriedYellowCards(carriedYellowCards)
+		redSurrogates, blueSurrogates := match.RedSurrogates(), match.BlueSurrogates()
+		for i, teamId := range match.RedTeamIds() {
+			if teamId > 0 && !redSurrogates[i] {
+				addMatchResultToRankings(rankings, teamId, matchResult, true)
+			}
 		}
-		if !match.Blue3IsSurrogate {
-			addMatchResultToRankings(rankings, match.Blue3, matchResult, false)
+		for i, teamId := range match.BlueTeamIds() {
+			if teamId > 0 && !blueSurrogates[i] {
+				addMatchResultToRankings(rankings, teamId, matchResult, false)
+			}
 		}
+		addMatchResultToHeadToHead(headToHead, &match, matchResult)
 	}
 
 	// Retrieve old rankings so that we can display changes in rank as a result of this calculation.
@@ -56,7 +63,7 @@ func CalculateRankings(database *model.Database, preservePreviousRank bool) (gam
 		oldRankingsMap[ranking.TeamId] = ranking
 	}
 
-	sortedRankings := sortRankings(rankings)
+	sortedRankings := sortRankings(rankings, tiebreakers, headToHead)
 	for rank, ranking := range sortedRankings {
 		sortedRankings[rank].Rank = rank + 1
 		if oldRank, ok := oldRankingsMap[ranking.TeamId]; ok {
@@ -85,18 +92,62 @@ func addMatchResultToRankings(
 		rankings[teamId] = ranking
 	}
 
+	disqualified := matchResult.IsTeamDisqualified(teamId)
 	if isRed {
-		ranking.AddScoreSummary(matchResult.RedScoreSummary(), matchResult.BlueScoreSummary())
+		ranking.AddScoreSummary(matchResult.RedScoreSummary(), matchResult.BlueScoreSummary(), disqualified)
+		if !disqualified {
+			ranking.AddElementCounts(matchResult.RedScore.ElementCounts)
+		}
 	} else {
-		ranking.AddScoreSummary(matchResult.BlueScoreSummary(), matchResult.RedScoreSummary())
+		ranking.AddScoreSummary(matchResult.BlueScoreSummary(), matchResult.RedScoreSummary(), disqualified)
+		if !disqualified {
+			ranking.AddElementCounts(matchResult.BlueScore.ElementCounts)
+		}
+	}
+}
+
+// Credits each team on the winning alliance of the given match with a win over each team on the losing alliance.
+// Surrogate appearances are excluded, as they are from the rankings.
+func addMatchResultToHeadToHead(headToHead game.HeadToHeadRecords, match *model.Match, matchResult *model.MatchResult) {
+	redTeamIds, blueTeamIds := match.RedTeamIds(), match.BlueTeamIds()
+	redSurrogates, blueSurrogates := match.RedSurrogates(), match.BlueSurrogates()
+	for i := range redTeamIds {
+		redTeamIds[i] = nonSurrogateTeamId(redTeamIds[i], redSurrogates[i])
+		blueTeamIds[i] = nonSurrogateTeamId(blueTeamIds[i], blueSurrogates[i])
+	}
+	var winnerTeamIds, loserTeamIds [game.MaxTeamsPerAlliance]int
+	switch game.DetermineMatchStatus(matchResult.RedScoreSummary(), matchResult.BlueScoreSummary()) {
+	case game.RedWonMatch:
+		winnerTeamIds, loserTeamIds = redTeamIds, blueTeamIds
+	case game.BlueWonMatch:
+		winnerTeamIds, loserTeamIds = blueTeamIds, redTeamIds
+	default:
+		return
+	}
+	for _, winnerTeamId := range winnerTeamIds {
+		for _, loserTeamId := range loserTeamIds {
+			if winnerTeamId > 0 && loserTeamId > 0 {
+				headToHead.AddWin(winnerTeamId, loserTeamId)
+			}
+		}
+	}
+}
+
+// Returns the given team ID, or zero if the team is playing as a surrogate.
+func nonSurrogateTeamId(teamId int, isSurrogate bool) int {
+	if isSurrogate {
+		return 0
 	}
+	return teamId
 }
 
-func sortRankings(rankings map[int]*game.Ranking) game.Rankings {
+func sortRankings(
+	rankings map[int]*game.Ranking, tiebreakers []game.Tiebreaker, headToHead game.HeadToHeadRecords,
+) game.Rankings {
 	var sortedRankings game.Rankings
 	for _, ranking := range rankings {
 		sortedRankings = append(sortedRankings, *ranking)
 	}
-	sort.Sort(sortedRankings)
+	game.SortRankings(sortedRankings, tiebreakers, headToHead)
 	return sortedRankings
 }
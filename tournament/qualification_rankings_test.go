@@ -85,6 +85,65 @@ func TestCalculateRankings(t *testing.T) {
 	}
 }
 
+func TestCalculateRankingsWithDisqualification(t *testing.T) {
+	database := setupTestDb(t)
+
+	match := model.Match{Type: "qualification", DisplayName: "1", Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5,
+		Blue3: 6, Status: game.RedWonMatch}
+	database.CreateMatch(&match)
+	matchResult := model.BuildTestMatchResult(match.Id, 1)
+	matchResult.RedCards = map[string]string{"2": "red", "3": "yellow"}
+	database.CreateMatchResult(matchResult)
+
+	_, err := CalculateRankings(database, false)
+	assert.Nil(t, err)
+	ranking1, _ := database.GetRankingForTeam(1)
+	ranking2, _ := database.GetRankingForTeam(2)
+	ranking3, _ := database.GetRankingForTeam(3)
+	if assert.NotNil(t, ranking1) && assert.NotNil(t, ranking2) && assert.NotNil(t, ranking3) {
+		assert.Equal(t, 2, ranking1.RankingPoints)
+		assert.Equal(t, 0, ranking1.Disqualifications)
+		assert.Equal(t, 0, ranking2.RankingPoints)
+		assert.Equal(t, 0, ranking2.Wins)
+		assert.Equal(t, 1, ranking2.Disqualifications)
+		assert.Equal(t, 1, ranking2.Played)
+		assert.Equal(t, 2, ranking3.RankingPoints)
+	}
+}
+
+func TestCalculateRankingsWithTiebreakers(t *testing.T) {
+	database := setupTestDb(t)
+
+	match := model.Match{Type: "qualification", DisplayName: "1", Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5,
+		Blue3: 6, Status: game.RedWonMatch}
+	database.CreateMatch(&match)
+	matchResult := model.BuildTestMatchResult(match.Id, 1)
+	matchResult.RedScore.Fouls = []game.Foul{{Type: game.MajorFoul, TeamId: 1}}
+	database.CreateMatchResult(matchResult)
+
+	eventSettings, _ := database.GetEventSettings()
+	eventSettings.RankingTiebreakers = "headToHead, bogus"
+	database.UpdateEventSettings(eventSettings)
+	_, err := CalculateRankings(database, false)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "unknown tiebreaker 'bogus'")
+	}
+
+	eventSettings.RankingTiebreakers = "headToHead, fewestFouls"
+	database.UpdateEventSettings(eventSettings)
+	rankings, err := CalculateRankings(database, false)
+	assert.Nil(t, err)
+	if assert.Equal(t, 6, len(rankings)) {
+		assert.ElementsMatch(t, []int{1, 2, 3}, []int{rankings[0].TeamId, rankings[1].TeamId, rankings[2].TeamId})
+		assert.Equal(t, "", rankings[2].Tiebreaker)
+		assert.Equal(t, 6, rankings[0].ConcededFoulPoints)
+		assert.Equal(t, 45, rankings[0].ElementCounts["auto"])
+		assert.Equal(t, 0, rankings[3].ConcededFoulPoints)
+		assert.Equal(t, 15, rankings[3].ElementCounts["auto"])
+		assert.Equal(t, game.RandomTiebreakerName, rankings[3].Tiebreaker)
+	}
+}
+
 // Sets up a schedule and results that touches on all possible variables.
 func setupMatchResultsForRankings(database *model.Database) {
 	match1 := model.Match{Type: "qualification", DisplayName: "1", Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5,
@@ -98,8 +157,8 @@ func setupMatchResultsForRankings(database *model.Database) {
 	database.CreateMatch(&match2)
 	matchResult2 := model.BuildTestMatchResult(match2.Id, 1)
 	matchResult2.BlueScore, matchResult2.RedScore = matchResult2.RedScore, matchResult2.BlueScore
-	matchResult2.RedScore.AutoPoints += 2
-	matchResult2.BlueScore.AutoPoints += 2
+	matchResult2.RedScore.ElementCounts["auto"] += 2
+	matchResult2.BlueScore.ElementCounts["auto"] += 2
 	database.CreateMatchResult(matchResult2)
 
 	match3 := model.Match{Type: "qualification", DisplayName: "3", Red1: 6, Red2: 5, Red3: 4, Blue1: 3, Blue2: 2,
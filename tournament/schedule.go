@@ -18,21 +18,61 @@ import (
 )
 
 const (
-	schedulesDir  = "schedules"
-	TeamsPerMatch = 6
+	schedulesDir = "schedules"
+	// Number of times a generated schedule is dealt before giving up on keeping each team out of a match twice.
+	maxAnonScheduleAttempts = 100
 )
 
-// Creates a random schedule for the given parameters and returns it as a list of matches.
-func BuildRandomSchedule(teams []model.Team, scheduleBlocks []model.ScheduleBlock,
-	matchType string) ([]model.Match, error) {
-	// Load the anonymized, pre-randomized match schedule for the given number of teams and matches per team.
+// Creates a random schedule for the given parameters and returns it as a list of matches. Schedules with the standard
+// three teams per alliance are drawn from the pre-randomized templates; other alliance sizes are generated on the fly.
+func BuildRandomSchedule(teams []model.Team, scheduleBlocks []model.ScheduleBlock, matchType string,
+	teamsPerAlliance int) ([]model.Match, error) {
 	numTeams := len(teams)
+	teamsPerMatch := 2 * teamsPerAlliance
 	numMatches := countMatches(scheduleBlocks)
-	matchesPerTeam := int(float32(numMatches*TeamsPerMatch) / float32(numTeams))
+	matchesPerTeam := int(float32(numMatches*teamsPerMatch) / float32(numTeams))
 
 	// Adjust the number of matches to remove any excess from non-perfect block scheduling.
-	numMatches = int(math.Ceil(float64(numTeams) * float64(matchesPerTeam) / TeamsPerMatch))
+	numMatches = int(math.Ceil(float64(numTeams) * float64(matchesPerTeam) / float64(teamsPerMatch)))
 
+	var anonSchedule [][]int
+	var err error
+	if teamsPerAlliance == 3 {
+		anonSchedule, err = loadAnonSchedule(numTeams, matchesPerTeam, numMatches)
+	} else {
+		anonSchedule, err = generateAnonSchedule(numTeams, matchesPerTeam, numMatches, teamsPerMatch)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate a random permutation of the team ordering to fill into the pre-randomized schedule.
+	teamShuffle := rand.Perm(numTeams)
+	matches := make([]model.Match, numMatches)
+	for i, anonMatch := range anonSchedule {
+		matches[i].Type = matchType
+		matches[i].DisplayName = strconv.Itoa(i + 1)
+		for j, station := range model.AllianceStationNames(teamsPerAlliance) {
+			matches[i].SetTeamIdForStation(station, teams[teamShuffle[anonMatch[2*j]-1]].Id)
+			matches[i].SetIsSurrogateForStation(station, anonMatch[2*j+1] == 1)
+		}
+	}
+
+	// Fill in the match times.
+	matchIndex := 0
+	for _, block := range scheduleBlocks {
+		for i := 0; i < block.NumMatches && matchIndex < numMatches; i++ {
+			matches[matchIndex].Time = block.StartTime.Add(time.Duration(i*block.MatchSpacingSec) * time.Second)
+			matchIndex++
+		}
+	}
+
+	return matches, nil
+}
+
+// Loads the anonymized, pre-randomized three-team-alliance match schedule for the given number of teams and matches
+// per team. Each row holds a one-based team index followed by a surrogate flag for each station.
+func loadAnonSchedule(numTeams, matchesPerTeam, numMatches int) ([][]int, error) {
 	file, err := os.Open(fmt.Sprintf("%s/%d_%d.csv", filepath.Join(model.BaseDir, schedulesDir), numTeams,
 		matchesPerTeam))
 	if err != nil {
@@ -49,8 +89,9 @@ func BuildRandomSchedule(teams []model.Team, scheduleBlocks []model.ScheduleBloc
 	}
 
 	// Convert string fields from schedule to integers.
-	anonSchedule := make([][12]int, numMatches)
+	anonSchedule := make([][]int, numMatches)
 	for i := 0; i < numMatches; i++ {
+		anonSchedule[i] = make([]int, 12)
 		for j := 0; j < 12; j++ {
 			anonSchedule[i][j], err = strconv.Atoi(csvLines[i][j])
 			if err != nil {
@@ -58,37 +99,99 @@ func BuildRandomSchedule(teams []model.Team, scheduleBlocks []model.ScheduleBloc
 			}
 		}
 	}
+	return anonSchedule, nil
+}
 
-	// Generate a random permutation of the team ordering to fill into the pre-randomized schedule.
-	teamShuffle := rand.Perm(numTeams)
-	matches := make([]model.Match, numMatches)
-	for i, anonMatch := range anonSchedule {
-		matches[i].Type = matchType
-		matches[i].DisplayName = strconv.Itoa(i + 1)
-		matches[i].Red1 = teams[teamShuffle[anonMatch[0]-1]].Id
-		matches[i].Red1IsSurrogate = anonMatch[1] == 1
-		matches[i].Red2 = teams[teamShuffle[anonMatch[2]-1]].Id
-		matches[i].Red2IsSurrogate = anonMatch[3] == 1
-		matches[i].Red3 = teams[teamShuffle[anonMatch[4]-1]].Id
-		matches[i].Red3IsSurrogate = anonMatch[5] == 1
-		matches[i].Blue1 = teams[teamShuffle[anonMatch[6]-1]].Id
-		matches[i].Blue1IsSurrogate = anonMatch[7] == 1
-		matches[i].Blue2 = teams[teamShuffle[anonMatch[8]-1]].Id
-		matches[i].Blue2IsSurrogate = anonMatch[9] == 1
-		matches[i].Blue3 = teams[teamShuffle[anonMatch[10]-1]].Id
-		matches[i].Blue3IsSurrogate = anonMatch[11] == 1
+// Generates an anonymized match schedule in the same format as the templates for alliance sizes that have none, by
+// dealing out successive random orderings of the teams. Teams dealt into the leftover slots of the last match beyond
+// their fair share of matches are marked as surrogates.
+func generateAnonSchedule(numTeams, matchesPerTeam, numMatches, teamsPerMatch int) ([][]int, error) {
+	if numTeams < teamsPerMatch {
+		return nil, fmt.Errorf("Need at least %d teams to schedule matches, have %d", teamsPerMatch, numTeams)
 	}
 
-	// Fill in the match times.
-	matchIndex := 0
-	for _, block := range scheduleBlocks {
-		for i := 0; i < block.NumMatches && matchIndex < numMatches; i++ {
-			matches[matchIndex].Time = block.StartTime.Add(time.Duration(i*block.MatchSpacingSec) * time.Second)
-			matchIndex++
+	// Deal again with a fresh shuffle in the unlikely event that a team can't be kept out of the same match twice.
+	var slots []int
+	separated := false
+	for attempt := 0; attempt < maxAnonScheduleAttempts && !separated; attempt++ {
+		slots = dealAnonSlots(numTeams, numMatches*teamsPerMatch)
+		separated = separateRepeatedTeams(slots, teamsPerMatch)
+	}
+	if !separated {
+		return nil, fmt.Errorf("Unable to schedule %d teams without a team appearing twice in a match", numTeams)
+	}
+
+	anonSchedule := make([][]int, numMatches)
+	appearances := make(map[int]int)
+	for i := range anonSchedule {
+		anonSchedule[i] = make([]int, 2*teamsPerMatch)
+		for j := 0; j < teamsPerMatch; j++ {
+			team := slots[i*teamsPerMatch+j]
+			appearances[team]++
+			anonSchedule[i][2*j] = team
+			if appearances[team] > matchesPerTeam {
+				anonSchedule[i][2*j+1] = 1
+			}
 		}
 	}
+	return anonSchedule, nil
+}
 
-	return matches, nil
+// Returns the given number of match slots filled with successive random orderings of the one-based team indices.
+func dealAnonSlots(numTeams, numSlots int) []int {
+	var slots []int
+	for len(slots) < numSlots {
+		for _, index := range rand.Perm(numTeams) {
+			slots = append(slots, index+1)
+		}
+	}
+	return slots[:numSlots]
+}
+
+// Where consecutive orderings meet, a team can land in the same match twice; swaps each repeat with a slot in another
+// match that doesn't create a new repeat. Returns false if a repeat is left for which no such swap exists.
+func separateRepeatedTeams(slots []int, teamsPerMatch int) bool {
+	matchContains := func(match, team int) bool {
+		for _, slotTeam := range slots[match*teamsPerMatch : (match+1)*teamsPerMatch] {
+			if slotTeam == team {
+				return true
+			}
+		}
+		return false
+	}
+	for i := range slots {
+		match := i / teamsPerMatch
+		for j := match * teamsPerMatch; j < i; j++ {
+			if slots[j] != slots[i] {
+				continue
+			}
+			swapped := false
+			for k := range slots {
+				otherMatch := k / teamsPerMatch
+				if otherMatch != match && !matchContains(match, slots[k]) && !matchContains(otherMatch, slots[i]) {
+					slots[i], slots[k] = slots[k], slots[i]
+					swapped = true
+					break
+				}
+			}
+			if !swapped {
+				return false
+			}
+			break
+		}
+	}
+	return true
+}
+
+// Assigns the given matches to the given fields in alternation, in the order in which they are to be played. Leaves
+// the matches unassigned if there is only a single field.
+func AssignFields(matches []model.Match, fields []model.Field) {
+	if len(fields) < 2 {
+		return
+	}
+	for i := range matches {
+		matches[i].FieldId = fields[i%len(fields)].Id
+	}
 }
 
 // Returns the total number of matches that can be run within the given schedule blocks.
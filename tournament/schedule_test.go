@@ -21,7 +21,7 @@ func TestMain(m *testing.M) {
 func TestNonExistentSchedule(t *testing.T) {
 	teams := make([]model.Team, 5)
 	scheduleBlocks := []model.ScheduleBlock{{0, "", time.Unix(0, 0).UTC(), 2, 60}}
-	_, err := BuildRandomSchedule(teams, scheduleBlocks, "test")
+	_, err := BuildRandomSchedule(teams, scheduleBlocks, "test", 3)
 	expectedErr := "No schedule template exists for 5 teams and 2 matches"
 	if assert.NotNil(t, err) {
 		assert.Equal(t, expectedErr, err.Error())
@@ -36,7 +36,7 @@ func TestMalformedSchedule(t *testing.T) {
 	scheduleFile.Close()
 	teams := make([]model.Team, 5)
 	scheduleBlocks := []model.ScheduleBlock{{0, "", time.Unix(0, 0).UTC(), 1, 60}}
-	_, err := BuildRandomSchedule(teams, scheduleBlocks, "test")
+	_, err := BuildRandomSchedule(teams, scheduleBlocks, "test", 3)
 	expectedErr := "Schedule file contains 2 matches, expected 1"
 	if assert.NotNil(t, err) {
 		assert.Equal(t, expectedErr, err.Error())
@@ -46,7 +46,7 @@ func TestMalformedSchedule(t *testing.T) {
 	scheduleFile, _ = os.Create(filename)
 	scheduleFile.WriteString("1,0,asdf,0,3,0,4,0,5,0,6,0\n")
 	scheduleFile.Close()
-	_, err = BuildRandomSchedule(teams, scheduleBlocks, "test")
+	_, err = BuildRandomSchedule(teams, scheduleBlocks, "test", 3)
 	if assert.NotNil(t, err) {
 		assert.Contains(t, err.Error(), "strconv.Atoi")
 	}
@@ -61,7 +61,7 @@ func TestScheduleTeams(t *testing.T) {
 		teams[i].Id = i + 101
 	}
 	scheduleBlocks := []model.ScheduleBlock{{0, "", time.Unix(0, 0).UTC(), 6, 60}}
-	matches, err := BuildRandomSchedule(teams, scheduleBlocks, "test")
+	matches, err := BuildRandomSchedule(teams, scheduleBlocks, "test", 3)
 	assert.Nil(t, err)
 	assert.Equal(t, model.Match{Type: "test", DisplayName: "1", Time: time.Unix(0, 0).UTC(), Red1: 115, Red2: 111,
 		Red3: 108, Blue1: 109, Blue2: 116, Blue3: 117}, matches[0])
@@ -78,7 +78,7 @@ func TestScheduleTeams(t *testing.T) {
 
 	// Check with excess room for matches in the schedule.
 	scheduleBlocks = []model.ScheduleBlock{{0, "", time.Unix(0, 0).UTC(), 7, 60}}
-	matches, err = BuildRandomSchedule(teams, scheduleBlocks, "test")
+	matches, err = BuildRandomSchedule(teams, scheduleBlocks, "test", 3)
 	assert.Nil(t, err)
 }
 
@@ -87,7 +87,7 @@ func TestScheduleTiming(t *testing.T) {
 	scheduleBlocks := []model.ScheduleBlock{{0, "", time.Unix(100, 0).UTC(), 10, 75},
 		{0, "", time.Unix(20000, 0).UTC(), 5, 1000},
 		{0, "", time.Unix(100000, 0).UTC(), 15, 29}}
-	matches, err := BuildRandomSchedule(teams, scheduleBlocks, "test")
+	matches, err := BuildRandomSchedule(teams, scheduleBlocks, "test", 3)
 	assert.Nil(t, err)
 	assert.Equal(t, time.Unix(100, 0).UTC(), matches[0].Time)
 	assert.Equal(t, time.Unix(775, 0).UTC(), matches[9].Time)
@@ -106,7 +106,7 @@ func TestScheduleSurrogates(t *testing.T) {
 		teams[i].Id = i + 101
 	}
 	scheduleBlocks := []model.ScheduleBlock{{0, "", time.Unix(0, 0).UTC(), 64, 60}}
-	matches, _ := BuildRandomSchedule(teams, scheduleBlocks, "test")
+	matches, _ := BuildRandomSchedule(teams, scheduleBlocks, "test", 3)
 	for i, match := range matches {
 		if i == 13 || i == 14 {
 			if !match.Red1IsSurrogate || match.Red2IsSurrogate || match.Red3IsSurrogate ||
@@ -121,3 +121,85 @@ func TestScheduleSurrogates(t *testing.T) {
 		}
 	}
 }
+
+func TestScheduleOtherAllianceSizes(t *testing.T) {
+	rand.Seed(0)
+
+	numTeams := 10
+	teams := make([]model.Team, numTeams)
+	for i := 0; i < numTeams; i++ {
+		teams[i].Id = i + 101
+	}
+	scheduleBlocks := []model.ScheduleBlock{
+		{StartTime: time.Unix(0, 0).UTC(), NumMatches: 8, MatchSpacingSec: 60},
+	}
+	matches, err := BuildRandomSchedule(teams, scheduleBlocks, "test", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 8, len(matches))
+	appearances := make(map[int]int)
+	numSurrogates := 0
+	for _, match := range matches {
+		assert.Equal(t, 0, match.Red3+match.Red4+match.Blue3+match.Blue4)
+		teamIds := match.TeamIds(2)
+		for i, teamId := range teamIds {
+			assert.NotContains(t, teamIds[i+1:], teamId, "Team %d appears twice in match %s", teamId, match.DisplayName)
+			appearances[teamId]++
+		}
+		redSurrogates, blueSurrogates := match.RedSurrogates(), match.BlueSurrogates()
+		for i := range redSurrogates {
+			if redSurrogates[i] {
+				numSurrogates++
+			}
+			if blueSurrogates[i] {
+				numSurrogates++
+			}
+		}
+	}
+	assert.Equal(t, numTeams, len(appearances))
+	for teamId, count := range appearances {
+		assert.True(t, count == 3 || count == 4, "Team %d plays %d matches", teamId, count)
+	}
+	assert.Equal(t, 2, numSurrogates)
+
+	matches, err = BuildRandomSchedule(teams, scheduleBlocks, "test", 4)
+	assert.Nil(t, err)
+	for _, match := range matches {
+		assert.NotEqual(t, 0, match.Red4)
+		assert.NotEqual(t, 0, match.Blue4)
+	}
+
+	_, err = BuildRandomSchedule(teams[:3], scheduleBlocks, "test", 2)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Need at least 4 teams to schedule matches, have 3", err.Error())
+	}
+}
+
+func TestSeparateRepeatedTeams(t *testing.T) {
+	slots := []int{1, 2, 3, 4, 5, 5, 2, 1, 3, 4, 1, 2}
+	assert.True(t, separateRepeatedTeams(slots, 4))
+	for match := 0; match < 3; match++ {
+		matchSlots := slots[match*4 : (match+1)*4]
+		for i, team := range matchSlots {
+			assert.NotContains(t, matchSlots[i+1:], team)
+		}
+	}
+
+	// Check that a repeat is reported when there are too few teams for any swap to fix it.
+	assert.False(t, separateRepeatedTeams([]int{1, 1, 1, 2}, 2))
+	assert.False(t, separateRepeatedTeams([]int{1, 2, 1, 1, 2, 1}, 3))
+}
+
+func TestScheduleAssignFields(t *testing.T) {
+	matches := make([]model.Match, 5)
+	AssignFields(matches, []model.Field{{Id: 1}})
+	for _, match := range matches {
+		assert.Equal(t, 0, match.FieldId)
+	}
+
+	AssignFields(matches, []model.Field{{Id: 3}, {Id: 5}})
+	assert.Equal(t, 3, matches[0].FieldId)
+	assert.Equal(t, 5, matches[1].FieldId)
+	assert.Equal(t, 3, matches[2].FieldId)
+	assert.Equal(t, 5, matches[3].FieldId)
+	assert.Equal(t, 3, matches[4].FieldId)
+}
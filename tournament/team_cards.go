@@ -0,0 +1,124 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Functions for tracking the yellow and red cards issued to teams across matches.
+
+package tournament
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"github.com/FRCTeam1987/crimson-arena/model"
+)
+
+// Returns a copy of the given match result, sharing its scores, with any yellow card issued to a team already carrying
+// a yellow card from an earlier match of the same type escalated into a red card, as per FRC rules. The given match
+// result is left as issued.
+func ApplyYellowCardCarryover(
+	database *model.Database, match *model.Match, matchResult *model.MatchResult,
+) (*model.MatchResult, error) {
+	carriedYellowCards, err := getCarriedYellowCards(database, match)
+	if err != nil {
+		return nil, err
+	}
+	return matchResult.WithCardCarryover(carriedYellowCards), nil
+}
+
+// Updates the yellow card status of every team based on the cards issued in the completed matches of the given type.
+func CalculateTeamCards(database *model.Database, matchType string) error {
+	yellowCards, err := getCarriedYellowCards(database, &model.Match{Type: matchType})
+	if err != nil {
+		return err
+	}
+
+	teams, err := database.GetAllTeams()
+	if err != nil {
+		return err
+	}
+	for _, team := range teams {
+		if team.YellowCard != yellowCards[team.Id] {
+			team.YellowCard = yellowCards[team.Id]
+			if err = database.UpdateTeam(&team); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Redetermines the DQ of each completed playoff match following the given one, since changing the cards of a match can
+// turn a yellow card issued in a later match into a red card or back. Each later match whose DQ changes is saved with
+// its status redetermined using the given tiebreakers.
+func UpdateLaterPlayoffDqs(
+	database *model.Database, match *model.Match, tiebreakers []game.TiebreakerCriterion,
+) error {
+	matches, err := database.GetMatchesByType("elimination")
+	if err != nil {
+		return err
+	}
+
+	yellowCards := make(map[int]bool)
+	isLaterMatch := false
+	for _, laterMatch := range matches {
+		if !laterMatch.IsComplete() {
+			continue
+		}
+		matchResult, err := database.GetMatchResultForMatch(laterMatch.Id)
+		if err != nil {
+			return err
+		}
+		if matchResult == nil {
+			continue
+		}
+
+		if isLaterMatch {
+			redDq, blueDq := matchResult.RedScore.PlayoffDq, matchResult.BlueScore.PlayoffDq
+			matchResult.WithCardCarryover(yellowCards).CorrectPlayoffScore()
+			if matchResult.RedScore.PlayoffDq != redDq || matchResult.BlueScore.PlayoffDq != blueDq {
+				if err = database.UpdateMatchResult(matchResult); err != nil {
+					return err
+				}
+				laterMatch.Status, laterMatch.TiebreakReason = game.DeterminePlayoffMatchStatus(
+					matchResult.RedScore,
+					matchResult.BlueScore,
+					matchResult.RedScoreSummary(),
+					matchResult.BlueScoreSummary(),
+					tiebreakers,
+				)
+				if err = database.UpdateMatch(&laterMatch); err != nil {
+					return err
+				}
+			}
+		}
+		matchResult.AddCarriedYellowCards(yellowCards)
+		if laterMatch.Id == match.Id {
+			isLaterMatch = true
+		}
+	}
+	return nil
+}
+
+// Returns the set of teams carrying a yellow card into the given match, based on the completed matches of the same
+// type that precede it. A team that has received either a yellow or a red card carries a yellow card thereafter.
+func getCarriedYellowCards(database *model.Database, match *model.Match) (map[int]bool, error) {
+	matches, err := database.GetMatchesByType(match.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	yellowCards := make(map[int]bool)
+	for _, previousMatch := range matches {
+		if previousMatch.Id == match.Id {
+			break
+		}
+		if !previousMatch.IsComplete() {
+			continue
+		}
+		matchResult, err := database.GetMatchResultForMatch(previousMatch.Id)
+		if err != nil {
+			return nil, err
+		}
+		if matchResult != nil {
+			matchResult.AddCarriedYellowCards(yellowCards)
+		}
+	}
+	return yellowCards, nil
+}
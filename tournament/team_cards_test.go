@@ -0,0 +1,137 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package tournament
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestYellowCardCarryover(t *testing.T) {
+	database := setupTestDb(t)
+	for teamId := 1; teamId <= 6; teamId++ {
+		database.CreateTeam(&model.Team{Id: teamId})
+	}
+
+	match1 := model.Match{Type: "qualification", DisplayName: "1", Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5,
+		Blue3: 6, Status: game.RedWonMatch}
+	database.CreateMatch(&match1)
+	matchResult1 := model.BuildTestMatchResult(match1.Id, 1)
+	matchResult1.RedCards = map[string]string{"1": "yellow"}
+	matchResult1.BlueCards = map[string]string{"4": "red"}
+	carriedMatchResult, err := ApplyYellowCardCarryover(database, &match1, matchResult1)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"1": "yellow"}, carriedMatchResult.RedCards)
+	database.CreateMatchResult(matchResult1)
+	assert.Nil(t, CalculateTeamCards(database, "qualification"))
+	assertTeamYellowCards(t, database, map[int]bool{1: true, 4: true})
+
+	// A second yellow card should be escalated to a red card, for both a yellow and a red carried over.
+	match2 := model.Match{Type: "qualification", DisplayName: "2", Red1: 4, Red2: 5, Red3: 6, Blue1: 1, Blue2: 2,
+		Blue3: 3, Status: game.BlueWonMatch}
+	database.CreateMatch(&match2)
+	matchResult2 := model.BuildTestMatchResult(match2.Id, 1)
+	matchResult2.RedCards = map[string]string{"4": "yellow", "5": "yellow"}
+	matchResult2.BlueCards = map[string]string{"1": "yellow"}
+	carriedMatchResult, err = ApplyYellowCardCarryover(database, &match2, matchResult2)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"4": "red", "5": "yellow"}, carriedMatchResult.RedCards)
+	assert.Equal(t, map[string]string{"1": "red"}, carriedMatchResult.BlueCards)
+	assert.Same(t, matchResult2.RedScore, carriedMatchResult.RedScore)
+	database.CreateMatchResult(matchResult2)
+	assert.Nil(t, CalculateTeamCards(database, "qualification"))
+	assertTeamYellowCards(t, database, map[int]bool{1: true, 4: true, 5: true})
+
+	// The cards are saved as issued, and the carried-over red cards count in the rankings.
+	matchResult2, _ = database.GetMatchResultForMatch(match2.Id)
+	assert.Equal(t, map[string]string{"4": "yellow", "5": "yellow"}, matchResult2.RedCards)
+	rankings, err := CalculateRankings(database, false)
+	assert.Nil(t, err)
+	assertDisqualifications(t, rankings, map[int]int{1: 1, 4: 2})
+
+	// Re-editing the first match shouldn't take the cards from later matches into account.
+	matchResult1.RedCards = map[string]string{"1": "yellow", "5": "yellow"}
+	carriedMatchResult, err = ApplyYellowCardCarryover(database, &match1, matchResult1)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"1": "yellow", "5": "yellow"}, carriedMatchResult.RedCards)
+
+	// Removing a card from the first match should undo its carry-over into the second.
+	matchResult1.RedCards = map[string]string{}
+	matchResult1.BlueCards = map[string]string{}
+	assert.Nil(t, database.UpdateMatchResult(matchResult1))
+	rankings, err = CalculateRankings(database, false)
+	assert.Nil(t, err)
+	assertDisqualifications(t, rankings, map[int]int{})
+	assert.Nil(t, CalculateTeamCards(database, "qualification"))
+	assertTeamYellowCards(t, database, map[int]bool{1: true, 4: true, 5: true})
+
+	// Cards shouldn't carry over between match types.
+	assert.Nil(t, CalculateTeamCards(database, "elimination"))
+	assertTeamYellowCards(t, database, map[int]bool{})
+}
+
+func TestUpdateLaterPlayoffDqs(t *testing.T) {
+	database := setupTestDb(t)
+
+	match1 := model.Match{Type: "elimination", DisplayName: "SF1-1", Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5,
+		Blue3: 6, Status: game.RedWonMatch}
+	database.CreateMatch(&match1)
+	matchResult1 := model.BuildTestMatchResult(match1.Id, 1)
+	matchResult1.MatchType = "elimination"
+	matchResult1.RedCards = map[string]string{}
+	database.CreateMatchResult(matchResult1)
+	match2 := model.Match{Type: "elimination", DisplayName: "SF1-2", Red1: 4, Red2: 5, Red3: 6, Blue1: 1, Blue2: 2,
+		Blue3: 3}
+	database.CreateMatch(&match2)
+	matchResult2 := model.BuildTestMatchResult(match2.Id, 1)
+	matchResult2.MatchType = "elimination"
+	matchResult2.RedCards = map[string]string{}
+	matchResult2.BlueCards = map[string]string{"1": "yellow"}
+	matchResult2.RedScore, matchResult2.BlueScore = game.TestScore2(), game.TestScore1()
+	database.CreateMatchResult(matchResult2)
+	match2.Status, _ = game.DeterminePlayoffMatchStatus(
+		matchResult2.RedScore,
+		matchResult2.BlueScore,
+		matchResult2.RedScoreSummary(),
+		matchResult2.BlueScoreSummary(),
+		nil,
+	)
+	database.UpdateMatch(&match2)
+	assert.Equal(t, game.BlueWonMatch, match2.Status)
+
+	// A yellow card issued in the first match should turn the one in the second into a red card and DQ the alliance.
+	matchResult1.RedCards = map[string]string{"1": "yellow"}
+	database.UpdateMatchResult(matchResult1)
+	assert.Nil(t, UpdateLaterPlayoffDqs(database, &match1, nil))
+	matchResult2, _ = database.GetMatchResultForMatch(match2.Id)
+	assert.False(t, matchResult2.RedScore.PlayoffDq)
+	assert.True(t, matchResult2.BlueScore.PlayoffDq)
+	assert.Equal(t, map[string]string{"1": "yellow"}, matchResult2.BlueCards)
+	match, _ := database.GetMatchById(match2.Id)
+	assert.Equal(t, game.RedWonMatch, match.Status)
+
+	// Taking the card away again should undo the DQ.
+	matchResult1.RedCards = map[string]string{}
+	database.UpdateMatchResult(matchResult1)
+	assert.Nil(t, UpdateLaterPlayoffDqs(database, &match1, nil))
+	matchResult2, _ = database.GetMatchResultForMatch(match2.Id)
+	assert.False(t, matchResult2.BlueScore.PlayoffDq)
+	match, _ = database.GetMatchById(match2.Id)
+	assert.Equal(t, game.BlueWonMatch, match.Status)
+}
+
+func assertDisqualifications(t *testing.T, rankings game.Rankings, expectedDisqualifications map[int]int) {
+	for _, ranking := range rankings {
+		assert.Equal(t, expectedDisqualifications[ranking.TeamId], ranking.Disqualifications, "Team %d", ranking.TeamId)
+	}
+}
+
+func assertTeamYellowCards(t *testing.T, database *model.Database, expectedYellowCards map[int]bool) {
+	teams, err := database.GetAllTeams()
+	assert.Nil(t, err)
+	for _, team := range teams {
+		assert.Equal(t, expectedYellowCards[team.Id], team.YellowCard, "Team %d", team.Id)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Functions for totaling each team's individual contribution to its alliances' scores.
+
+package tournament
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"github.com/FRCTeam1987/crimson-arena/model"
+)
+
+// Totals the per-robot scoring elements achieved by each team across all completed qualification and playoff matches,
+// keyed by team ID. Teams that haven't played a match are absent from the map.
+func CalculateTeamContributions(database *model.Database) (map[int]game.TeamContribution, error) {
+	contributions := make(map[int]*game.TeamContribution)
+	for _, matchType := range []string{"qualification", "elimination"} {
+		matches, err := database.GetMatchesByType(matchType)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			if !match.IsComplete() {
+				continue
+			}
+			matchResult, err := database.GetMatchResultForMatch(match.Id)
+			if err != nil {
+				return nil, err
+			}
+			if matchResult == nil {
+				continue
+			}
+			for i, teamId := range match.RedTeamIds() {
+				addTeamContribution(contributions, teamId, matchResult.RedScore, i)
+			}
+			for i, teamId := range match.BlueTeamIds() {
+				addTeamContribution(contributions, teamId, matchResult.BlueScore, i)
+			}
+		}
+	}
+
+	teamContributions := make(map[int]game.TeamContribution, len(contributions))
+	for teamId, contribution := range contributions {
+		teamContributions[teamId] = *contribution
+	}
+	return teamContributions, nil
+}
+
+func addTeamContribution(contributions map[int]*game.TeamContribution, teamId int, score *game.Score, robotIndex int) {
+	if teamId == 0 {
+		return
+	}
+	contribution, ok := contributions[teamId]
+	if !ok {
+		contribution = &game.TeamContribution{TeamId: teamId}
+		contributions[teamId] = contribution
+	}
+	contribution.AddMatch(score, robotIndex)
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package tournament
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCalculateTeamContributions(t *testing.T) {
+	database := setupTestDb(t)
+	defer func(definition *game.GameDefinition) { game.CurrentGame = definition }(game.CurrentGame)
+	definition := *game.CurrentGame
+	definition.ScoringElements = []game.ScoringElement{
+		{Id: "leave", Name: "Leave", Period: game.AutoScoringPeriod, Points: 3, PerRobot: true},
+		{Id: "coral", Name: "Coral", Period: game.TeleopScoringPeriod, Points: 2},
+		{Id: "climb", Name: "Climb", Period: game.EndgameScoringPeriod, Points: 12, PerRobot: true},
+	}
+	game.CurrentGame = &definition
+
+	match1 := model.Match{Type: "qualification", DisplayName: "1", Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5,
+		Blue3: 6, Status: game.RedWonMatch}
+	database.CreateMatch(&match1)
+	matchResult1 := model.BuildTestMatchResult(match1.Id, 1)
+	matchResult1.RedScore = &game.Score{ElementCounts: map[string]int{"coral": 20}}
+	matchResult1.RedScore.SetRobotElementCount(0, "leave", 1)
+	matchResult1.RedScore.SetRobotElementCount(0, "climb", 1)
+	matchResult1.RedScore.SetRobotElementCount(2, "leave", 1)
+	matchResult1.BlueScore = new(game.Score)
+	matchResult1.BlueScore.SetRobotElementCount(1, "leave", 1)
+	database.CreateMatchResult(matchResult1)
+
+	match2 := model.Match{Type: "elimination", DisplayName: "F-1", Red1: 4, Red2: 5, Red3: 6, Blue1: 1, Blue2: 2,
+		Blue3: 3, Status: game.BlueWonMatch}
+	database.CreateMatch(&match2)
+	matchResult2 := model.BuildTestMatchResult(match2.Id, 1)
+	matchResult2.RedScore = new(game.Score)
+	matchResult2.BlueScore = new(game.Score)
+	matchResult2.BlueScore.SetRobotElementCount(0, "climb", 1)
+	database.CreateMatchResult(matchResult2)
+
+	// Unplayed matches shouldn't count.
+	match3 := model.Match{Type: "qualification", DisplayName: "2", Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5,
+		Blue3: 6}
+	database.CreateMatch(&match3)
+
+	contributions, err := CalculateTeamContributions(database)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(contributions))
+	assert.Equal(
+		t,
+		game.TeamContribution{TeamId: 1, MatchesPlayed: 2, ElementCounts: map[string]int{"leave": 1, "climb": 2},
+			Points: 27},
+		contributions[1],
+	)
+	assert.Equal(t, 13.5, contributions[1].AveragePoints())
+	assert.Equal(t, 3, contributions[3].Points)
+	assert.Equal(t, 3, contributions[5].Points)
+	assert.Equal(t, 0, contributions[6].Points)
+	assert.Equal(t, 2, contributions[6].MatchesPlayed)
+}
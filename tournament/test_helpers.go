@@ -6,6 +6,7 @@
 package tournament
 
 import (
+	"github.com/FRCTeam1987/crimson-arena/game"
 	"github.com/FRCTeam1987/crimson-arena/model"
 	"testing"
 )
@@ -15,7 +16,7 @@ func CreateTestAlliances(database *model.Database, allianceCount int) {
 		alliance := model.Alliance{
 			Id:      i,
 			TeamIds: []int{100*i + 1, 100*i + 2, 100*i + 3, 100*i + 4},
-			Lineup:  [3]int{100*i + 2, 100*i + 1, 100*i + 3},
+			Lineup:  [game.MaxTeamsPerAlliance]int{100*i + 2, 100*i + 1, 100*i + 3},
 		}
 		database.CreateAlliance(&alliance)
 	}
@@ -170,6 +170,10 @@ func (web *Web) allianceSelectionResetHandler(w http.ResponseWriter, r *http.Req
 		handleWebErr(w, err)
 		return
 	}
+	if err = web.updateOtherPlayoffBrackets(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
 
 	web.arena.AllianceSelectionAlliances = []model.Alliance{}
 	cachedRankedTeams = []*RankedTeam{}
@@ -208,10 +212,12 @@ func (web *Web) allianceSelectionFinalizeHandler(w http.ResponseWriter, r *http.
 	// Save alliances to the database.
 	for _, alliance := range web.arena.AllianceSelectionAlliances {
 		// Populate the initial lineup according to the tournament rules (alliance captain in the middle, first pick on
-		// the left, second pick on the right).
-		alliance.Lineup[0] = alliance.TeamIds[1]
-		alliance.Lineup[1] = alliance.TeamIds[0]
-		alliance.Lineup[2] = alliance.TeamIds[2]
+		// the left, second pick on the right), limited to the number of teams that play on each alliance.
+		lineupSize := min(web.arena.EventSettings.TeamsPerAlliance, len(alliance.TeamIds))
+		copy(alliance.Lineup[:lineupSize], alliance.TeamIds)
+		if lineupSize >= 2 {
+			alliance.Lineup[0], alliance.Lineup[1] = alliance.TeamIds[1], alliance.TeamIds[0]
+		}
 
 		err := web.arena.Database.CreateAlliance(&alliance)
 		if err != nil {
@@ -229,6 +235,10 @@ func (web *Web) allianceSelectionFinalizeHandler(w http.ResponseWriter, r *http.
 		handleWebErr(w, err)
 		return
 	}
+	if err = web.updateOtherPlayoffBrackets(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
 
 	// Back up the database.
 	err = web.arena.Database.Backup(web.arena.EventSettings.Name, "post_alliance_selection")
@@ -277,6 +287,26 @@ func (web *Web) allianceSelectionPublishHandler(w http.ResponseWriter, r *http.R
 	http.Redirect(w, r, "/alliance_selection", 303)
 }
 
+// Repopulates the ranked team list for an alliance selection that was restored after a restart, marking the teams
+// that have already been picked.
+func (web *Web) restoreRankedTeams() error {
+	rankings, err := web.arena.Database.GetAllRankings()
+	if err != nil {
+		return err
+	}
+	pickedTeamIds := make(map[int]bool)
+	for _, alliance := range web.arena.AllianceSelectionAlliances {
+		for _, teamId := range alliance.TeamIds {
+			pickedTeamIds[teamId] = true
+		}
+	}
+	cachedRankedTeams = make([]*RankedTeam, len(rankings))
+	for i, ranking := range rankings {
+		cachedRankedTeams[i] = &RankedTeam{i + 1, ranking.TeamId, pickedTeamIds[ranking.TeamId]}
+	}
+	return nil
+}
+
 func (web *Web) renderAllianceSelection(w http.ResponseWriter, r *http.Request, errorMessage string) {
 	if len(web.arena.AllianceSelectionAlliances) == 0 {
 		// The application may have been restarted since the alliance selection was conducted; try reloading the
@@ -8,6 +8,7 @@ package web
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/FRCTeam1987/crimson-arena/field"
 	"github.com/FRCTeam1987/crimson-arena/game"
 	"github.com/FRCTeam1987/crimson-arena/model"
 	"github.com/FRCTeam1987/crimson-arena/partner"
@@ -235,6 +236,32 @@ func (web *Web) alliancesApiHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Returns the recent connection history of the driver station in each alliance station, along with the alerts raised
+// for the current match.
+func (web *Web) driverStationHistoryApiHandler(w http.ResponseWriter, r *http.Request) {
+	data := struct {
+		MatchId  int
+		Stations map[string][]field.DriverStationSample
+		Alerts   []field.DriverStationAlert
+	}{web.arena.CurrentMatch.Id, make(map[string][]field.DriverStationSample), web.arena.DsAlerts}
+	for _, station := range model.AllianceStationNames(web.arena.TeamsPerAlliance) {
+		data.Stations[station] = web.arena.GetDsHistory(station)
+	}
+
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(jsonData)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
 // Websocket API for receiving arena status updates.
 func (web *Web) arenaWebsocketApiHandler(w http.ResponseWriter, r *http.Request) {
 	ws, err := websocket.NewWebsocket(w, r)
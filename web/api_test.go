@@ -5,6 +5,7 @@ package web
 
 import (
 	"encoding/json"
+	"github.com/FRCTeam1987/crimson-arena/field"
 	"github.com/FRCTeam1987/crimson-arena/game"
 	"github.com/FRCTeam1987/crimson-arena/model"
 	"github.com/FRCTeam1987/crimson-arena/tournament"
@@ -129,6 +130,29 @@ func TestAlliancesApi(t *testing.T) {
 	}
 }
 
+func TestDriverStationHistoryApi(t *testing.T) {
+	web := setupTestWeb(t)
+	assert.Nil(t, web.arena.SubstituteTeam(254, "B1"))
+	web.arena.AllianceStations["B1"].DsConn = &field.DriverStationConnection{TeamId: 254}
+	web.arena.Update()
+
+	recorder := web.getHttpResponse("/api/driver_stations/history")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header()["Content-Type"][0])
+	var data struct {
+		Stations map[string][]field.DriverStationSample
+		Alerts   []field.DriverStationAlert
+	}
+	err := json.Unmarshal([]byte(recorder.Body.String()), &data)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(data.Stations))
+	assert.Empty(t, data.Stations["R1"])
+	if assert.Equal(t, 1, len(data.Stations["B1"])) {
+		assert.Equal(t, 254, data.Stations["B1"][0].TeamId)
+	}
+	assert.Empty(t, data.Alerts)
+}
+
 func TestArenaWebsocketApi(t *testing.T) {
 	web := setupTestWeb(t)
 
@@ -34,6 +34,7 @@ func TestFieldMonitorDisplayWebsocket(t *testing.T) {
 	// Should get a few status updates right after connection.
 	readWebsocketType(t, ws, "displayConfiguration")
 	readWebsocketType(t, ws, "arenaStatus")
+	readWebsocketType(t, ws, "dsAlerts")
 	readWebsocketType(t, ws, "eventStatus")
 
 	// Should not be able to update team notes.
@@ -58,6 +59,7 @@ func TestFieldMonitorFtaDisplayWebsocket(t *testing.T) {
 	// Should get a few status updates right after connection.
 	readWebsocketType(t, ws, "displayConfiguration")
 	readWebsocketType(t, ws, "arenaStatus")
+	readWebsocketType(t, ws, "dsAlerts")
 	readWebsocketType(t, ws, "eventStatus")
 
 	// Should not be able to update team notes.
@@ -18,8 +18,8 @@ import (
 	"io"
 	"log"
 	"net/http"
-	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -29,6 +29,8 @@ type MatchPlayListItem struct {
 	Time        string
 	Status      game.MatchStatus
 	ColorClass  string
+	// Reason for the replay if this entry is a queued replay of a match that has already been played.
+	ReplayReason string
 }
 
 type MatchPlayList []MatchPlayListItem
@@ -79,19 +81,22 @@ func (web *Web) matchPlayHandler(w http.ResponseWriter, r *http.Request) {
 	isReplay := matchResult != nil
 	data := struct {
 		*model.EventSettings
-		PlcIsEnabled          bool
-		MatchesByType         map[string]MatchPlayList
-		CurrentMatchType      string
-		Match                 *model.Match
-		RedOffFieldTeams      []int
-		BlueOffFieldTeams     []int
-		RedScore              *game.Score
-		BlueScore             *game.Score
-		AllowSubstitution     bool
-		IsReplay              bool
-		SavedMatchType        string
-		SavedMatch            *model.Match
-		PlcArmorBlockStatuses map[string]bool
+		PlcIsEnabled            bool
+		MatchesByType           map[string]MatchPlayList
+		CurrentMatchType        string
+		Match                   *model.Match
+		RedOffFieldTeams        []int
+		BlueOffFieldTeams       []int
+		RedScore                *game.Score
+		BlueScore               *game.Score
+		GameDefinition          *game.GameDefinition
+		AllowSubstitution       bool
+		IsReplay                bool
+		SavedMatchType          string
+		SavedMatch              *model.Match
+		PlcArmorBlockStatuses   map[string]bool
+		RecoveredJournal        *model.ArenaJournal
+		RecoveredJournalSummary []string
 	}{
 		web.arena.EventSettings,
 		web.arena.Plc.IsEnabled(),
@@ -102,11 +107,14 @@ func (web *Web) matchPlayHandler(w http.ResponseWriter, r *http.Request) {
 		blueOffFieldTeams,
 		web.arena.RedScore,
 		web.arena.BlueScore,
+		game.CurrentGame,
 		web.arena.CurrentMatch.ShouldAllowSubstitution(),
 		isReplay,
 		web.arena.SavedMatch.CapitalizedType(),
 		web.arena.SavedMatch,
 		web.arena.Plc.GetArmorBlockStatuses(),
+		web.arena.RecoveredJournal,
+		web.arena.RecoveredJournalSummary(),
 	}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
@@ -155,51 +163,68 @@ func (web *Web) matchPlayShowResultHandler(w http.ResponseWriter, r *http.Reques
 
 	vars := mux.Vars(r)
 	matchId, _ := strconv.Atoi(vars["matchId"])
-	match, err := web.arena.Database.GetMatchById(matchId)
-	if err != nil {
+	if err := web.showMatchResult(matchId); err != nil {
 		handleWebErr(w, err)
 		return
 	}
-	if match == nil {
-		handleWebErr(w, fmt.Errorf("Invalid match ID %d.", matchId))
+
+	http.Redirect(w, r, "/match_play", 303)
+}
+
+// Clears the match results display buffer.
+func (web *Web) matchPlayClearResultHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
 		return
 	}
-	matchResult, err := web.arena.Database.GetMatchResultForMatch(match.Id)
-	if err != nil {
-		handleWebErr(w, err)
+
+	// Load an empty match to effectively clear the buffer.
+	web.arena.SavedMatch = &model.Match{}
+	web.arena.SavedMatchResult = model.NewMatchResult()
+	web.arena.ScorePostedNotifier.Notify()
+
+	http.Redirect(w, r, "/match_play", 303)
+}
+
+// Restores the arena state that was journaled before the last unexpected shutdown.
+func (web *Web) matchPlayRecoveryRestoreHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
 		return
 	}
-	if matchResult == nil {
-		handleWebErr(w, fmt.Errorf("No result found for match ID %d.", matchId))
+
+	journal := web.arena.RecoveredJournal
+	if journal == nil {
+		handleWebErr(w, fmt.Errorf("There is no recovered arena state to restore."))
 		return
 	}
-	if match.ShouldUpdateRankings() {
-		web.arena.SavedRankings, err = web.arena.Database.GetAllRankings()
-		if err != nil {
+	hadAllianceSelection := len(web.arena.AllianceSelectionAlliances) == 0 &&
+		len(journal.AllianceSelectionAlliances) > 0
+	if err := web.arena.RestoreRecoveredJournal(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if journal.SavedMatchId > 0 {
+		if err := web.showMatchResult(journal.SavedMatchId); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+	if hadAllianceSelection && len(web.arena.AllianceSelectionAlliances) > 0 {
+		if err := web.restoreRankedTeams(); err != nil {
 			handleWebErr(w, err)
 			return
 		}
-	} else {
-		web.arena.SavedRankings = game.Rankings{}
 	}
-	web.arena.SavedMatch = match
-	web.arena.SavedMatchResult = matchResult
-	web.arena.ScorePostedNotifier.Notify()
 
 	http.Redirect(w, r, "/match_play", 303)
 }
 
-// Clears the match results display buffer.
-func (web *Web) matchPlayClearResultHandler(w http.ResponseWriter, r *http.Request) {
+// Drops the arena state that was journaled before the last unexpected shutdown.
+func (web *Web) matchPlayRecoveryDiscardHandler(w http.ResponseWriter, r *http.Request) {
 	if !web.userIsAdmin(w, r) {
 		return
 	}
 
-	// Load an empty match to effectively clear the buffer.
-	web.arena.SavedMatch = &model.Match{}
-	web.arena.SavedMatchResult = model.NewMatchResult()
-	web.arena.ScorePostedNotifier.Notify()
-
+	web.arena.DiscardRecoveredJournal()
 	http.Redirect(w, r, "/match_play", 303)
 }
 
@@ -219,10 +244,21 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 	// Subscribe the websocket to the notifiers whose messages will be passed on to the client, in a separate goroutine.
 	go ws.HandleNotifiers(web.arena.MatchTimingNotifier, web.arena.ArenaStatusNotifier, web.arena.MatchTimeNotifier,
 		web.arena.RealtimeScoreNotifier, web.arena.AudienceDisplayModeNotifier,
-		web.arena.AllianceStationDisplayModeNotifier, web.arena.EventStatusNotifier, web.arena.FieldLightsNotifier)
+		web.arena.AllianceStationDisplayModeNotifier, web.arena.EventStatusNotifier, web.arena.FieldLightsNotifier,
+		web.arena.ScoringStatusNotifier, web.arena.HeadRefStatusNotifier)
+
+	// Record each command in the audit trail once it has been handled, including when the connection drops
+	// immediately afterwards.
+	session := web.getUserSessionFromCookie(r)
+	var action *model.OperatorAction
+	defer func() { web.finishOperatorAction(action) }()
+	writeError := func(message string) {
+		action.Error = message
+		ws.WriteError(message)
+	}
 
 	// Loop, waiting for commands and responding to them, until the client closes the connection.
-	for {
+	for ; ; action = web.finishOperatorAction(action) {
 		messageType, data, err := ws.Read()
 		if err != nil {
 			if err == io.EOF {
@@ -232,6 +268,7 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 			log.Println(err)
 			return
 		}
+		action = web.startOperatorAction(session, r.RemoteAddr, messageType, data)
 
 		switch messageType {
 		case "substituteTeam":
@@ -241,22 +278,22 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 			}{}
 			err = mapstructure.Decode(data, &args)
 			if err != nil {
-				ws.WriteError(err.Error())
+				writeError(err.Error())
 				continue
 			}
 			err = web.arena.SubstituteTeam(args.Team, args.Position)
 			if err != nil {
-				ws.WriteError(err.Error())
+				writeError(err.Error())
 				continue
 			}
 		case "toggleBypass":
 			station, ok := data.(string)
 			if !ok {
-				ws.WriteError(fmt.Sprintf("Failed to parse '%s' message.", messageType))
+				writeError(fmt.Sprintf("Failed to parse '%s' message.", messageType))
 				continue
 			}
 			if _, ok := web.arena.AllianceStations[station]; !ok {
-				ws.WriteError(fmt.Sprintf("Invalid alliance station '%s'.", station))
+				writeError(fmt.Sprintf("Invalid alliance station '%s'.", station))
 				continue
 			}
 			if web.arena.MatchState == field.AutoPeriod ||
@@ -272,19 +309,56 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 			}{}
 			err = mapstructure.Decode(data, &args)
 			if err != nil {
-				ws.WriteError(err.Error())
+				writeError(err.Error())
 				continue
 			}
 			web.arena.MuteMatchSounds = args.MuteMatchSounds
 			err = web.arena.StartMatch()
 			if err != nil {
-				ws.WriteError(err.Error())
+				writeError(err.Error())
+				continue
+			}
+		case "overrideReadinessRule":
+			args := struct {
+				RuleId       string
+				Reason       string
+				OverriddenBy string
+			}{}
+			err = mapstructure.Decode(data, &args)
+			if err != nil {
+				writeError(err.Error())
+				continue
+			}
+			// Fall back to the logged-in user, who is absent when authentication is disabled.
+			if strings.TrimSpace(args.OverriddenBy) == "" && session != nil {
+				args.OverriddenBy = session.Username
+			}
+			err = web.arena.OverrideReadinessRule(args.RuleId, args.Reason, args.OverriddenBy)
+			if err != nil {
+				writeError(err.Error())
 				continue
 			}
 		case "abortMatch":
 			err = web.arena.AbortMatch()
 			if err != nil {
-				ws.WriteError(err.Error())
+				writeError(err.Error())
+				continue
+			}
+		case "fieldFault":
+			reason, ok := data.(string)
+			if !ok && data != nil {
+				writeError(fmt.Sprintf("Failed to parse '%s' message.", messageType))
+				continue
+			}
+			err = web.arena.StartFieldFault(reason)
+			if err != nil {
+				writeError(err.Error())
+				continue
+			}
+		case "resumeMatch":
+			err = web.arena.ResumeMatch()
+			if err != nil {
+				writeError(err.Error())
 				continue
 			}
 		case "signalVolunteers":
@@ -304,19 +378,27 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 			web.arena.AllianceStationDisplayModeNotifier.Notify()
 			continue // Don't reload.
 		case "commitResults":
+			if !web.arena.ScoringPanelRegistry.AllScoresCommitted() {
+				writeError("Cannot commit results: not all scoring panels have committed their scores.")
+				continue
+			}
+			if err = web.arena.CheckCanCommitResult(); err != nil {
+				writeError(err.Error())
+				continue
+			}
 			err = web.commitCurrentMatchScore()
 			if err != nil {
-				ws.WriteError(err.Error())
+				writeError(err.Error())
 				continue
 			}
 			err = web.arena.ResetMatch()
 			if err != nil {
-				ws.WriteError(err.Error())
+				writeError(err.Error())
 				continue
 			}
 			err = web.arena.LoadNextMatch()
 			if err != nil {
-				ws.WriteError(err.Error())
+				writeError(err.Error())
 				continue
 			}
 			err = ws.WriteNotifier(web.arena.ReloadDisplaysNotifier)
@@ -328,12 +410,12 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 		case "discardResults":
 			err = web.arena.ResetMatch()
 			if err != nil {
-				ws.WriteError(err.Error())
+				writeError(err.Error())
 				continue
 			}
 			err = web.arena.LoadNextMatch()
 			if err != nil {
-				ws.WriteError(err.Error())
+				writeError(err.Error())
 				continue
 			}
 			err = ws.WriteNotifier(web.arena.ReloadDisplaysNotifier)
@@ -345,7 +427,7 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 		case "setAudienceDisplay":
 			mode, ok := data.(string)
 			if !ok {
-				ws.WriteError(fmt.Sprintf("Failed to parse '%s' message.", messageType))
+				writeError(fmt.Sprintf("Failed to parse '%s' message.", messageType))
 				continue
 			}
 			web.arena.SetAudienceDisplayMode(mode)
@@ -353,7 +435,7 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 		case "setAllianceStationDisplay":
 			mode, ok := data.(string)
 			if !ok {
-				ws.WriteError(fmt.Sprintf("Failed to parse '%s' message.", messageType))
+				writeError(fmt.Sprintf("Failed to parse '%s' message.", messageType))
 				continue
 			}
 			web.arena.SetAllianceStationDisplayMode(mode)
@@ -361,7 +443,7 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 		case "setFieldLights":
 			color, ok := data.(string)
 			if !ok {
-				ws.WriteError(fmt.Sprintf("Failed to parse '%s' message.", messageType))
+				writeError(fmt.Sprintf("Failed to parse '%s' message.", messageType))
 				continue
 			}
 			switch color {
@@ -378,12 +460,12 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 		case "startTimeout":
 			durationSec, ok := data.(float64)
 			if !ok {
-				ws.WriteError(fmt.Sprintf("Failed to parse '%s' message.", messageType))
+				writeError(fmt.Sprintf("Failed to parse '%s' message.", messageType))
 				continue
 			}
 			err = web.arena.StartTimeout(int(durationSec))
 			if err != nil {
-				ws.WriteError(err.Error())
+				writeError(err.Error())
 				continue
 			}
 		case "setTestMatchName":
@@ -393,23 +475,43 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 			}
 			name, ok := data.(string)
 			if !ok {
-				ws.WriteError(fmt.Sprintf("Failed to parse '%s' message.", messageType))
+				writeError(fmt.Sprintf("Failed to parse '%s' message.", messageType))
 				continue
 			}
 			web.arena.CurrentMatch.DisplayName = name
 			web.arena.MatchLoadNotifier.Notify()
 			continue
 		case "updateRealtimeScore":
-			args := data.(map[string]interface{})
-			web.arena.BlueScore.AutoPoints = int(args["blueAuto"].(float64))
-			web.arena.RedScore.AutoPoints = int(args["redAuto"].(float64))
-			web.arena.BlueScore.TeleopPoints = int(args["blueTeleop"].(float64))
-			web.arena.RedScore.TeleopPoints = int(args["redTeleop"].(float64))
-			web.arena.BlueScore.EndgamePoints = int(args["blueEndgame"].(float64))
-			web.arena.RedScore.EndgamePoints = int(args["redEndgame"].(float64))
+			args := struct {
+				Red  map[string]int
+				Blue map[string]int
+			}{}
+			err = mapstructure.Decode(data, &args)
+			if err != nil {
+				writeError(err.Error())
+				continue
+			}
+			previousRedScore, previousBlueScore := web.arena.RedScore.Copy(), web.arena.BlueScore.Copy()
+			for id, count := range args.Red {
+				if !isPerRobotElement(id) {
+					web.arena.RedScore.SetElementCount(id, count)
+				}
+			}
+			for id, count := range args.Blue {
+				if !isPerRobotElement(id) {
+					web.arena.BlueScore.SetElementCount(id, count)
+				}
+			}
+			err = web.arena.RecordScoreChange(
+				model.UserScoreEventSource, "match play", previousRedScore, previousBlueScore,
+			)
+			if err != nil {
+				writeError(err.Error())
+			}
 			web.arena.RealtimeScoreNotifier.Notify()
+			web.arena.NotifyPendingResultEdited()
 		default:
-			ws.WriteError(fmt.Sprintf("Invalid message type '%s'.", messageType))
+			writeError(fmt.Sprintf("Invalid message type '%s'.", messageType))
 			continue
 		}
 
@@ -426,7 +528,27 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchResult, isMatchReviewEdit bool) error {
 	var updatedRankings game.Rankings
 
+	// The result with any second yellow cards converted into red cards, which is used for the playoff DQ and shown on
+	// the audience display while the result is saved with the cards as issued.
+	carriedMatchResult := matchResult
 	if match.Type != "test" {
+		if match.ShouldUpdateCards() {
+			var err error
+			carriedMatchResult, err = tournament.ApplyYellowCardCarryover(web.arena.Database, match, matchResult)
+			if err != nil {
+				return err
+			}
+		}
+		if match.Type == "elimination" {
+			// This also sets the DQ on the result being saved, since the two share their scores.
+			carriedMatchResult.CorrectPlayoffScore()
+		}
+
+		matchResult.ScoreTimeline = game.FinalizeScoreTimeline(
+			matchResult.ScoreTimeline, matchResult.RedScoreSummary(), matchResult.BlueScoreSummary(),
+		)
+		matchResult.CommittedAt = time.Now()
+
 		if matchResult.PlayNumber == 0 {
 			// Determine the play number for this new match result.
 			prevMatchResult, err := web.arena.Database.GetMatchResultForMatch(match.Id)
@@ -444,6 +566,10 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 			if err != nil {
 				return err
 			}
+			if match.Id == web.arena.CurrentMatch.Id {
+				// Any further score changes while the match is still loaded belong to the play after this one.
+				web.arena.PendingPlayNumber = matchResult.PlayNumber + 1
+			}
 		} else {
 			// We are updating a match result record that already exists.
 			err := web.arena.Database.UpdateMatchResult(matchResult)
@@ -452,16 +578,46 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 			}
 		}
 
+		if !isMatchReviewEdit {
+			if err := web.arena.Database.CompletePendingMatchReplay(match.Id, matchResult.PlayNumber); err != nil {
+				return err
+			}
+		}
+
 		// Update and save the match record to the database.
 		match.ScoreCommittedAt = time.Now()
 		redScoreSummary := matchResult.RedScoreSummary()
 		blueScoreSummary := matchResult.BlueScoreSummary()
-		match.Status = game.DetermineMatchStatus(redScoreSummary, blueScoreSummary)
-		err := web.arena.Database.UpdateMatch(match)
+		playoffTiebreakers, err := game.ParsePlayoffTiebreakers(
+			web.arena.EventSettings.PlayoffTiebreakers, game.CurrentGame,
+		)
+		if err != nil {
+			return err
+		}
+		if match.Type == "elimination" {
+			match.Status, match.TiebreakReason = game.DeterminePlayoffMatchStatus(
+				matchResult.RedScore, matchResult.BlueScore, redScoreSummary, blueScoreSummary, playoffTiebreakers,
+			)
+		} else {
+			match.Status = game.DetermineMatchStatus(redScoreSummary, blueScoreSummary)
+		}
+		err = web.arena.Database.UpdateMatch(match)
 		if err != nil {
 			return err
 		}
 
+		if match.ShouldUpdateCards() {
+			if err = tournament.CalculateTeamCards(web.arena.Database, match.Type); err != nil {
+				return err
+			}
+		}
+		if match.Type == "elimination" {
+			// The cards of this match carry over into the later playoff matches, whose DQs may change as a result.
+			if err = tournament.UpdateLaterPlayoffDqs(web.arena.Database, match, playoffTiebreakers); err != nil {
+				return err
+			}
+		}
+
 		if match.ShouldUpdateRankings() {
 			// Recalculate all the rankings.
 			rankings, err := tournament.CalculateRankings(web.arena.Database, isMatchReviewEdit)
@@ -473,12 +629,12 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 
 		if match.ShouldUpdateEliminationMatches() {
 			if err = web.arena.Database.UpdateAllianceFromMatch(
-				match.ElimRedAlliance, [3]int{match.Red1, match.Red2, match.Red3},
+				match.ElimRedAlliance, match.RedTeamIds(),
 			); err != nil {
 				return err
 			}
 			if err = web.arena.Database.UpdateAllianceFromMatch(
-				match.ElimBlueAlliance, [3]int{match.Blue1, match.Blue2, match.Blue3},
+				match.ElimBlueAlliance, match.BlueTeamIds(),
 			); err != nil {
 				return err
 			}
@@ -488,6 +644,9 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 			if err = web.arena.UpdatePlayoffBracket(&nextMatchTime); err != nil {
 				return err
 			}
+			if err = web.updateOtherPlayoffBrackets(); err != nil {
+				return err
+			}
 
 			// Generate awards if the tournament is over.
 			if web.arena.PlayoffBracket.IsComplete() {
@@ -525,8 +684,11 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 
 	if !isMatchReviewEdit {
 		// Store the result in the buffer to be shown in the audience display.
+		if err := web.updateSavedTeamContributions(); err != nil {
+			return err
+		}
 		web.arena.SavedMatch = match
-		web.arena.SavedMatchResult = matchResult
+		web.arena.SavedMatchResult = carriedMatchResult
 		web.arena.SavedRankings = updatedRankings
 		web.arena.ScorePostedNotifier.Notify()
 	}
@@ -534,37 +696,102 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 	return nil
 }
 
-func (web *Web) getCurrentMatchResult() *model.MatchResult {
-	return &model.MatchResult{MatchId: web.arena.CurrentMatch.Id, MatchType: web.arena.CurrentMatch.Type,
-		RedScore: web.arena.RedScore, BlueScore: web.arena.BlueScore}
-}
-
-// Saves the realtime result as the final score for the match currently loaded into the arena.
-func (web *Web) commitCurrentMatchScore() error {
-	return web.commitMatchScore(web.arena.CurrentMatch, web.getCurrentMatchResult(), false)
+// Recalculates the per-team contribution totals shown alongside a posted score, if the game has any per-robot scoring
+// elements.
+func (web *Web) updateSavedTeamContributions() error {
+	if !game.CurrentGame.HasPerRobotElements() {
+		web.arena.SavedTeamContributions = nil
+		return nil
+	}
+	contributions, err := tournament.CalculateTeamContributions(web.arena.Database)
+	if err != nil {
+		return err
+	}
+	web.arena.SavedTeamContributions = contributions
+	return nil
 }
 
-// Helper function to implement the required interface for Sort.
-func (list MatchPlayList) Len() int {
-	return len(list)
+// Loads the committed results for the given match into the display buffer.
+func (web *Web) showMatchResult(matchId int) error {
+	match, err := web.arena.Database.GetMatchById(matchId)
+	if err != nil {
+		return err
+	}
+	if match == nil {
+		return fmt.Errorf("Invalid match ID %d.", matchId)
+	}
+	matchResult, err := web.arena.Database.GetMatchResultForMatch(match.Id)
+	if err != nil {
+		return err
+	}
+	if matchResult == nil {
+		return fmt.Errorf("No result found for match ID %d.", matchId)
+	}
+	if match.ShouldUpdateRankings() {
+		web.arena.SavedRankings, err = web.arena.Database.GetAllRankings()
+		if err != nil {
+			return err
+		}
+	} else {
+		web.arena.SavedRankings = game.Rankings{}
+	}
+	if err = web.updateSavedTeamContributions(); err != nil {
+		return err
+	}
+	web.arena.SavedMatch = match
+	web.arena.SavedMatchResult = matchResult
+	web.arena.ScorePostedNotifier.Notify()
+	return nil
 }
 
-// Helper function to implement the required interface for Sort.
-func (list MatchPlayList) Less(i, j int) bool {
-	return list[i].Status == game.MatchNotPlayed && list[j].Status != game.MatchNotPlayed
+func (web *Web) getCurrentMatchResult() *model.MatchResult {
+	return &model.MatchResult{MatchId: web.arena.CurrentMatch.Id, MatchType: web.arena.CurrentMatch.Type,
+		RedScore: web.arena.RedScore, BlueScore: web.arena.BlueScore, RedCards: web.arena.RedCards,
+		BlueCards: web.arena.BlueCards, ScoreTimeline: web.arena.ScoreTimeline}
 }
 
-// Helper function to implement the required interface for Sort.
-func (list MatchPlayList) Swap(i, j int) {
-	list[i], list[j] = list[j], list[i]
+// Saves the realtime result as the final score for the match currently loaded into the arena.
+func (web *Web) commitCurrentMatchScore() error {
+	matchResult := web.getCurrentMatchResult()
+	if web.arena.IsResultApproved() {
+		matchResult.ApprovedBy = web.arena.HeadRefApproval.ApprovedBy
+		matchResult.ApprovedAt = web.arena.HeadRefApproval.ApprovedAt
+	}
+	return web.commitMatchScore(web.arena.CurrentMatch, matchResult, false)
 }
 
-// Constructs the list of matches to display on the side of the match play interface.
+// Constructs the list of matches to display on the side of the match play interface. Matches waiting to be played,
+// including any queued replays, are listed first in the order in which they are to be run.
 func (web *Web) buildMatchPlayList(matchType string) (MatchPlayList, error) {
-	matches, err := web.arena.Database.GetMatchesByType(matchType)
+	matches, replays, err := web.arena.Database.GetMatchQueue(matchType)
 	if err != nil {
 		return MatchPlayList{}, err
 	}
+	allMatches, err := web.arena.Database.GetMatchesByType(matchType)
+	if err != nil {
+		return MatchPlayList{}, err
+	}
+	isQueued := make(map[int]bool, len(matches))
+	for _, match := range matches {
+		isQueued[match.Id] = true
+	}
+	for _, match := range allMatches {
+		if !isQueued[match.Id] {
+			matches = append(matches, match)
+			replays = append(replays, nil)
+		}
+	}
+
+	// Leave out matches scheduled to be played on another field.
+	var fieldMatches []model.Match
+	var fieldReplays []*model.MatchReplay
+	for i, match := range matches {
+		if match.IsOnField(web.arena.FieldId()) {
+			fieldMatches = append(fieldMatches, match)
+			fieldReplays = append(fieldReplays, replays[i])
+		}
+	}
+	matches, replays = fieldMatches, fieldReplays
 
 	matchPlayList := make(MatchPlayList, len(matches))
 	for i, match := range matches {
@@ -572,6 +799,9 @@ func (web *Web) buildMatchPlayList(matchType string) (MatchPlayList, error) {
 		matchPlayList[i].DisplayName = match.TypePrefix() + match.DisplayName
 		matchPlayList[i].Time = match.Time.Local().Format("3:04 PM")
 		matchPlayList[i].Status = match.Status
+		if replays[i] != nil {
+			matchPlayList[i].ReplayReason = replays[i].Reason.DisplayName()
+		}
 		switch match.Status {
 		case game.RedWonMatch:
 			matchPlayList[i].ColorClass = "danger"
@@ -587,8 +817,12 @@ func (web *Web) buildMatchPlayList(matchType string) (MatchPlayList, error) {
 		}
 	}
 
-	// Sort the list to put all completed matches at the bottom.
-	sort.Stable(matchPlayList)
-
 	return matchPlayList, nil
 }
+
+// Returns true if the given scoring element is counted per robot, in which case its alliance total is derived from the
+// per-robot counts and can't be set directly.
+func isPerRobotElement(id string) bool {
+	element := game.CurrentGame.GetScoringElement(id)
+	return element != nil && element.PerRobot
+}
@@ -114,6 +114,68 @@ func TestMatchPlayShowAndClearResult(t *testing.T) {
 	assert.Equal(t, *model.NewMatchResult(), *web.arena.SavedMatchResult)
 }
 
+func TestMatchPlayRecovery(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.postHttpResponse("/match_play/recovery/restore", "")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "no recovered arena state")
+
+	savedMatch := model.Match{Type: "qualification", DisplayName: "1", Status: game.RedWonMatch}
+	web.arena.Database.CreateMatch(&savedMatch)
+	web.arena.Database.CreateMatchResult(model.BuildTestMatchResult(savedMatch.Id, 1))
+	match := model.Match{Type: "qualification", DisplayName: "2"}
+	web.arena.Database.CreateMatch(&match)
+	web.arena.RecoveredJournal = &model.ArenaJournal{
+		SavedAt:      time.Now(),
+		CurrentMatch: match,
+		MatchState:   int(field.TeleopPeriod),
+		MatchTimeSec: 95,
+		RedScore:     game.TestScore1(),
+		BlueScore:    game.TestScore2(),
+		SavedMatchId: savedMatch.Id,
+	}
+	recorder = web.getHttpResponse("/match_play")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "The arena was stopped unexpectedly.")
+	assert.Contains(t, recorder.Body.String(), "Match Q2 was interrupted 95 seconds in")
+
+	recorder = web.postHttpResponse("/match_play/recovery/restore", "")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	assert.Nil(t, web.arena.RecoveredJournal)
+	assert.Equal(t, field.PostMatch, web.arena.MatchState)
+	assert.Equal(t, match.Id, web.arena.CurrentMatch.Id)
+	assert.Equal(t, game.TestScore1(), web.arena.RedScore)
+	assert.Equal(t, savedMatch.Id, web.arena.SavedMatch.Id)
+	assert.Equal(t, savedMatch.Id, web.arena.SavedMatchResult.MatchId)
+	recorder = web.getHttpResponse("/match_play")
+	assert.NotContains(t, recorder.Body.String(), "The arena was stopped unexpectedly.")
+
+	// Check that a restored alliance selection picks up where it left off.
+	assert.Nil(t, web.arena.ResetMatch())
+	web.arena.Database.CreateRanking(&game.Ranking{TeamId: 254, Rank: 1})
+	web.arena.Database.CreateRanking(&game.Ranking{TeamId: 1114, Rank: 2})
+	web.arena.RecoveredJournal = &model.ArenaJournal{
+		AllianceSelectionAlliances: []model.Alliance{{Id: 1, TeamIds: []int{254, 0, 0}}},
+	}
+	recorder = web.postHttpResponse("/match_play/recovery/restore", "")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	assert.Equal(t, 1, len(web.arena.AllianceSelectionAlliances))
+	if assert.Equal(t, 2, len(cachedRankedTeams)) {
+		assert.True(t, cachedRankedTeams[0].Picked)
+		assert.False(t, cachedRankedTeams[1].Picked)
+	}
+
+	web.arena.AllianceSelectionAlliances = []model.Alliance{}
+	web.arena.RecoveredJournal = &model.ArenaJournal{
+		AllianceSelectionAlliances: []model.Alliance{{Id: 1, TeamIds: []int{254, 0, 0}}},
+	}
+	recorder = web.postHttpResponse("/match_play/recovery/discard", "")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Nil(t, web.arena.RecoveredJournal)
+	assert.Equal(t, 0, len(web.arena.AllianceSelectionAlliances))
+}
+
 func TestMatchPlayErrors(t *testing.T) {
 	web := setupTestWeb(t)
 
@@ -139,7 +201,7 @@ func TestCommitMatch(t *testing.T) {
 	assert.Nil(t, web.arena.Database.CreateMatch(match))
 	matchResult = model.NewMatchResult()
 	matchResult.MatchId = match.Id
-	matchResult.BlueScore = &game.Score{AutoPoints: 10}
+	matchResult.BlueScore = &game.Score{ElementCounts: map[string]int{"auto": 10}}
 	err = web.commitMatchScore(match, matchResult, true)
 	assert.Nil(t, err)
 	assert.Equal(t, 1, matchResult.PlayNumber)
@@ -148,7 +210,7 @@ func TestCommitMatch(t *testing.T) {
 
 	matchResult = model.NewMatchResult()
 	matchResult.MatchId = match.Id
-	matchResult.RedScore = &game.Score{AutoPoints: 20}
+	matchResult.RedScore = &game.Score{ElementCounts: map[string]int{"auto": 20}}
 	err = web.commitMatchScore(match, matchResult, true)
 	assert.Nil(t, err)
 	assert.Equal(t, 2, matchResult.PlayNumber)
@@ -199,6 +261,40 @@ func TestCommitEliminationTie(t *testing.T) {
 	web.commitMatchScore(match, matchResult, true)
 	match, _ = web.arena.Database.GetMatchById(1)
 	assert.Equal(t, game.TieMatch, match.Status)
+	assert.Equal(t, "", match.TiebreakReason)
+
+	// Check that the configured tiebreak ladder decides a playoff match with tied final scores.
+	web.arena.EventSettings.PlayoffTiebreakers = "fewestFouls, autoPoints"
+	matchResult.RedScore = &game.Score{ElementCounts: map[string]int{"auto": 10, "teleop": 20}}
+	matchResult.BlueScore = &game.Score{ElementCounts: map[string]int{"auto": 20, "teleop": 10}}
+	web.commitMatchScore(match, matchResult, true)
+	match, _ = web.arena.Database.GetMatchById(1)
+	assert.Equal(t, game.BlueWonMatch, match.Status)
+	assert.Equal(t, "More auto points", match.TiebreakReason)
+
+	recorder := web.getHttpResponse("/match_review")
+	assert.Contains(t, recorder.Body.String(), "More auto points")
+}
+
+func TestCommitMatchTeamContributions(t *testing.T) {
+	web := setupTestWeb(t)
+	defer func(definition *game.GameDefinition) { game.CurrentGame = definition }(game.CurrentGame)
+	definition := *game.CurrentGame
+	definition.ScoringElements = append(
+		append([]game.ScoringElement(nil), definition.ScoringElements...),
+		game.ScoringElement{Id: "leave", Name: "Leave", Period: game.AutoScoringPeriod, Points: 3, PerRobot: true},
+	)
+	game.CurrentGame = &definition
+
+	match := &model.Match{Type: "qualification", Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6}
+	web.arena.Database.CreateMatch(match)
+	matchResult := model.NewMatchResult()
+	matchResult.MatchId = match.Id
+	matchResult.RedScore.SetRobotElementCount(2, "leave", 1)
+	assert.Nil(t, web.commitMatchScore(match, matchResult, false))
+	assert.Equal(t, 3, web.arena.SavedTeamContributions[3].Points)
+	assert.Equal(t, 1, web.arena.SavedTeamContributions[4].MatchesPlayed)
+	assert.Equal(t, game.RedWonMatch, web.arena.SavedMatch.Status)
 }
 
 func TestMatchPlayWebsocketCommands(t *testing.T) {
@@ -219,6 +315,9 @@ func TestMatchPlayWebsocketCommands(t *testing.T) {
 	readWebsocketType(t, ws, "audienceDisplayMode")
 	readWebsocketType(t, ws, "allianceStationDisplayMode")
 	readWebsocketType(t, ws, "eventStatus")
+	readWebsocketType(t, ws, "fieldLights")
+	readWebsocketType(t, ws, "scoringStatus")
+	readWebsocketType(t, ws, "headRefStatus")
 
 	// Test that a server-side error is communicated to the client.
 	ws.Write("nonexistenttype", nil)
@@ -271,26 +370,20 @@ func TestMatchPlayWebsocketCommands(t *testing.T) {
 	readWebsocketType(t, ws, "allianceStationDisplayMode")
 	assert.Equal(t, field.PostMatch, web.arena.MatchState)
 	ws.Write("updateRealtimeScore", map[string]interface{}{
-		"blueAuto":    10,
-		"redAuto":     20,
-		"blueTeleop":  30,
-		"redTeleop":   40,
-		"blueEndgame": 50,
-		"redEndgame":  60,
+		"red":  map[string]int{"auto": 20, "teleop": 40, "endgame": 60},
+		"blue": map[string]int{"auto": 10, "teleop": 30, "endgame": 50},
 	})
 	readWebsocketType(t, ws, "arenaStatus")
 	readWebsocketType(t, ws, "realtimeScore")
-	assert.Equal(t, 20, web.arena.SavedMatchResult.RedScore.AutoPoints)
-	assert.Equal(t, 40, web.arena.SavedMatchResult.RedScore.TeleopPoints)
-	assert.Equal(t, 60, web.arena.SavedMatchResult.RedScore.EndgamePoints)
-	assert.Equal(t, 10, web.arena.SavedMatchResult.BlueScore.AutoPoints)
-	assert.Equal(t, 30, web.arena.SavedMatchResult.BlueScore.TeleopPoints)
-	assert.Equal(t, 50, web.arena.SavedMatchResult.BlueScore.EndgamePoints)
+	assert.Equal(t, map[string]int{"auto": 20, "teleop": 40, "endgame": 60},
+		web.arena.SavedMatchResult.RedScore.ElementCounts)
+	assert.Equal(t, map[string]int{"auto": 10, "teleop": 30, "endgame": 50},
+		web.arena.SavedMatchResult.BlueScore.ElementCounts)
 	ws.Write("commitResults", nil)
-	readWebsocketMultiple(t, ws, 3) // reload, realtimeScore, setAllianceStationDisplay
+	readWebsocketMultiple(t, ws, 5) // reload, realtimeScore, scoringStatus, headRefStatus, setAllianceStationDisplay
 	assert.Equal(t, field.PreMatch, web.arena.MatchState)
 	ws.Write("discardResults", nil)
-	readWebsocketMultiple(t, ws, 3) // reload, realtimeScore, setAllianceStationDisplay
+	readWebsocketMultiple(t, ws, 5) // reload, realtimeScore, scoringStatus, headRefStatus, setAllianceStationDisplay
 	assert.Equal(t, field.PreMatch, web.arena.MatchState)
 
 	// Test changing the displays.
@@ -322,6 +415,9 @@ func TestMatchPlayWebsocketNotifications(t *testing.T) {
 	readWebsocketType(t, ws, "audienceDisplayMode")
 	readWebsocketType(t, ws, "allianceStationDisplayMode")
 	readWebsocketType(t, ws, "eventStatus")
+	readWebsocketType(t, ws, "fieldLights")
+	readWebsocketType(t, ws, "scoringStatus")
+	readWebsocketType(t, ws, "headRefStatus")
 
 	web.arena.AllianceStations["R1"].Bypass = true
 	web.arena.AllianceStations["R2"].Bypass = true
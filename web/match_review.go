@@ -8,23 +8,52 @@ package web
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/FRCTeam1987/crimson-arena/bracket"
 	"github.com/FRCTeam1987/crimson-arena/game"
 	"github.com/FRCTeam1987/crimson-arena/model"
+	"github.com/FRCTeam1987/crimson-arena/tournament"
 	"github.com/gorilla/mux"
+	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type MatchReviewListItem struct {
-	Id          int
-	DisplayName string
-	Time        string
-	RedTeams    []int
-	BlueTeams   []int
-	RedScore    int
-	BlueScore   int
-	ColorClass  string
-	IsComplete  bool
+	Id             int
+	DisplayName    string
+	Time           string
+	RedTeams       []int
+	BlueTeams      []int
+	RedScore       int
+	BlueScore      int
+	TiebreakReason string
+	RedGameData    string
+	BlueGameData   string
+	ColorClass     string
+	IsComplete     bool
+	// Reason for the replay of the match that is waiting to be played, if there is one.
+	ReplayReason string
+}
+
+type PlayHistoryItem struct {
+	model.MatchResult
+	RedScoreSummary  *game.ScoreSummary
+	BlueScoreSummary *game.ScoreSummary
+	// Differences between this play's scores and those of the official result.
+	RedScoreDelta  int
+	BlueScoreDelta int
+	IsOfficial     bool
+}
+
+type ScoreLogItem struct {
+	model.ScoreEvent
+	MatchTime string
+	Changes   []string
+	// Running totals of each alliance's score after the event is applied.
+	RedScore  int
+	BlueScore int
 }
 
 // Shows the match review interface.
@@ -56,11 +85,17 @@ func (web *Web) matchReviewHandler(w http.ResponseWriter, r *http.Request) {
 	if currentMatchType == "test" {
 		currentMatchType = "practice"
 	}
+	sessionUsername := ""
+	if session := web.getUserSessionFromCookie(r); session != nil {
+		sessionUsername = session.Username
+	}
 	data := struct {
 		*model.EventSettings
 		MatchesByType    map[string][]MatchReviewListItem
 		CurrentMatchType string
-	}{web.arena.EventSettings, matchesByType, currentMatchType}
+		ReplayReasons    []model.ReplayReason
+		SessionUsername  string
+	}{web.arena.EventSettings, matchesByType, currentMatchType, model.ReplayReasons, sessionUsername}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
@@ -92,9 +127,12 @@ func (web *Web) matchReviewEditGetHandler(w http.ResponseWriter, r *http.Request
 	}
 	data := struct {
 		*model.EventSettings
-		Match           *model.Match
-		MatchResultJson string
-	}{web.arena.EventSettings, match, string(matchResultJson)}
+		Match                *model.Match
+		MatchResult          *model.MatchResult
+		MatchResultJson      string
+		GameDefinition       *game.GameDefinition
+		ScoreTimelineMatchId string
+	}{web.arena.EventSettings, match, matchResult, string(matchResultJson), game.CurrentGame, mux.Vars(r)["matchId"]}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
@@ -108,7 +146,7 @@ func (web *Web) matchReviewEditPostHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	match, _, isCurrent, err := web.getMatchResultFromRequest(r)
+	match, previousMatchResult, isCurrent, err := web.getMatchResultFromRequest(r)
 	if err != nil {
 		handleWebErr(w, err)
 		return
@@ -123,24 +161,381 @@ func (web *Web) matchReviewEditPostHandler(w http.ResponseWriter, r *http.Reques
 		handleWebErr(w, fmt.Errorf("Error: match ID %d from result does not match expected", matchResult.MatchId))
 		return
 	}
+	for _, score := range []*game.Score{matchResult.RedScore, matchResult.BlueScore} {
+		score.UpdateRobotElementTotals()
+		for _, foul := range score.Fouls {
+			if !game.IsValidFoulType(foul.Type) {
+				handleWebErr(w, fmt.Errorf("Error: invalid foul type '%s'", foul.Type))
+				return
+			}
+		}
+	}
+	for _, cards := range []map[string]string{matchResult.RedCards, matchResult.BlueCards} {
+		for teamId, card := range cards {
+			if card == "" {
+				delete(cards, teamId)
+			} else if !model.IsValidCard(card) {
+				handleWebErr(w, fmt.Errorf("Error: invalid card '%s'", card))
+				return
+			}
+		}
+	}
 
 	if isCurrent {
 		// If editing the current match, just save it back to memory.
+		previousRedScore, previousBlueScore := web.arena.RedScore.Copy(), web.arena.BlueScore.Copy()
 		*web.arena.RedScore = *matchResult.RedScore
 		*web.arena.BlueScore = *matchResult.BlueScore
+		web.arena.RedCards = matchResult.RedCards
+		web.arena.BlueCards = matchResult.BlueCards
+		err = web.arena.RecordScoreChange(
+			model.UserScoreEventSource, "match review", previousRedScore, previousBlueScore,
+		)
+		web.arena.RealtimeScoreNotifier.Notify()
+		web.arena.NotifyPendingResultEdited()
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
 
 		http.Redirect(w, r, "/match_play", 303)
 	} else {
+		// The head referee's approval applies only to the result as it was originally committed.
+		matchResult.ApprovedBy = ""
+		matchResult.ApprovedAt = time.Time{}
 		err = web.commitMatchScore(match, &matchResult, true)
 		if err != nil {
 			handleWebErr(w, err)
 			return
 		}
+		if match.Type != "test" {
+			err = web.arena.Database.CreateScoreEvents(
+				previousMatchResult, &matchResult, 0, model.UserScoreEventSource, "match review",
+			)
+			if err != nil {
+				handleWebErr(w, err)
+				return
+			}
+		}
 
 		http.Redirect(w, r, "/match_review", 303)
 	}
 }
 
+// Flags a played match to be replayed, queueing it to be run after a number of the upcoming matches.
+func (web *Web) matchReviewReplayPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	match, _, isCurrent, err := web.getMatchResultFromRequest(r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if isCurrent || match.Type == "test" {
+		handleWebErr(w, fmt.Errorf("Error: only a committed match can be flagged for replay"))
+		return
+	}
+	if !match.IsComplete() {
+		handleWebErr(w, fmt.Errorf("Error: match %s has not been played", match.DisplayName))
+		return
+	}
+	pendingReplay, err := web.arena.Database.GetPendingMatchReplay(match.Id)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if pendingReplay != nil {
+		handleWebErr(w, fmt.Errorf("Error: match %s has already been flagged for replay", match.DisplayName))
+		return
+	}
+
+	matchReplay := model.MatchReplay{
+		MatchId:     match.Id,
+		Reason:      model.ReplayReason(r.PostFormValue("reason")),
+		RequestedBy: strings.TrimSpace(r.PostFormValue("requestedBy")),
+		Notes:       strings.TrimSpace(r.PostFormValue("notes")),
+		RequestedAt: time.Now(),
+	}
+	if !model.IsValidReplayReason(matchReplay.Reason) {
+		handleWebErr(w, fmt.Errorf("Error: invalid replay reason '%s'", matchReplay.Reason))
+		return
+	}
+	if matchReplay.RequestedBy == "" {
+		// Fall back to the logged-in user; there isn't one when authentication is disabled, so a name must be typed.
+		if session := web.getUserSessionFromCookie(r); session != nil {
+			matchReplay.RequestedBy = session.Username
+		}
+	}
+	if matchReplay.RequestedBy == "" {
+		handleWebErr(w, fmt.Errorf("Error: the name of the person requesting the replay is required"))
+		return
+	}
+	numMatchesBefore, err := strconv.Atoi(r.PostFormValue("numMatchesBefore"))
+	if err != nil {
+		numMatchesBefore = web.arena.EventSettings.NumMatchesBeforeReplay
+	}
+	if err = web.arena.Database.QueueMatchReplay(&matchReplay, match.Type, numMatchesBefore); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	http.Redirect(w, r, "/match_review", 303)
+}
+
+// Removes the results of a match and returns it to unplayed status, undoing its effect on the rest of the tournament.
+func (web *Web) matchReviewUnscorePostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	match, _, isCurrent, err := web.getMatchResultFromRequest(r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if isCurrent || match.Type == "test" {
+		handleWebErr(w, fmt.Errorf("Error: only a committed match can be unscored"))
+		return
+	}
+	if !match.IsComplete() {
+		handleWebErr(w, fmt.Errorf("Error: match %s has not been scored", match.DisplayName))
+		return
+	}
+
+	if err = web.unscoreMatch(match); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	http.Redirect(w, r, "/match_review", 303)
+}
+
+// Deletes all results for the given match and recalculates the cards, rankings, and playoff bracket without it.
+func (web *Web) unscoreMatch(match *model.Match) error {
+	if match.ShouldUpdateEliminationMatches() {
+		// Later playoff matches would have to be deleted along with their results, so require those to be unscored
+		// first.
+		hasPlayedDependentMatches, err := web.arena.PlayoffBracket.HasPlayedDependentMatches(
+			web.arena.Database, match.ElimRound, match.ElimGroup,
+		)
+		if err != nil {
+			return err
+		}
+		if hasPlayedDependentMatches {
+			return fmt.Errorf(
+				"Error: match %s can't be unscored because later playoff matches depending on its outcome have "+
+					"already been played; unscore those first", match.DisplayName,
+			)
+		}
+	}
+
+	// Back up the database, but don't error out if it fails.
+	err := web.arena.Database.Backup(web.arena.EventSettings.Name,
+		fmt.Sprintf("pre_unscore_%s_match_%s", match.Type, match.DisplayName))
+	if err != nil {
+		log.Println(err)
+	}
+
+	if err = web.arena.Database.DeleteMatchResultsForMatch(match.Id); err != nil {
+		return err
+	}
+	match.Status = game.MatchNotPlayed
+	match.ScoreCommittedAt = time.Time{}
+	match.TiebreakReason = ""
+	if err = web.arena.Database.UpdateMatch(match); err != nil {
+		return err
+	}
+
+	if match.ShouldUpdateCards() {
+		if err = tournament.CalculateTeamCards(web.arena.Database, match.Type); err != nil {
+			return err
+		}
+	}
+
+	if match.ShouldUpdateRankings() {
+		if _, err = tournament.CalculateRankings(web.arena.Database, true); err != nil {
+			return err
+		}
+	}
+
+	if match.ShouldUpdateEliminationMatches() {
+		// Recount the matchup and remove any subsequent matches that depended on its outcome.
+		nextMatchTime := time.Now().Add(time.Second * bracket.ElimMatchSpacingSec)
+		if err = web.arena.UpdatePlayoffBracket(&nextMatchTime); err != nil {
+			return err
+		}
+		if err = web.updateOtherPlayoffBrackets(); err != nil {
+			return err
+		}
+		if !web.arena.PlayoffBracket.IsComplete() {
+			if err = tournament.DeleteWinnerAndFinalistAwards(web.arena.Database); err != nil {
+				return err
+			}
+		}
+	}
+
+	if web.arena.SavedMatch.Id == match.Id {
+		// Clear the result from the audience display buffer since it no longer stands.
+		web.arena.SavedMatch = &model.Match{}
+		web.arena.SavedMatchResult = model.NewMatchResult()
+		web.arena.ScorePostedNotifier.Notify()
+	}
+
+	if web.arena.EventSettings.TbaPublishingEnabled && match.Type != "practice" {
+		// Publish asynchronously to The Blue Alliance.
+		go func() {
+			if match.ShouldUpdateEliminationMatches() {
+				// Playoff matches may have been deleted from the bracket, so start from a clean slate.
+				if err := web.arena.TbaClient.DeletePublishedMatches(); err != nil {
+					log.Printf("Failed to delete published matches: %s", err.Error())
+				}
+			}
+			if err := web.arena.TbaClient.PublishMatches(web.arena.Database); err != nil {
+				log.Printf("Failed to publish matches: %s", err.Error())
+			}
+			if match.ShouldUpdateRankings() {
+				if err := web.arena.TbaClient.PublishRankings(web.arena.Database); err != nil {
+					log.Printf("Failed to publish rankings: %s", err.Error())
+				}
+			}
+		}()
+	}
+
+	return nil
+}
+
+// Shows every recorded play of a match, from which an earlier one can be promoted back to the official result.
+func (web *Web) matchReviewPlaysGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	match, _, _, err := web.getMatchResultFromRequest(r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	matchResults, err := web.arena.Database.GetMatchResultsForMatch(match.Id)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	template, err := web.parseFiles("templates/match_plays.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Match *model.Match
+		Items []PlayHistoryItem
+	}{web.arena.EventSettings, match, buildPlayHistory(matchResults)}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Makes an earlier play of a match the official result by committing a copy of it as a new play, so that the history
+// of plays is preserved.
+func (web *Web) matchReviewPlaysPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	match, officialMatchResult, isCurrent, err := web.getMatchResultFromRequest(r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if isCurrent || match.Type == "test" {
+		handleWebErr(w, fmt.Errorf("Error: only a committed match has plays to promote"))
+		return
+	}
+	playNumber, _ := strconv.Atoi(r.PostFormValue("playNumber"))
+	matchResults, err := web.arena.Database.GetMatchResultsForMatch(match.Id)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var matchResult *model.MatchResult
+	for i := range matchResults {
+		if matchResults[i].PlayNumber == playNumber {
+			matchResult = &matchResults[i]
+		}
+	}
+	if matchResult == nil {
+		handleWebErr(w, fmt.Errorf("Error: No play %d of match %s", playNumber, match.DisplayName))
+		return
+	}
+	if matchResult.PlayNumber == officialMatchResult.PlayNumber {
+		handleWebErr(w, fmt.Errorf("Error: play %d is already the official result", playNumber))
+		return
+	}
+
+	restoredMatchResult := *matchResult
+	restoredMatchResult.Id = 0
+	restoredMatchResult.PlayNumber = 0
+	restoredMatchResult.RestoredFromPlay = matchResult.PlayNumber
+	if err = web.commitMatchScore(match, &restoredMatchResult, true); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Log the restored scores from scratch, as for any other new play of the match.
+	err = web.arena.Database.CreateScoreEvents(
+		model.NewMatchResult(),
+		&restoredMatchResult,
+		0,
+		model.UserScoreEventSource,
+		fmt.Sprintf("restored play %d", matchResult.PlayNumber),
+	)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/match_review/%d/plays", match.Id), 303)
+}
+
+// Shows the log of changes made to the scores of a match, replayed in order to show the running score after each one.
+func (web *Web) matchReviewScoreLogHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	match, _, _, err := web.getMatchResultFromRequest(r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	events, err := web.arena.Database.GetScoreEventsForMatch(match.Id)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	template, err := web.parseFiles("templates/score_log.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Match *model.Match
+		Items []ScoreLogItem
+	}{web.arena.EventSettings, match, buildScoreLog(events)}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
 // Load the match result for the match referenced in the HTTP query string.
 func (web *Web) getMatchResultFromRequest(r *http.Request) (*model.Match, *model.MatchResult, bool, error) {
 	vars := mux.Vars(r)
@@ -179,13 +574,18 @@ func (web *Web) buildMatchReviewList(matchType string) ([]MatchReviewListItem, e
 		return []MatchReviewListItem{}, err
 	}
 
+	teamsPerAlliance := web.arena.EventSettings.TeamsPerAlliance
 	matchReviewList := make([]MatchReviewListItem, len(matches))
 	for i, match := range matches {
 		matchReviewList[i].Id = match.Id
 		matchReviewList[i].DisplayName = match.TypePrefix() + match.DisplayName
 		matchReviewList[i].Time = match.Time.Local().Format("Mon 1/02 03:04 PM")
-		matchReviewList[i].RedTeams = []int{match.Red1, match.Red2, match.Red3}
-		matchReviewList[i].BlueTeams = []int{match.Blue1, match.Blue2, match.Blue3}
+		teamIds := match.TeamIds(teamsPerAlliance)
+		matchReviewList[i].RedTeams = teamIds[:teamsPerAlliance]
+		matchReviewList[i].BlueTeams = teamIds[teamsPerAlliance:]
+		matchReviewList[i].TiebreakReason = match.TiebreakReason
+		matchReviewList[i].RedGameData = match.RedGameData
+		matchReviewList[i].BlueGameData = match.BlueGameData
 		matchResult, err := web.arena.Database.GetMatchResultForMatch(match.Id)
 		if err != nil {
 			return []MatchReviewListItem{}, err
@@ -194,6 +594,13 @@ func (web *Web) buildMatchReviewList(matchType string) ([]MatchReviewListItem, e
 			matchReviewList[i].RedScore = matchResult.RedScoreSummary().Score
 			matchReviewList[i].BlueScore = matchResult.BlueScoreSummary().Score
 		}
+		pendingReplay, err := web.arena.Database.GetPendingMatchReplay(match.Id)
+		if err != nil {
+			return []MatchReviewListItem{}, err
+		}
+		if pendingReplay != nil {
+			matchReviewList[i].ReplayReason = pendingReplay.Reason.DisplayName()
+		}
 		switch match.Status {
 		case game.RedWonMatch:
 			matchReviewList[i].ColorClass = "danger"
@@ -212,3 +619,64 @@ func (web *Web) buildMatchReviewList(matchType string) ([]MatchReviewListItem, e
 
 	return matchReviewList, nil
 }
+
+// Constructs the list of plays of a match, comparing each one's scores to those of the official (last) play.
+func buildPlayHistory(matchResults []model.MatchResult) []PlayHistoryItem {
+	items := make([]PlayHistoryItem, len(matchResults))
+	for i, matchResult := range matchResults {
+		items[i].MatchResult = matchResult
+		items[i].RedScoreSummary = matchResult.RedScoreSummary()
+		items[i].BlueScoreSummary = matchResult.BlueScoreSummary()
+	}
+	if len(items) > 0 {
+		official := &items[len(items)-1]
+		official.IsOfficial = true
+		for i := range items {
+			items[i].RedScoreDelta = items[i].RedScoreSummary.Score - official.RedScoreSummary.Score
+			items[i].BlueScoreDelta = items[i].BlueScoreSummary.Score - official.BlueScoreSummary.Score
+		}
+	}
+	return items
+}
+
+// Replays the given score events in order, starting each play of the match from an empty score.
+func buildScoreLog(events []model.ScoreEvent) []ScoreLogItem {
+	items := make([]ScoreLogItem, len(events))
+	var redScore, blueScore *game.Score
+	for i, event := range events {
+		if i == 0 || event.PlayNumber != events[i-1].PlayNumber {
+			redScore, blueScore = new(game.Score), new(game.Score)
+		}
+		if event.Alliance == "red" {
+			redScore = event.Score
+		} else {
+			blueScore = event.Score
+		}
+
+		items[i].ScoreEvent = event
+		if event.MatchTimeSec > 0 {
+			items[i].MatchTime = fmt.Sprintf("%.1f", event.MatchTimeSec)
+		}
+		for _, delta := range event.Deltas {
+			items[i].Changes = append(items[i].Changes, describeScoreDelta(delta))
+		}
+		if event.FoulsDelta != 0 {
+			items[i].Changes = append(items[i].Changes, fmt.Sprintf("%+d foul(s)", event.FoulsDelta))
+		}
+		items[i].RedScore = redScore.Summarize(blueScore).Score
+		items[i].BlueScore = blueScore.Summarize(redScore).Score
+	}
+	return items
+}
+
+// Returns a human-readable description of the given change to a scoring element count, e.g. "+2 Coral (robot 1)".
+func describeScoreDelta(delta game.ScoreDelta) string {
+	name := delta.ElementId
+	if element := game.CurrentGame.GetScoringElement(delta.ElementId); element != nil {
+		name = element.Name
+	}
+	if delta.Position > 0 {
+		return fmt.Sprintf("%+d %s (robot %d)", delta.Delta, name, delta.Position)
+	}
+	return fmt.Sprintf("%+d %s", delta.Delta, name)
+}
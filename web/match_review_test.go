@@ -5,11 +5,13 @@ package web
 
 import (
 	"fmt"
+	"github.com/FRCTeam1987/crimson-arena/field"
 	"github.com/FRCTeam1987/crimson-arena/game"
 	"github.com/FRCTeam1987/crimson-arena/model"
 	"github.com/FRCTeam1987/crimson-arena/tournament"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestMatchReview(t *testing.T) {
@@ -17,7 +19,9 @@ func TestMatchReview(t *testing.T) {
 
 	match1 := model.Match{Type: "practice", DisplayName: "1", Status: game.RedWonMatch}
 	match2 := model.Match{Type: "practice", DisplayName: "2"}
-	match3 := model.Match{Type: "qualification", DisplayName: "1", Status: game.BlueWonMatch}
+	match3 := model.Match{
+		Type: "qualification", DisplayName: "1", Status: game.BlueWonMatch, RedGameData: "LRL", BlueGameData: "RLR",
+	}
 	match4 := model.Match{Type: "elimination", DisplayName: "SF1-1", Status: game.TieMatch}
 	match5 := model.Match{Type: "elimination", DisplayName: "SF1-2"}
 	web.arena.Database.CreateMatch(&match1)
@@ -34,6 +38,8 @@ func TestMatchReview(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), ">Q1<")
 	assert.Contains(t, recorder.Body.String(), ">SF1-1<")
 	assert.Contains(t, recorder.Body.String(), ">SF1-2<")
+	assert.Contains(t, recorder.Body.String(), "<span class=\"red-text\">LRL</span>")
+	assert.Contains(t, recorder.Body.String(), "<span class=\"blue-text\">RLR</span>")
 }
 
 func TestMatchReviewEditExistingResult(t *testing.T) {
@@ -140,8 +146,8 @@ func TestMatchReviewEditCurrentMatch(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), " 352 ")
 
 	postBody := fmt.Sprintf(
-		"matchResultJson={\"MatchId\":%d,\"RedScore\":{\"AutoPoints\":10,\"TeleopPoints\":20,\"EndgamePoints\":30},"+
-			"\"BlueScore\":{\"AutoPoints\":40,\"TeleopPoints\":50,\"EndgamePoints\":60}}",
+		"matchResultJson={\"MatchId\":%d,\"RedScore\":{\"ElementCounts\":{\"auto\":10,\"teleop\":20,"+
+			"\"endgame\":30}},\"BlueScore\":{\"ElementCounts\":{\"auto\":40,\"teleop\":50,\"endgame\":60}}}",
 		match.Id,
 	)
 	recorder = web.postHttpResponse("/match_review/current/edit", postBody)
@@ -151,10 +157,387 @@ func TestMatchReviewEditCurrentMatch(t *testing.T) {
 	// Check that the persisted match is still unedited and that the realtime scores have been updated instead.
 	match2, _ := web.arena.Database.GetMatchById(match.Id)
 	assert.Equal(t, game.MatchNotPlayed, match2.Status)
-	assert.Equal(t, 10, web.arena.RedScore.AutoPoints)
-	assert.Equal(t, 20, web.arena.RedScore.TeleopPoints)
-	assert.Equal(t, 30, web.arena.RedScore.EndgamePoints)
-	assert.Equal(t, 40, web.arena.BlueScore.AutoPoints)
-	assert.Equal(t, 50, web.arena.BlueScore.TeleopPoints)
-	assert.Equal(t, 60, web.arena.BlueScore.EndgamePoints)
+	assert.Equal(t, map[string]int{"auto": 10, "teleop": 20, "endgame": 30}, web.arena.RedScore.ElementCounts)
+	assert.Equal(t, map[string]int{"auto": 40, "teleop": 50, "endgame": 60}, web.arena.BlueScore.ElementCounts)
+}
+
+func TestMatchReviewEditFouls(t *testing.T) {
+	web := setupTestWeb(t)
+
+	match := model.Match{Type: "qualification", DisplayName: "12", Red1: 1001, Red2: 1002, Red3: 1003, Blue1: 1004,
+		Blue2: 1005, Blue3: 1006}
+	web.arena.Database.CreateMatch(&match)
+
+	// The blue alliance wins on the strength of the fouls committed by red.
+	postBody := fmt.Sprintf(
+		"matchResultJson={\"MatchId\":%d,\"RedScore\":{\"ElementCounts\":{\"teleop\":20},\"Fouls\":[{\"Type\":"+
+			"\"major\",\"TeamId\":1002,\"RuleId\":0,\"TimeInMatchSec\":35.5},{\"Type\":\"tech\",\"TeamId\":1003}]},"+
+			"\"BlueScore\":{\"ElementCounts\":{\"teleop\":5}}}",
+		match.Id,
+	)
+	recorder := web.postHttpResponse(fmt.Sprintf("/match_review/%d/edit", match.Id), postBody)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+
+	matchResult, _ := web.arena.Database.GetMatchResultForMatch(match.Id)
+	if assert.NotNil(t, matchResult) {
+		assert.Equal(t, 2, len(matchResult.RedScore.Fouls))
+		assert.Equal(t, 1002, matchResult.RedScore.Fouls[0].TeamId)
+		assert.Equal(t, 18, matchResult.BlueScoreSummary().FoulPoints)
+		assert.Equal(t, 23, matchResult.BlueScoreSummary().Score)
+		assert.Equal(t, 20, matchResult.RedScoreSummary().Score)
+	}
+	match2, _ := web.arena.Database.GetMatchById(match.Id)
+	assert.Equal(t, game.BlueWonMatch, match2.Status)
+
+	// Check that the fouls are rendered for editing.
+	recorder = web.getHttpResponse(fmt.Sprintf("/match_review/%d/edit", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Fouls Committed")
+
+	postBody = fmt.Sprintf(
+		"matchResultJson={\"MatchId\":%d,\"RedScore\":{\"Fouls\":[{\"Type\":\"yellow\"}]},\"BlueScore\":{}}",
+		match.Id,
+	)
+	recorder = web.postHttpResponse(fmt.Sprintf("/match_review/%d/edit", match.Id), postBody)
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "invalid foul type")
+}
+
+func TestMatchReviewEditCards(t *testing.T) {
+	web := setupTestWeb(t)
+
+	match := model.Match{Type: "elimination", DisplayName: "QF4-1", Red1: 1001, Red2: 1002, Red3: 1003, Blue1: 1004,
+		Blue2: 1005, Blue3: 1006, ElimRedAlliance: 1, ElimBlueAlliance: 2}
+	web.arena.Database.CreateMatch(&match)
+	web.arena.Database.CreateTeam(&model.Team{Id: 1005})
+	tournament.CreateTestAlliances(web.arena.Database, 2)
+	web.arena.EventSettings.NumElimAlliances = 2
+	web.arena.CreatePlayoffBracket()
+
+	// A red card in the playoffs should cause the alliance to lose regardless of score.
+	postBody := fmt.Sprintf(
+		"matchResultJson={\"MatchId\":%d,\"RedScore\":{\"ElementCounts\":{\"teleop\":50}},\"BlueScore\":"+
+			"{\"ElementCounts\":{\"teleop\":10}},\"RedCards\":{\"1002\":\"red\"},\"BlueCards\":{\"1005\":\"yellow\","+
+			"\"1006\":\"\"}}",
+		match.Id,
+	)
+	recorder := web.postHttpResponse(fmt.Sprintf("/match_review/%d/edit", match.Id), postBody)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	match2, _ := web.arena.Database.GetMatchById(match.Id)
+	assert.Equal(t, game.BlueWonMatch, match2.Status)
+	matchResult, _ := web.arena.Database.GetMatchResultForMatch(match.Id)
+	if assert.NotNil(t, matchResult) {
+		assert.True(t, matchResult.RedScore.PlayoffDq)
+		assert.Equal(t, map[string]string{"1005": "yellow"}, matchResult.BlueCards)
+	}
+	team, _ := web.arena.Database.GetTeamById(1005)
+	if assert.NotNil(t, team) {
+		assert.True(t, team.YellowCard)
+	}
+
+	postBody = fmt.Sprintf("matchResultJson={\"MatchId\":%d,\"RedScore\":{},\"BlueScore\":{},"+
+		"\"RedCards\":{\"1001\":\"green\"}}", match.Id)
+	recorder = web.postHttpResponse(fmt.Sprintf("/match_review/%d/edit", match.Id), postBody)
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "invalid card")
+}
+
+func TestMatchReviewEditRobotScores(t *testing.T) {
+	web := setupTestWeb(t)
+	defer func(definition *game.GameDefinition) { game.CurrentGame = definition }(game.CurrentGame)
+	definition := *game.CurrentGame
+	definition.ScoringElements = append(
+		append([]game.ScoringElement(nil), definition.ScoringElements...),
+		game.ScoringElement{Id: "leave", Name: "Leave", Period: game.AutoScoringPeriod, Points: 3, PerRobot: true},
+	)
+	game.CurrentGame = &definition
+
+	match := model.Match{Type: "qualification", DisplayName: "1", Red1: 1001, Red2: 1002, Red3: 1003, Blue1: 1004,
+		Blue2: 1005, Blue3: 1006}
+	web.arena.Database.CreateMatch(&match)
+
+	recorder := web.getHttpResponse(fmt.Sprintf("/match_review/%d/edit", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Leave (3 pts per robot)")
+	assert.Contains(t, recorder.Body.String(), "data-element=\"leave\" data-robot=\"2\"")
+
+	// The alliance total of the per-robot element should be recalculated from the per-robot counts.
+	postBody := fmt.Sprintf(
+		"matchResultJson={\"MatchId\":%d,\"RedScore\":{\"ElementCounts\":{\"teleop\":10,\"leave\":99},"+
+			"\"RobotElementCounts\":[{\"leave\":1},{\"leave\":0},{\"leave\":1}]},\"BlueScore\":{}}",
+		match.Id,
+	)
+	recorder = web.postHttpResponse(fmt.Sprintf("/match_review/%d/edit", match.Id), postBody)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	matchResult, _ := web.arena.Database.GetMatchResultForMatch(match.Id)
+	if assert.NotNil(t, matchResult) {
+		assert.Equal(t, 2, matchResult.RedScore.ElementCounts["leave"])
+		assert.Equal(t, 1, matchResult.RedScore.RobotElementCounts[2]["leave"])
+		assert.Equal(t, 16, matchResult.RedScoreSummary().Score)
+	}
+}
+
+func TestMatchReviewScoreLog(t *testing.T) {
+	web := setupTestWeb(t)
+
+	match := model.Match{Type: "qualification", DisplayName: "12", Red1: 1001, Red2: 1002, Red3: 1003, Blue1: 1004,
+		Blue2: 1005, Blue3: 1006}
+	assert.Nil(t, web.arena.Database.CreateMatch(&match))
+	assert.Nil(t, web.arena.LoadMatch(&match))
+	web.arena.MatchState = field.PostMatch
+
+	recorder := web.getHttpResponse(fmt.Sprintf("/match_review/%d/score_log", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No score changes have been recorded")
+
+	// Make changes through the API and the match review page, before and after the result is committed.
+	recorder = web.patchHttpResponse("/api/scores", "{\"red\":{\"teleop\":10}}")
+	assert.Equal(t, 200, recorder.Code)
+	recorder = web.patchHttpResponse("/api/scores", "{\"blue\":{\"auto\":3}}")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Nil(t, web.commitCurrentMatchScore())
+	assert.Equal(t, 2, web.arena.PendingPlayNumber)
+	postBody := fmt.Sprintf(
+		"matchResultJson={\"Id\":1,\"MatchId\":%d,\"PlayNumber\":1,\"RedScore\":{\"ElementCounts\":{\"teleop\":8}},"+
+			"\"BlueScore\":{\"ElementCounts\":{\"auto\":3}}}",
+		match.Id,
+	)
+	recorder = web.postHttpResponse(fmt.Sprintf("/match_review/%d/edit", match.Id), postBody)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+
+	events, err := web.arena.Database.GetScoreEventsForMatch(match.Id)
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(events)) {
+		assert.Equal(t, model.ApiScoreEventSource, events[0].Source)
+		assert.Equal(t, "red", events[0].Alliance)
+		assert.Equal(t, 10, events[0].PointsDelta)
+		assert.Equal(t, "blue", events[1].Alliance)
+		assert.Equal(t, model.UserScoreEventSource, events[2].Source)
+		assert.Equal(t, "match review", events[2].SourceDetail)
+		assert.Equal(t, 1, events[2].PlayNumber)
+		assert.Equal(t, -2, events[2].PointsDelta)
+	}
+
+	// Check that the log replays the running score.
+	recorder = web.getHttpResponse(fmt.Sprintf("/match_review/%d/score_log", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Match 12 Score Log")
+	assert.Contains(t, recorder.Body.String(), "+10 Teleop")
+	assert.Contains(t, recorder.Body.String(), "-2 Teleop")
+	assert.Contains(t, recorder.Body.String(), "match review")
+	items := buildScoreLog(events)
+	if assert.Equal(t, 3, len(items)) {
+		assert.Equal(t, [2]int{10, 0}, [2]int{items[0].RedScore, items[0].BlueScore})
+		assert.Equal(t, [2]int{10, 3}, [2]int{items[1].RedScore, items[1].BlueScore})
+		assert.Equal(t, [2]int{8, 3}, [2]int{items[2].RedScore, items[2].BlueScore})
+	}
+	assert.NotContains(t, recorder.Body.String(), "Field Faults")
+
+	// Check that field faults logged on the match are listed.
+	dbMatch, _ := web.arena.Database.GetMatchById(match.Id)
+	dbMatch.FieldFaults = []model.FieldFault{
+		{StartedAt: time.Now(), MatchTimeSec: 42.5, PeriodName: "Teleoperated", Reason: "Scoring table power loss"},
+	}
+	assert.Nil(t, web.arena.Database.UpdateMatch(dbMatch))
+	recorder = web.getHttpResponse(fmt.Sprintf("/match_review/%d/score_log", match.Id))
+	assert.Contains(t, recorder.Body.String(), "Field Faults")
+	assert.Contains(t, recorder.Body.String(), "Scoring table power loss")
+	assert.Contains(t, recorder.Body.String(), "Not resumed")
+}
+
+func TestMatchReviewUnscoreQualificationMatch(t *testing.T) {
+	web := setupTestWeb(t)
+
+	match := model.Match{Type: "qualification", DisplayName: "1", Red1: 1001, Red2: 1002, Red3: 1003, Blue1: 1004,
+		Blue2: 1005, Blue3: 1006}
+	assert.Nil(t, web.arena.Database.CreateMatch(&match))
+	assert.Nil(t, web.commitMatchScore(&match, model.BuildTestMatchResult(match.Id, 0), false))
+	rankings, _ := web.arena.Database.GetAllRankings()
+	assert.Equal(t, 6, len(rankings))
+	assert.Equal(t, match.Id, web.arena.SavedMatch.Id)
+
+	recorder := web.postHttpResponse(fmt.Sprintf("/match_review/%d/unscore", match.Id), "")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	unscoredMatch, _ := web.arena.Database.GetMatchById(match.Id)
+	assert.Equal(t, game.MatchNotPlayed, unscoredMatch.Status)
+	assert.True(t, unscoredMatch.ScoreCommittedAt.IsZero())
+	matchResult, _ := web.arena.Database.GetMatchResultForMatch(match.Id)
+	assert.Nil(t, matchResult)
+	rankings, _ = web.arena.Database.GetAllRankings()
+	assert.Equal(t, 0, len(rankings))
+	assert.Equal(t, 0, web.arena.SavedMatch.Id)
+
+	// Check that a match that hasn't been scored can't be unscored.
+	recorder = web.postHttpResponse(fmt.Sprintf("/match_review/%d/unscore", match.Id), "")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "has not been scored")
+	recorder = web.postHttpResponse("/match_review/current/unscore", "")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "only a committed match can be unscored")
+}
+
+func TestMatchReviewUnscorePlayoffMatch(t *testing.T) {
+	web := setupTestWeb(t)
+
+	tournament.CreateTestAlliances(web.arena.Database, 4)
+	web.arena.EventSettings.NumElimAlliances = 4
+	assert.Nil(t, web.arena.CreatePlayoffBracket())
+	assert.Nil(t, web.arena.UpdatePlayoffBracket(nil))
+	commitPlayoffMatch := func(displayName string, redWins bool) *model.Match {
+		match, _ := web.arena.Database.GetMatchByName("elimination", displayName)
+		matchResult := model.BuildTestMatchResult(match.Id, 0)
+		matchResult.MatchType = match.Type
+		matchResult.RedCards = map[string]string{}
+		if !redWins {
+			matchResult.RedScore, matchResult.BlueScore = matchResult.BlueScore, matchResult.RedScore
+		}
+		assert.Nil(t, web.commitMatchScore(match, matchResult, true))
+		return match
+	}
+	commitPlayoffMatch("SF1-1", true)
+	sf12 := commitPlayoffMatch("SF1-2", true)
+	commitPlayoffMatch("SF2-1", false)
+	commitPlayoffMatch("SF2-2", false)
+	f1 := commitPlayoffMatch("F-1", true)
+
+	// Check that a match can't be unscored while later matches that depend on its outcome have been played.
+	recorder := web.postHttpResponse(fmt.Sprintf("/match_review/%d/unscore", sf12.Id), "")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "unscore those first")
+
+	recorder = web.postHttpResponse(fmt.Sprintf("/match_review/%d/unscore", f1.Id), "")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	recorder = web.postHttpResponse(fmt.Sprintf("/match_review/%d/unscore", sf12.Id), "")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+
+	// Check that the finals were removed from the bracket now that the first semifinal is undecided.
+	matchup, _ := web.arena.PlayoffBracket.GetMatchup(3, 1)
+	assert.Equal(t, 1, matchup.RedAllianceWins)
+	assert.False(t, matchup.IsComplete())
+	finalsMatch, _ := web.arena.Database.GetMatchByName("elimination", "F-1")
+	assert.Nil(t, finalsMatch)
+	unscoredMatch, _ := web.arena.Database.GetMatchByName("elimination", "SF1-2")
+	if assert.NotNil(t, unscoredMatch) {
+		assert.Equal(t, game.MatchNotPlayed, unscoredMatch.Status)
+	}
+}
+
+func TestMatchReviewPlays(t *testing.T) {
+	web := setupTestWeb(t)
+
+	match := model.Match{Type: "qualification", DisplayName: "1", Red1: 1001, Red2: 1002, Red3: 1003, Blue1: 1004,
+		Blue2: 1005, Blue3: 1006}
+	assert.Nil(t, web.arena.Database.CreateMatch(&match))
+	assert.Nil(t, web.commitMatchScore(&match, model.BuildTestMatchResult(match.Id, 0), false))
+	replayResult := model.BuildTestMatchResult(match.Id, 0)
+	replayResult.RedScore, replayResult.BlueScore = replayResult.BlueScore, replayResult.RedScore
+	assert.Nil(t, web.commitMatchScore(&match, replayResult, false))
+	ranking, _ := web.arena.Database.GetRankingForTeam(1001)
+	assert.Equal(t, 0, ranking.Wins)
+
+	recorder := web.getHttpResponse(fmt.Sprintf("/match_review/%d/plays", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Match 1 Plays")
+	assert.Contains(t, recorder.Body.String(), "Official result")
+	assert.Contains(t, recorder.Body.String(), ">+75<")
+	assert.Contains(t, recorder.Body.String(), ">-75<")
+
+	// Promote the first play back to the official result.
+	recorder = web.postHttpResponse(fmt.Sprintf("/match_review/%d/plays", match.Id), "playNumber=1")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	matchResults, _ := web.arena.Database.GetMatchResultsForMatch(match.Id)
+	if assert.Equal(t, 3, len(matchResults)) {
+		assert.Equal(t, 3, matchResults[2].PlayNumber)
+		assert.Equal(t, 1, matchResults[2].RestoredFromPlay)
+		assert.Equal(t, matchResults[0].RedScore, matchResults[2].RedScore)
+	}
+	promotedMatch, _ := web.arena.Database.GetMatchById(match.Id)
+	assert.Equal(t, game.RedWonMatch, promotedMatch.Status)
+	ranking, _ = web.arena.Database.GetRankingForTeam(1001)
+	assert.Equal(t, 1, ranking.Wins)
+	recorder = web.getHttpResponse(fmt.Sprintf("/match_review/%d/plays", match.Id))
+	assert.Contains(t, recorder.Body.String(), "Restored from play 1")
+
+	recorder = web.postHttpResponse(fmt.Sprintf("/match_review/%d/plays", match.Id), "playNumber=3")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "already the official result")
+	recorder = web.postHttpResponse(fmt.Sprintf("/match_review/%d/plays", match.Id), "playNumber=9")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No play 9")
+}
+
+func TestMatchReviewReplay(t *testing.T) {
+	web := setupTestWeb(t)
+
+	for i := 1; i <= 4; i++ {
+		match := model.Match{Type: "qualification", DisplayName: fmt.Sprintf("%d", i), Red1: 1001, Red2: 1002,
+			Red3: 1003, Blue1: 1004, Blue2: 1005, Blue3: 1006}
+		assert.Nil(t, web.arena.Database.CreateMatch(&match))
+	}
+	match, _ := web.arena.Database.GetMatchByName("qualification", "1")
+	assert.Nil(t, web.commitMatchScore(match, model.BuildTestMatchResult(match.Id, 0), false))
+
+	recorder := web.postHttpResponse(
+		fmt.Sprintf("/match_review/%d/replay", match.Id),
+		"reason=fieldFault&requestedBy=Head Ref&notes=Field didn't start&numMatchesBefore=2",
+	)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	matchReplay, _ := web.arena.Database.GetPendingMatchReplay(match.Id)
+	if assert.NotNil(t, matchReplay) {
+		assert.Equal(t, model.FieldFaultReplay, matchReplay.Reason)
+		assert.Equal(t, "Head Ref", matchReplay.RequestedBy)
+		assert.Equal(t, "Field didn't start", matchReplay.Notes)
+	}
+	matches, replays, _ := web.arena.Database.GetMatchQueue("qualification")
+	if assert.Equal(t, 4, len(matches)) {
+		assert.Equal(t, "2", matches[0].DisplayName)
+		assert.Equal(t, "3", matches[1].DisplayName)
+		assert.Equal(t, "1", matches[2].DisplayName)
+		assert.NotNil(t, replays[2])
+	}
+	recorder = web.getHttpResponse("/match_review")
+	assert.Contains(t, recorder.Body.String(), "Replay queued: Field fault")
+
+	// Check the error cases.
+	replayUrl := fmt.Sprintf("/match_review/%d/replay", match.Id)
+	recorder = web.postHttpResponse(replayUrl, "reason=fieldFault&requestedBy=FTA")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "already been flagged for replay")
+	match2, _ := web.arena.Database.GetMatchByName("qualification", "2")
+	replayUrl = fmt.Sprintf("/match_review/%d/replay", match2.Id)
+	recorder = web.postHttpResponse(replayUrl, "reason=fieldFault&requestedBy=FTA")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "has not been played")
+	assert.Nil(t, web.commitMatchScore(match2, model.BuildTestMatchResult(match2.Id, 0), false))
+	recorder = web.postHttpResponse(replayUrl, "reason=weather&requestedBy=FTA")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "invalid replay reason")
+	recorder = web.postHttpResponse(replayUrl, "reason=arenaFault")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "is required")
+
+	// Check that playing the match again completes the replay.
+	assert.Nil(t, web.commitMatchScore(match, model.BuildTestMatchResult(match.Id, 0), false))
+	matchReplay, _ = web.arena.Database.GetPendingMatchReplay(match.Id)
+	assert.Nil(t, matchReplay)
+	matchReplays, _ := web.arena.Database.GetAllMatchReplays()
+	if assert.Equal(t, 1, len(matchReplays)) {
+		assert.Equal(t, 2, matchReplays[0].PlayNumber)
+		assert.False(t, matchReplays[0].IsPending())
+	}
+
+	// Check that a name must be typed when authentication is disabled, and that the logged-in user is used otherwise.
+	recorder = web.getHttpResponse("/match_review")
+	assert.Contains(t, recorder.Body.String(), "name=\"requestedBy\" required")
+	web.arena.EventSettings.AdminPassword = "admin"
+	recorder = web.postHttpResponse("/login", "username=admin&password=admin")
+	headers := map[string]string{"Cookie": recorder.Header().Get("Set-Cookie")}
+	recorder = web.getHttpResponseWithHeaders("/match_review", headers)
+	assert.NotContains(t, recorder.Body.String(), "name=\"requestedBy\" required")
+	recorder = web.postHttpResponseWithHeaders(replayUrl, "reason=arenaFault", headers)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	matchReplay, _ = web.arena.Database.GetPendingMatchReplay(match2.Id)
+	if assert.NotNil(t, matchReplay) {
+		assert.Equal(t, "admin", matchReplay.RequestedBy)
+	}
 }
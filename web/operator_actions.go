@@ -0,0 +1,213 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Web routes for reviewing the audit trail of match play commands, and helpers for recording it.
+
+package web
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/FRCTeam1987/crimson-arena/field"
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"github.com/mitchellh/mapstructure"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Names of the match states, matching those used by the client-side match timing code.
+var matchStateNames = map[field.MatchState]string{
+	field.PreMatch:      "PRE_MATCH",
+	field.StartMatch:    "START_MATCH",
+	field.WarmupPeriod:  "WARMUP_PERIOD",
+	field.AutoPeriod:    "AUTO_PERIOD",
+	field.PausePeriod:   "PAUSE_PERIOD",
+	field.TeleopPeriod:  "TELEOP_PERIOD",
+	field.PostMatch:     "POST_MATCH",
+	field.TimeoutActive: "TIMEOUT_ACTIVE",
+	field.PostTimeout:   "POST_TIMEOUT",
+	field.FieldFault:    "FIELD_FAULT",
+}
+
+// Shows the audit trail of match play commands, narrowed down by any filters given in the query string.
+func (web *Web) operatorActionsGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	filter := parseOperatorActionFilter(r)
+	actions, err := web.arena.Database.GetOperatorActions(filter)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	template, err := web.parseFiles("templates/operator_actions.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Filter   model.OperatorActionFilter
+		Actions  []model.OperatorAction
+		CsvQuery string
+	}{web.arena.EventSettings, filter, actions, r.URL.RawQuery}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Generates a CSV-formatted report of the audit trail of match play commands, using the same filters as the viewer.
+func (web *Web) operatorActionsCsvReportHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	actions, err := web.arena.Database.GetOperatorActions(parseOperatorActionFilter(r))
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Don't set the content type as "text/csv", as that will trigger an automatic download in the browser.
+	w.Header().Set("Content-Type", "text/plain")
+
+	// The free-form fields can contain commas, quotes and newlines, so let the CSV writer quote them as needed.
+	csvWriter := csv.NewWriter(w)
+	csvWriter.Write([]string{"Time", "Username", "Address", "Field", "Match", "Action", "Target", "Before", "After",
+		"Error"})
+	for _, action := range actions {
+		csvWriter.Write(
+			[]string{
+				action.Time.Local().String(),
+				action.Username,
+				action.Address,
+				strconv.Itoa(action.FieldId),
+				action.MatchName,
+				action.Action,
+				action.Target,
+				action.Before,
+				action.After,
+				action.Error,
+			},
+		)
+	}
+	csvWriter.Flush()
+	if err = csvWriter.Error(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+func parseOperatorActionFilter(r *http.Request) model.OperatorActionFilter {
+	return model.OperatorActionFilter{
+		Username:  r.URL.Query().Get("username"),
+		MatchName: r.URL.Query().Get("match"),
+		Action:    r.URL.Query().Get("action"),
+		Target:    r.URL.Query().Get("target"),
+	}
+}
+
+// Returns a record of the given match play command, capturing the value it is expected to change before it is
+// handled.
+func (web *Web) startOperatorAction(
+	session *model.UserSession, address, messageType string, data interface{},
+) *model.OperatorAction {
+	action := model.OperatorAction{
+		Time:      time.Now(),
+		Address:   address,
+		FieldId:   web.arena.FieldId(),
+		MatchId:   web.arena.CurrentMatch.Id,
+		MatchName: web.arena.CurrentMatch.TypePrefix() + web.arena.CurrentMatch.DisplayName,
+		Action:    messageType,
+		Target:    operatorActionTarget(messageType, data),
+	}
+	if session != nil {
+		action.Username = session.Username
+	}
+	action.Before = web.operatorActionValue(action.Action, action.Target)
+	return &action
+}
+
+// Captures the value the given command changed and saves the record of it, if there is one. Always returns nil so
+// that the caller can clear its reference to the finished record.
+func (web *Web) finishOperatorAction(action *model.OperatorAction) *model.OperatorAction {
+	if action == nil {
+		return nil
+	}
+	action.After = web.operatorActionValue(action.Action, action.Target)
+	if err := web.arena.Database.CreateOperatorAction(action); err != nil {
+		log.Printf("Failed to record operator action '%s': %v", action.Action, err)
+	}
+	return nil
+}
+
+// Returns the alliance station or other item that the given command applies to, or an empty string if there is none.
+func operatorActionTarget(messageType string, data interface{}) string {
+	switch messageType {
+	case "substituteTeam":
+		args := struct {
+			Position string
+		}{}
+		_ = mapstructure.Decode(data, &args)
+		return args.Position
+	case "overrideReadinessRule":
+		args := struct {
+			RuleId string
+		}{}
+		_ = mapstructure.Decode(data, &args)
+		return args.RuleId
+	case "toggleBypass":
+		station, _ := data.(string)
+		return station
+	}
+	return ""
+}
+
+// Returns a description of the current value of whatever the given command changes.
+func (web *Web) operatorActionValue(messageType, target string) string {
+	switch messageType {
+	case "substituteTeam", "toggleBypass":
+		allianceStation, ok := web.arena.AllianceStations[target]
+		if !ok {
+			return ""
+		}
+		if messageType == "substituteTeam" {
+			if allianceStation.Team == nil {
+				return ""
+			}
+			return strconv.Itoa(allianceStation.Team.Id)
+		}
+		return fmt.Sprintf("Bypass: %t, E-stop: %t", allianceStation.Bypass, allianceStation.Estop)
+	case "overrideReadinessRule":
+		for _, override := range web.arena.CurrentMatch.ReadinessOverrides {
+			if override.RuleId == target {
+				return fmt.Sprintf("Overridden by %s: %s", override.OverriddenBy, override.Reason)
+			}
+		}
+		return ""
+	case "signalVolunteers":
+		return strconv.FormatBool(web.arena.FieldVolunteers)
+	case "signalReset":
+		return strconv.FormatBool(web.arena.FieldReset)
+	case "setAudienceDisplay":
+		return web.arena.AudienceDisplayMode
+	case "setAllianceStationDisplay":
+		return web.arena.AllianceStationDisplayMode
+	case "setFieldLights":
+		return web.arena.FieldLights.GetCurrentStateAsString()
+	case "setTestMatchName":
+		return web.arena.CurrentMatch.DisplayName
+	case "updateRealtimeScore":
+		return fmt.Sprintf(
+			"Red: %d, Blue: %d",
+			web.arena.RedScore.Summarize(web.arena.BlueScore).Score,
+			web.arena.BlueScore.Summarize(web.arena.RedScore).Score,
+		)
+	}
+	return matchStateNames[web.arena.MatchState]
+}
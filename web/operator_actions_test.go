@@ -0,0 +1,90 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package web
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"github.com/FRCTeam1987/crimson-arena/websocket"
+	gorillawebsocket "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMatchPlayWebsocketRecordsOperatorActions(t *testing.T) {
+	web := setupTestWeb(t)
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/match_play/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+	readWebsocketMultiple(t, ws, 10)
+
+	ws.Write("toggleBypass", "R2")
+	readWebsocketType(t, ws, "arenaStatus")
+	ws.Write("toggleBypass", "R9")
+	assert.Contains(t, readWebsocketError(t, ws), "Invalid alliance station")
+	ws.Write("setAudienceDisplay", "intro")
+	readWebsocketType(t, ws, "audienceDisplayMode")
+
+	// Send a command that isn't recorded until later, to make sure the previous ones have been handled.
+	ws.Write("nonexistenttype", nil)
+	readWebsocketError(t, ws)
+
+	actions, _ := web.arena.Database.GetOperatorActions(model.OperatorActionFilter{Action: "toggleBypass"})
+	if assert.Equal(t, 2, len(actions)) {
+		assert.Equal(t, "R2", actions[0].Target)
+		assert.Equal(t, "Bypass: false, E-stop: false", actions[0].Before)
+		assert.Equal(t, "Bypass: true, E-stop: false", actions[0].After)
+		assert.Equal(t, "", actions[0].Error)
+		assert.Equal(t, "R9", actions[1].Target)
+		assert.Contains(t, actions[1].Error, "Invalid alliance station")
+	}
+	actions, _ = web.arena.Database.GetOperatorActions(model.OperatorActionFilter{Action: "setAudienceDisplay"})
+	if assert.Equal(t, 1, len(actions)) {
+		assert.Equal(t, "blank", actions[0].Before)
+		assert.Equal(t, "intro", actions[0].After)
+	}
+}
+
+func TestOperatorActions(t *testing.T) {
+	web := setupTestWeb(t)
+
+	actionTime := time.Unix(1000, 0)
+	web.arena.Database.CreateOperatorAction(&model.OperatorAction{Time: actionTime, Username: "admin",
+		Address: "10.0.100.5", FieldId: 1, MatchName: "Q12", Action: "toggleBypass", Target: "R2",
+		Before: "Bypass: false", After: "Bypass: true"})
+	web.arena.Database.CreateOperatorAction(&model.OperatorAction{Time: actionTime, Username: "admin",
+		Address: "10.0.100.5", FieldId: 1, MatchName: "Q13", Action: "abortMatch", Before: "AUTO_PERIOD",
+		After: "POST_MATCH"})
+
+	recorder := web.getHttpResponse("/operator_actions")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "toggleBypass")
+	assert.Contains(t, recorder.Body.String(), "abortMatch")
+	recorder = web.getHttpResponse("/operator_actions?match=Q13")
+	assert.Equal(t, 200, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "toggleBypass")
+	assert.Contains(t, recorder.Body.String(), "abortMatch")
+	assert.Contains(t, recorder.Body.String(), "/reports/csv/operator_actions?match=Q13")
+
+	recorder = web.getHttpResponse("/reports/csv/operator_actions?action=toggleBypass")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "text/plain", recorder.Header()["Content-Type"][0])
+	expectedBody := "Time,Username,Address,Field,Match,Action,Target,Before,After,Error\n" +
+		actionTime.Local().String() + ",admin,10.0.100.5,1,Q12,toggleBypass,R2,Bypass: false,Bypass: true,\n"
+	assert.Equal(t, expectedBody, recorder.Body.String())
+
+	// Check that fields containing commas, quotes or newlines are quoted so that they don't break the row.
+	web.arena.Database.CreateOperatorAction(&model.OperatorAction{Time: actionTime, Username: "admin",
+		Address: "10.0.100.5", FieldId: 1, MatchName: "Q14", Action: "startMatch",
+		After: "Overridden by Jo \"FTA\", Smith: radio\nrebooted"})
+	recorder = web.getHttpResponse("/reports/csv/operator_actions?action=startMatch")
+	assert.Equal(t, 200, recorder.Code)
+	expectedBody = "Time,Username,Address,Field,Match,Action,Target,Before,After,Error\n" +
+		actionTime.Local().String() + ",admin,10.0.100.5,1,Q14,startMatch,,," +
+		"\"Overridden by Jo \"\"FTA\"\", Smith: radio\nrebooted\",\n"
+	assert.Equal(t, expectedBody, recorder.Body.String())
+}
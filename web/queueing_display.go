@@ -24,7 +24,7 @@ func (web *Web) queueingDisplayHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	matches, err := web.arena.Database.GetMatchesByType(web.arena.CurrentMatch.Type)
+	matches, replays, err := web.arena.Database.GetMatchQueue(web.arena.CurrentMatch.Type)
 	if err != nil {
 		handleWebErr(w, err)
 		return
@@ -36,16 +36,15 @@ func (web *Web) queueingDisplayHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var upcomingMatches []model.Match
+	var upcomingReplays []*model.MatchReplay
 	var redOffFieldTeamsByMatch, blueOffFieldTeamsByMatch [][]int
 	if err != nil {
 		handleWebErr(w, err)
 		return
 	}
 	for i, match := range matches {
-		if match.IsComplete() {
-			continue
-		}
 		upcomingMatches = append(upcomingMatches, match)
+		upcomingReplays = append(upcomingReplays, replays[i])
 		redOffFieldTeams, blueOffFieldTeams, err := web.arena.Database.GetOffFieldTeamIds(&match)
 		if err != nil {
 			handleWebErr(w, err)
@@ -58,8 +57,10 @@ func (web *Web) queueingDisplayHandler(w http.ResponseWriter, r *http.Request) {
 			break
 		}
 
-		// Don't include any more matches if there is a significant gap before the next one.
-		if i+1 < len(matches) && matches[i+1].Time.Sub(match.Time) > field.MaxMatchGapMin*time.Minute {
+		// Don't include any more matches if there is a significant gap before the next one. Replays keep the time of
+		// their original match, so they don't count.
+		if i+1 < len(matches) && replays[i] == nil && replays[i+1] == nil &&
+			matches[i+1].Time.Sub(match.Time) > field.MaxMatchGapMin*time.Minute {
 			break
 		}
 	}
@@ -74,12 +75,14 @@ func (web *Web) queueingDisplayHandler(w http.ResponseWriter, r *http.Request) {
 		*model.EventSettings
 		MatchTypePrefix   string
 		Matches           []model.Match
+		Replays           []*model.MatchReplay
 		RedOffFieldTeams  [][]int
 		BlueOffFieldTeams [][]int
 	}{
 		web.arena.EventSettings,
 		web.arena.CurrentMatch.TypePrefix(),
 		upcomingMatches,
+		upcomingReplays,
 		redOffFieldTeamsByMatch,
 		blueOffFieldTeamsByMatch,
 	}
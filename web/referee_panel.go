@@ -0,0 +1,86 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Web handlers for the head referee panel, used to review and sign off on the result of each match.
+
+package web
+
+import (
+	"fmt"
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"github.com/FRCTeam1987/crimson-arena/websocket"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// Renders the head referee interface for reviewing the pending match result.
+func (web *Web) refereePanelHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	template, err := web.parseFiles("templates/referee_panel.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		GameDefinition *game.GameDefinition
+	}{web.arena.EventSettings, game.CurrentGame}
+	err = template.ExecuteTemplate(w, "base_no_navbar", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// The websocket endpoint for the head referee interface client to send approvals and receive status updates.
+func (web *Web) refereePanelWebsocketHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	ws, err := websocket.NewWebsocket(w, r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	defer ws.Close()
+
+	// Subscribe the websocket to the notifiers whose messages will be passed on to the client, in a separate goroutine.
+	go ws.HandleNotifiers(web.arena.MatchTimingNotifier, web.arena.MatchLoadNotifier, web.arena.MatchTimeNotifier,
+		web.arena.RealtimeScoreNotifier, web.arena.HeadRefStatusNotifier, web.arena.ReloadDisplaysNotifier)
+
+	// Loop, waiting for commands and responding to them, until the client closes the connection.
+	for {
+		command, data, err := ws.Read()
+		if err != nil {
+			if err == io.EOF {
+				// Client has closed the connection; nothing to do here.
+				return
+			}
+			log.Println(err)
+			return
+		}
+
+		value, ok := data.(string)
+		if !ok {
+			ws.WriteError(fmt.Sprintf("Failed to parse '%s' message.", command))
+			continue
+		}
+		switch command {
+		case "approveResult":
+			err = web.arena.ApproveResult(strings.TrimSpace(value))
+		case "returnResult":
+			err = web.arena.ReturnResult(strings.TrimSpace(value))
+		default:
+			err = fmt.Errorf("Invalid message type '%s'.", command)
+		}
+		if err != nil {
+			ws.WriteError(err.Error())
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package web
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/field"
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"github.com/FRCTeam1987/crimson-arena/websocket"
+	gorillawebsocket "github.com/gorilla/websocket"
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRefereePanel(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/panels/referee")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Head Referee Panel - Untitled Event")
+}
+
+func TestRefereePanelWebsocket(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.RequireHeadRefApproval = true
+	match := model.Match{Type: "qualification", DisplayName: "1", Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5,
+		Blue3: 6}
+	web.arena.Database.CreateMatch(&match)
+	assert.Nil(t, web.arena.LoadMatch(&match))
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/panels/referee/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+
+	// Should get a few status updates right after connection.
+	readWebsocketType(t, ws, "matchTiming")
+	readWebsocketType(t, ws, "matchLoad")
+	readWebsocketType(t, ws, "matchTime")
+	readWebsocketType(t, ws, "realtimeScore")
+	readWebsocketType(t, ws, "headRefStatus")
+
+	ws.Write("approveResult", "Alice")
+	assert.Contains(t, readWebsocketError(t, ws), "not over")
+	ws.Write("nonexistenttype", "")
+	assert.Contains(t, readWebsocketError(t, ws), "Invalid message type")
+
+	matchPlayConn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/match_play/websocket", nil)
+	assert.Nil(t, err)
+	defer matchPlayConn.Close()
+	matchPlayWs := websocket.NewTestWebsocket(matchPlayConn)
+	readWebsocketMultiple(t, matchPlayWs, 10)
+
+	// Check that the result can't be committed until the head referee has approved it.
+	web.arena.MatchState = field.PostMatch
+	matchPlayWs.Write("commitResults", nil)
+	assert.Contains(t, readWebsocketError(t, matchPlayWs), "head referee has not approved")
+
+	ws.Write("returnResult", "Recount the blue endgame")
+	var status struct {
+		Approved     bool
+		ReturnReason string
+	}
+	assert.Nil(t, mapstructure.Decode(readWebsocketType(t, ws, "headRefStatus"), &status))
+	assert.False(t, status.Approved)
+	assert.Equal(t, "Recount the blue endgame", status.ReturnReason)
+	readWebsocketType(t, matchPlayWs, "headRefStatus")
+
+	ws.Write("approveResult", "Alice")
+	assert.Nil(t, mapstructure.Decode(readWebsocketType(t, ws, "headRefStatus"), &status))
+	assert.True(t, status.Approved)
+	assert.Equal(t, "", status.ReturnReason)
+	readWebsocketType(t, matchPlayWs, "headRefStatus")
+
+	// Editing the result after approval revokes it.
+	matchPlayWs.Write("updateRealtimeScore", map[string]interface{}{
+		"red":  map[string]int{"auto": 20},
+		"blue": map[string]int{"auto": 10},
+	})
+	readWebsocketType(t, ws, "realtimeScore")
+	assert.Nil(t, mapstructure.Decode(readWebsocketType(t, ws, "headRefStatus"), &status))
+	assert.False(t, status.Approved)
+	matchPlayWs.Write("commitResults", nil)
+	readWebsocketMultiple(t, matchPlayWs, 3) // arenaStatus, realtimeScore, headRefStatus
+	assert.Contains(t, readWebsocketError(t, matchPlayWs), "head referee has not approved")
+
+	ws.Write("approveResult", "Alice")
+	readWebsocketType(t, ws, "headRefStatus")
+	readWebsocketType(t, matchPlayWs, "headRefStatus")
+	matchPlayWs.Write("commitResults", nil)
+	readWebsocketMultiple(t, matchPlayWs, 5)
+	matchResult, err := web.arena.Database.GetMatchResultForMatch(match.Id)
+	assert.Nil(t, err)
+	if assert.NotNil(t, matchResult) {
+		assert.Equal(t, "Alice", matchResult.ApprovedBy)
+		assert.False(t, matchResult.ApprovedAt.IsZero())
+		assert.Equal(t, 20, matchResult.RedScore.ElementCounts["auto"])
+	}
+}
@@ -50,8 +50,8 @@ func (web *Web) rankingsPdfReportHandler(w http.ResponseWriter, r *http.Request)
 	}
 
 	// The widths of the table columns in mm, stored here so that they can be referenced for each row.
-	colWidths := map[string]float64{"Rank": 13, "Team": 22, "RP": 23, "Auto": 23, "Endgame": 23, "Teleop": 23,
-		"W-L-T": 23, "DQ": 23, "Played": 23}
+	colWidths := map[string]float64{"Rank": 12, "Team": 16, "RP": 16, "Bonus RP": 17, "Auto": 16, "Endgame": 17,
+		"Teleop": 16, "W-L-T": 17, "DQ": 12, "Played": 15, "Tiebreaker": 42}
 	rowHeight := 6.5
 
 	pdf := gofpdf.New("P", "mm", "Letter", "font")
@@ -64,11 +64,14 @@ func (web *Web) rankingsPdfReportHandler(w http.ResponseWriter, r *http.Request)
 	pdf.CellFormat(colWidths["Rank"], rowHeight, "Rank", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["Team"], rowHeight, "Team", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["RP"], rowHeight, "RP", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Bonus RP"], rowHeight, "Bonus RP", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["Auto"], rowHeight, "Auto", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["Endgame"], rowHeight, "Endgame", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["Teleop"], rowHeight, "Teleop", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["W-L-T"], rowHeight, "W-L-T", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Played"], rowHeight, "Played", "1", 1, "C", true, 0, "")
+	pdf.CellFormat(colWidths["DQ"], rowHeight, "DQ", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Played"], rowHeight, "Played", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Tiebreaker"], rowHeight, "Tiebreaker", "1", 1, "C", true, 0, "")
 	for _, ranking := range rankings {
 		// Render ranking info row.
 		pdf.SetFont("Arial", "B", 10)
@@ -76,12 +79,17 @@ func (web *Web) rankingsPdfReportHandler(w http.ResponseWriter, r *http.Request)
 		pdf.SetFont("Arial", "", 10)
 		pdf.CellFormat(colWidths["Team"], rowHeight, strconv.Itoa(ranking.TeamId), "1", 0, "C", false, 0, "")
 		pdf.CellFormat(colWidths["RP"], rowHeight, strconv.Itoa(ranking.RankingPoints), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(
+			colWidths["Bonus RP"], rowHeight, strconv.Itoa(ranking.BonusRankingPoints), "1", 0, "C", false, 0, "",
+		)
 		pdf.CellFormat(colWidths["Auto"], rowHeight, strconv.Itoa(ranking.AutoPoints), "1", 0, "C", false, 0, "")
 		pdf.CellFormat(colWidths["Endgame"], rowHeight, strconv.Itoa(ranking.EndgamePoints), "1", 0, "C", false, 0, "")
 		pdf.CellFormat(colWidths["Teleop"], rowHeight, strconv.Itoa(ranking.TeleopPoints), "1", 0, "C", false, 0, "")
 		record := fmt.Sprintf("%d-%d-%d", ranking.Wins, ranking.Losses, ranking.Ties)
 		pdf.CellFormat(colWidths["W-L-T"], rowHeight, record, "1", 0, "C", false, 0, "")
-		pdf.CellFormat(colWidths["Played"], rowHeight, strconv.Itoa(ranking.Played), "1", 1, "C", false, 0, "")
+		pdf.CellFormat(colWidths["DQ"], rowHeight, strconv.Itoa(ranking.Disqualifications), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths["Played"], rowHeight, strconv.Itoa(ranking.Played), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths["Tiebreaker"], rowHeight, ranking.Tiebreaker, "1", 1, "C", false, 0, "")
 	}
 
 	// Write out the PDF file as the HTTP response.
@@ -351,7 +359,7 @@ func drawPdfLogo(pdf gofpdf.Pdf, x float64, y float64, width float64) {
 // Generates a CSV-formatted report of the match schedule.
 func (web *Web) scheduleCsvReportHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	matches, err := web.arena.Database.GetMatchesByType(vars["type"])
+	matches, replays, err := web.getScheduleWithReplays(vars["type"])
 	if err != nil {
 		handleWebErr(w, err)
 		return
@@ -364,7 +372,11 @@ func (web *Web) scheduleCsvReportHandler(w http.ResponseWriter, r *http.Request)
 		handleWebErr(w, err)
 		return
 	}
-	err = template.ExecuteTemplate(w, "schedule.csv", matches)
+	data := struct {
+		Matches []model.Match
+		Replays []*model.MatchReplay
+	}{matches, replays}
+	err = template.ExecuteTemplate(w, "schedule.csv", data)
 	if err != nil {
 		handleWebErr(w, err)
 		return
@@ -386,11 +398,18 @@ func (web *Web) schedulePdfReportHandler(w http.ResponseWriter, r *http.Request)
 	}
 	matchesPerTeam := 0
 	if len(teams) > 0 {
-		matchesPerTeam = len(matches) * tournament.TeamsPerMatch / len(teams)
+		matchesPerTeam = len(matches) * 2 * web.arena.EventSettings.TeamsPerAlliance / len(teams)
+	}
+	matches, replays, err := web.getScheduleWithReplays(vars["type"])
+	if err != nil {
+		handleWebErr(w, err)
+		return
 	}
 
-	// The widths of the table columns in mm, stored here so that they can be referenced for each row.
-	colWidths := map[string]float64{"Time": 35, "Type": 25, "Match": 15, "Team": 20}
+	// The widths of the table columns in mm, stored here so that they can be referenced for each row. The team columns
+	// share the remaining width of the page.
+	stations := model.AllianceStationNames(web.arena.EventSettings.TeamsPerAlliance)
+	colWidths := map[string]float64{"Time": 35, "Type": 25, "Match": 15, "Team": 120 / float64(len(stations))}
 	rowHeight := 6.5
 
 	pdf := gofpdf.New("P", "mm", "Letter", "font")
@@ -403,25 +422,24 @@ func (web *Web) schedulePdfReportHandler(w http.ResponseWriter, r *http.Request)
 	pdf.CellFormat(colWidths["Time"], rowHeight, "Time", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["Type"], rowHeight, "Type", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["Match"], rowHeight, "Match", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Team"], rowHeight, "Red 1", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Team"], rowHeight, "Red 2", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Team"], rowHeight, "Red 3", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Team"], rowHeight, "Blue 1", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Team"], rowHeight, "Blue 2", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Team"], rowHeight, "Blue 3", "1", 1, "C", true, 0, "")
+	for i, station := range stations {
+		pdf.CellFormat(colWidths["Team"], rowHeight, stationDisplayName(station), "1", lineBreakAfter(i, stations), "C",
+			true, 0, "")
+	}
 	pdf.SetFont("Arial", "", 10)
-	for _, match := range matches {
+	for i, match := range matches {
 		height := rowHeight
 		borderStr := "1"
 		alignStr := "CM"
 		surrogate := false
-		if match.Red1IsSurrogate || match.Red2IsSurrogate || match.Red3IsSurrogate ||
-			match.Blue1IsSurrogate || match.Blue2IsSurrogate || match.Blue3IsSurrogate {
+		for _, station := range stations {
+			surrogate = surrogate || match.IsSurrogateForStation(station)
+		}
+		if surrogate {
 			// If the match contains surrogates, the row needs to be taller to fit some text beneath team numbers.
 			height = 5.0
 			borderStr = "LTR"
 			alignStr = "CB"
-			surrogate = true
 		}
 
 		// Capitalize match types.
@@ -435,17 +453,18 @@ func (web *Web) schedulePdfReportHandler(w http.ResponseWriter, r *http.Request)
 			}
 		}
 
-		// Render match info row.
-		pdf.CellFormat(colWidths["Time"], height, match.Time.Local().Format("Mon 1/02 03:04 PM"), borderStr, 0,
-			alignStr, false, 0, "")
+		// Render match info row, with replays marked in place of a time since they run whenever their slot comes up.
+		timeText := match.Time.Local().Format("Mon 1/02 03:04 PM")
+		if replays[i] != nil {
+			timeText = "Replay"
+		}
+		pdf.CellFormat(colWidths["Time"], height, timeText, borderStr, 0, alignStr, false, 0, "")
 		pdf.CellFormat(colWidths["Type"], height, matchType, borderStr, 0, alignStr, false, 0, "")
 		pdf.CellFormat(colWidths["Match"], height, match.DisplayName, borderStr, 0, alignStr, false, 0, "")
-		pdf.CellFormat(colWidths["Team"], height, formatTeam(match.Red1), borderStr, 0, alignStr, false, 0, "")
-		pdf.CellFormat(colWidths["Team"], height, formatTeam(match.Red2), borderStr, 0, alignStr, false, 0, "")
-		pdf.CellFormat(colWidths["Team"], height, formatTeam(match.Red3), borderStr, 0, alignStr, false, 0, "")
-		pdf.CellFormat(colWidths["Team"], height, formatTeam(match.Blue1), borderStr, 0, alignStr, false, 0, "")
-		pdf.CellFormat(colWidths["Team"], height, formatTeam(match.Blue2), borderStr, 0, alignStr, false, 0, "")
-		pdf.CellFormat(colWidths["Team"], height, formatTeam(match.Blue3), borderStr, 1, alignStr, false, 0, "")
+		for j, station := range stations {
+			pdf.CellFormat(colWidths["Team"], height, formatTeam(match.TeamIdForStation(station)), borderStr,
+				lineBreakAfter(j, stations), alignStr, false, 0, "")
+		}
 		if surrogate {
 			// Render the text that indicates which teams are surrogates.
 			height := 4.0
@@ -453,18 +472,10 @@ func (web *Web) schedulePdfReportHandler(w http.ResponseWriter, r *http.Request)
 			pdf.CellFormat(colWidths["Time"], height, "", "LBR", 0, "C", false, 0, "")
 			pdf.CellFormat(colWidths["Type"], height, "", "LBR", 0, "C", false, 0, "")
 			pdf.CellFormat(colWidths["Match"], height, "", "LBR", 0, "C", false, 0, "")
-			pdf.CellFormat(colWidths["Team"], height, surrogateText(match.Red1IsSurrogate), "LBR", 0, "CT", false, 0,
-				"")
-			pdf.CellFormat(colWidths["Team"], height, surrogateText(match.Red2IsSurrogate), "LBR", 0, "CT", false, 0,
-				"")
-			pdf.CellFormat(colWidths["Team"], height, surrogateText(match.Red3IsSurrogate), "LBR", 0, "CT", false, 0,
-				"")
-			pdf.CellFormat(colWidths["Team"], height, surrogateText(match.Blue1IsSurrogate), "LBR", 0, "CT", false, 0,
-				"")
-			pdf.CellFormat(colWidths["Team"], height, surrogateText(match.Blue2IsSurrogate), "LBR", 0, "CT", false, 0,
-				"")
-			pdf.CellFormat(colWidths["Team"], height, surrogateText(match.Blue3IsSurrogate), "LBR", 1, "CT", false, 0,
-				"")
+			for j, station := range stations {
+				pdf.CellFormat(colWidths["Team"], height, surrogateText(match.IsSurrogateForStation(station)), "LBR",
+					lineBreakAfter(j, stations), "CT", false, 0, "")
+			}
 			pdf.SetFont("Arial", "", 10)
 		}
 	}
@@ -483,6 +494,153 @@ func (web *Web) schedulePdfReportHandler(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+type ReplayReportItem struct {
+	model.MatchReplay
+	Match *model.Match
+}
+
+// Generates a CSV-formatted report of all replays requested over the course of the event and their reasons.
+func (web *Web) replaysCsvReportHandler(w http.ResponseWriter, r *http.Request) {
+	items, err := web.buildReplayReport()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Don't set the content type as "text/csv", as that will trigger an automatic download in the browser.
+	w.Header().Set("Content-Type", "text/plain")
+	template, err := web.parseFiles("templates/replays.csv")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	err = template.ExecuteTemplate(w, "replays.csv", items)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Generates a PDF-formatted report of all replays requested over the course of the event and their reasons.
+func (web *Web) replaysPdfReportHandler(w http.ResponseWriter, r *http.Request) {
+	items, err := web.buildReplayReport()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// The widths of the table columns in mm, stored here so that they can be referenced for each row.
+	colWidths := map[string]float64{"Match": 20, "Reason": 32, "RequestedBy": 35, "Time": 35, "Status": 23,
+		"Notes": 50}
+	rowHeight := 6.5
+
+	pdf := gofpdf.New("P", "mm", "Letter", "font")
+	pdf.AddPage()
+
+	// Render table header row.
+	pdf.SetFont("Arial", "B", 10)
+	pdf.SetFillColor(220, 220, 220)
+	pdf.CellFormat(195, rowHeight, "Match Replays - "+web.arena.EventSettings.Name, "", 1, "C", false, 0, "")
+	pdf.CellFormat(colWidths["Match"], rowHeight, "Match", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Reason"], rowHeight, "Reason", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["RequestedBy"], rowHeight, "Requested By", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Time"], rowHeight, "Requested At", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Status"], rowHeight, "Status", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Notes"], rowHeight, "Notes", "1", 1, "C", true, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	for _, item := range items {
+		status := "Pending"
+		if !item.IsPending() {
+			status = fmt.Sprintf("Play %d", item.PlayNumber)
+		}
+		pdf.CellFormat(colWidths["Match"], rowHeight, item.Match.TypePrefix()+item.Match.DisplayName, "1", 0, "C",
+			false, 0, "")
+		pdf.CellFormat(colWidths["Reason"], rowHeight, item.Reason.DisplayName(), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths["RequestedBy"], rowHeight, item.RequestedBy, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths["Time"], rowHeight, item.RequestedAt.Local().Format("Mon 1/02 03:04 PM"), "1", 0,
+			"C", false, 0, "")
+		pdf.CellFormat(colWidths["Status"], rowHeight, status, "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths["Notes"], rowHeight, item.Notes, "1", 1, "L", false, 0, "")
+	}
+
+	// Write out the PDF file as the HTTP response.
+	w.Header().Set("Content-Type", "application/pdf")
+	err = pdf.Output(w)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Returns every replay requested at the event along with the match it applies to, in the order they were requested.
+func (web *Web) buildReplayReport() ([]ReplayReportItem, error) {
+	matchReplays, err := web.arena.Database.GetAllMatchReplays()
+	if err != nil {
+		return nil, err
+	}
+
+	var items []ReplayReportItem
+	for _, matchReplay := range matchReplays {
+		match, err := web.arena.Database.GetMatchById(matchReplay.MatchId)
+		if err != nil {
+			return nil, err
+		}
+		if match == nil {
+			// The match has since been deleted along with the rest of its schedule.
+			continue
+		}
+		items = append(items, ReplayReportItem{matchReplay, match})
+	}
+	return items, nil
+}
+
+// Returns all matches of the given type in schedule order, with each pending replay inserted at the point in the
+// schedule at which it is queued to run. The returned replays are parallel to the matches, and nil for matches that
+// aren't replays.
+func (web *Web) getScheduleWithReplays(matchType string) ([]model.Match, []*model.MatchReplay, error) {
+	matches, err := web.arena.Database.GetMatchesByType(matchType)
+	if err != nil {
+		return nil, nil, err
+	}
+	queuedMatches, queuedReplays, err := web.arena.Database.GetMatchQueue(matchType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Group the queued replays by the unplayed match that they come before.
+	replaysBeforeMatchId := make(map[int][]*model.MatchReplay)
+	var replays []*model.MatchReplay
+	for i, match := range queuedMatches {
+		if queuedReplays[i] != nil {
+			replays = append(replays, queuedReplays[i])
+		} else {
+			replaysBeforeMatchId[match.Id] = replays
+			replays = nil
+		}
+	}
+	remainingReplays := replays
+
+	var scheduleMatches []model.Match
+	var scheduleReplays []*model.MatchReplay
+	appendReplays := func(replays []*model.MatchReplay) {
+		for _, replay := range replays {
+			for _, match := range matches {
+				if match.Id == replay.MatchId {
+					scheduleMatches = append(scheduleMatches, match)
+					scheduleReplays = append(scheduleReplays, replay)
+				}
+			}
+		}
+	}
+	for _, match := range matches {
+		appendReplays(replaysBeforeMatchId[match.Id])
+		scheduleMatches = append(scheduleMatches, match)
+		scheduleReplays = append(scheduleReplays, nil)
+	}
+	appendReplays(remainingReplays)
+	return scheduleMatches, scheduleReplays, nil
+}
+
 // Generates a CSV-formatted report of the team list.
 func (web *Web) teamsCsvReportHandler(w http.ResponseWriter, r *http.Request) {
 	teams, err := web.arena.Database.GetAllTeams()
@@ -491,6 +649,12 @@ func (web *Web) teamsCsvReportHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	contributions, err := tournament.CalculateTeamContributions(web.arena.Database)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
 	// Don't set the content type as "text/csv", as that will trigger an automatic download in the browser.
 	w.Header().Set("Content-Type", "text/plain")
 	template, err := web.parseFiles("templates/teams.csv")
@@ -498,7 +662,12 @@ func (web *Web) teamsCsvReportHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
-	err = template.ExecuteTemplate(w, "teams.csv", teams)
+	data := struct {
+		Teams             []model.Team
+		ShowContributions bool
+		Contributions     map[int]game.TeamContribution
+	}{teams, game.CurrentGame.HasPerRobotElements(), contributions}
+	err = template.ExecuteTemplate(w, "teams.csv", data)
 	if err != nil {
 		handleWebErr(w, err)
 		return
@@ -514,6 +683,12 @@ func (web *Web) teamsPdfReportHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	showHasConnected := r.URL.Query().Get("showHasConnected") == "true"
+	showContributions := game.CurrentGame.HasPerRobotElements()
+	contributions, err := tournament.CalculateTeamContributions(web.arena.Database)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
 
 	// The widths of the table columns in mm, stored here so that they can be referenced for each row.
 	var colWidths map[string]float64
@@ -522,6 +697,12 @@ func (web *Web) teamsPdfReportHandler(w http.ResponseWriter, r *http.Request) {
 	} else {
 		colWidths = map[string]float64{"Id": 12, "Name": 80, "Location": 80, "RookieYear": 23}
 	}
+	if showContributions {
+		// Make room for the contribution column by narrowing the free-text columns.
+		colWidths["Contribution"] = 24
+		colWidths["Name"] -= 12
+		colWidths["Location"] -= 12
+	}
 	rowHeight := 6.5
 
 	pdf := gofpdf.New("P", "mm", "Letter", "font")
@@ -534,12 +715,14 @@ func (web *Web) teamsPdfReportHandler(w http.ResponseWriter, r *http.Request) {
 	pdf.CellFormat(colWidths["Id"], rowHeight, "Team", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["Name"], rowHeight, "Name", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["Location"], rowHeight, "Location", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["RookieYear"], rowHeight, "Rookie Year", "1", 0, "C", true, 0, "")
 	if showHasConnected {
-		pdf.CellFormat(colWidths["RookieYear"], rowHeight, "Rookie Year", "1", 0, "C", true, 0, "")
-		pdf.CellFormat(colWidths["HasConnected"], rowHeight, "Connected?", "1", 1, "C", true, 0, "")
-	} else {
-		pdf.CellFormat(colWidths["RookieYear"], rowHeight, "Rookie Year", "1", 1, "C", true, 0, "")
+		pdf.CellFormat(colWidths["HasConnected"], rowHeight, "Connected?", "1", 0, "C", true, 0, "")
 	}
+	if showContributions {
+		pdf.CellFormat(colWidths["Contribution"], rowHeight, "Contrib. Pts", "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(rowHeight)
 	pdf.SetFont("Arial", "", 10)
 	for _, team := range teams {
 		// Render team info row.
@@ -547,16 +730,19 @@ func (web *Web) teamsPdfReportHandler(w http.ResponseWriter, r *http.Request) {
 		pdf.CellFormat(colWidths["Name"], rowHeight, team.Nickname, "1", 0, "L", false, 0, "")
 		location := fmt.Sprintf("%s, %s, %s", team.City, team.StateProv, team.Country)
 		pdf.CellFormat(colWidths["Location"], rowHeight, location, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(colWidths["RookieYear"], rowHeight, strconv.Itoa(team.RookieYear), "1", 0, "L", false, 0, "")
 		if showHasConnected {
-			pdf.CellFormat(colWidths["RookieYear"], rowHeight, strconv.Itoa(team.RookieYear), "1", 0, "L", false, 0, "")
 			var hasConnected string
 			if team.HasConnected {
 				hasConnected = "Yes"
 			}
-			pdf.CellFormat(colWidths["HasConnected"], rowHeight, hasConnected, "1", 1, "L", false, 0, "")
-		} else {
-			pdf.CellFormat(colWidths["RookieYear"], rowHeight, strconv.Itoa(team.RookieYear), "1", 1, "L", false, 0, "")
+			pdf.CellFormat(colWidths["HasConnected"], rowHeight, hasConnected, "1", 0, "L", false, 0, "")
+		}
+		if showContributions {
+			points := strconv.Itoa(contributions[team.Id].Points)
+			pdf.CellFormat(colWidths["Contribution"], rowHeight, points, "1", 0, "L", false, 0, "")
 		}
+		pdf.Ln(rowHeight)
 	}
 
 	// Write out the PDF file as the HTTP response.
@@ -707,3 +893,20 @@ func surrogateText(isSurrogate bool) string {
 		return ""
 	}
 }
+
+// Returns the human-readable name of the given alliance station (e.g. "Red 1" for "R1").
+func stationDisplayName(station string) string {
+	if station[0] == 'R' {
+		return "Red " + station[1:]
+	}
+	return "Blue " + station[1:]
+}
+
+// Returns the PDF cell line break setting for the cell of the given index within the list of alliance stations, so
+// that the row ends after the last station.
+func lineBreakAfter(index int, stations []string) int {
+	if index == len(stations)-1 {
+		return 1
+	}
+	return 0
+}
@@ -17,14 +17,16 @@ func TestRankingsCsvReport(t *testing.T) {
 
 	ranking1 := game.TestRanking2()
 	ranking2 := game.TestRanking1()
+	ranking2.Tiebreaker = "Avg Auto"
 	web.arena.Database.CreateRanking(ranking1)
 	web.arena.Database.CreateRanking(ranking2)
 
 	recorder := web.getHttpResponse("/reports/csv/rankings")
 	assert.Equal(t, 200, recorder.Code)
 	assert.Equal(t, "text/plain", recorder.Header()["Content-Type"][0])
-	expectedBody := "Rank,TeamId,RankingPoints,AutoPoints,EndgamePoints,TeleopPoints,Wins,Losses,Ties," +
-		"Played\n1,254,20,625,90,554,3,2,1,10\n2,1114,18,700,625,90,1,3,2,10\n\n"
+	expectedBody := "Rank,TeamId,RankingPoints,BonusRankingPoints,AutoPoints,EndgamePoints,TeleopPoints,Wins,Losses," +
+		"Ties,Disqualifications,Played,Tiebreaker\n1,254,20,4,625,90,554,3,2,1,0,10,Avg Auto\n" +
+		"2,1114,18,3,700,625,90,1,3,2,0,10,\n\n"
 	assert.Equal(t, expectedBody, recorder.Body.String())
 }
 
@@ -61,9 +63,24 @@ func TestScheduleCsvReport(t *testing.T) {
 	assert.Equal(t, 200, recorder.Code)
 	assert.Equal(t, "text/plain", recorder.Header()["Content-Type"][0])
 	expectedBody := "Match,Type,Time,Red1,Red1IsSurrogate,Red2,Red2IsSurrogate,Red3,Red3IsSurrogate,Blue1," +
-		"Blue1IsSurrogate,Blue2,Blue2IsSurrogate,Blue3,Blue3IsSurrogate\n1,qualification," + match1Time.String() +
-		",1,false,2,false,3,false,4,true,5,true,6,true\n2,qualification," + match2Time.String() +
-		",7,true,8,true,9,true,10,false,11,false,12,false\n\n"
+		"Blue1IsSurrogate,Blue2,Blue2IsSurrogate,Blue3,Blue3IsSurrogate,Replay\n1,qualification," +
+		match1Time.String() + ",1,false,2,false,3,false,4,true,5,true,6,true,\n2,qualification," +
+		match2Time.String() + ",7,true,8,true,9,true,10,false,11,false,12,false,\n\n"
+	assert.Equal(t, expectedBody, recorder.Body.String())
+
+	// Check that a replay of the first match shows up ahead of the second match.
+	match1.Status = game.RedWonMatch
+	web.arena.Database.UpdateMatch(&match1)
+	web.arena.Database.QueueMatchReplay(
+		&model.MatchReplay{MatchId: match1.Id, Reason: model.FieldFaultReplay}, "qualification", 0,
+	)
+	recorder = web.getHttpResponse("/reports/csv/schedule/qualification")
+	assert.Equal(t, 200, recorder.Code)
+	expectedBody = "Match,Type,Time,Red1,Red1IsSurrogate,Red2,Red2IsSurrogate,Red3,Red3IsSurrogate,Blue1," +
+		"Blue1IsSurrogate,Blue2,Blue2IsSurrogate,Blue3,Blue3IsSurrogate,Replay\n1,qualification," +
+		match1Time.String() + ",1,false,2,false,3,false,4,true,5,true,6,true,\n1,qualification," +
+		match1Time.String() + ",1,false,2,false,3,false,4,true,5,true,6,true,\"Field fault\"\n2,qualification," +
+		match2Time.String() + ",7,true,8,true,9,true,10,false,11,false,12,false,\n\n"
 	assert.Equal(t, expectedBody, recorder.Body.String())
 }
 
@@ -83,6 +100,29 @@ func TestSchedulePdfReport(t *testing.T) {
 	assert.Equal(t, "application/pdf", recorder.Header()["Content-Type"][0])
 }
 
+func TestReplaysCsvReport(t *testing.T) {
+	web := setupTestWeb(t)
+
+	match := model.Match{Type: "qualification", DisplayName: "12", Status: game.RedWonMatch}
+	web.arena.Database.CreateMatch(&match)
+	requestedAt := time.Unix(1000, 0)
+	web.arena.Database.CreateMatchReplay(&model.MatchReplay{MatchId: match.Id, Reason: model.RefereeDecisionReplay,
+		RequestedBy: "Head Ref", Notes: "Overturned DQ", RequestedAt: requestedAt})
+
+	recorder := web.getHttpResponse("/reports/csv/replays")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "text/plain", recorder.Header()["Content-Type"][0])
+	expectedBody := "Match,Type,Reason,RequestedBy,RequestedAt,Notes,Completed,PlayNumber,CompletedAt\n12," +
+		"qualification,\"Referee decision\",\"Head Ref\"," + requestedAt.Local().String() +
+		",\"Overturned DQ\",false,0,\n"
+	assert.Equal(t, expectedBody, recorder.Body.String())
+
+	// Can't really parse the PDF content and check it, so just check that what's sent back is a PDF.
+	recorder = web.getHttpResponse("/reports/pdf/replays")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/pdf", recorder.Header()["Content-Type"][0])
+}
+
 func TestTeamsCsvReport(t *testing.T) {
 	web := setupTestWeb(t)
 
@@ -102,6 +142,34 @@ func TestTeamsCsvReport(t *testing.T) {
 	assert.Equal(t, expectedBody, recorder.Body.String())
 }
 
+func TestTeamsCsvReportWithContributions(t *testing.T) {
+	web := setupTestWeb(t)
+	defer func(definition *game.GameDefinition) { game.CurrentGame = definition }(game.CurrentGame)
+	definition := *game.CurrentGame
+	definition.ScoringElements = []game.ScoringElement{
+		{Id: "leave", Name: "Leave", Period: game.AutoScoringPeriod, Points: 3, PerRobot: true},
+	}
+	game.CurrentGame = &definition
+
+	web.arena.Database.CreateTeam(&model.Team{Id: 254})
+	web.arena.Database.CreateTeam(&model.Team{Id: 1114})
+	match := model.Match{Type: "qualification", DisplayName: "1", Red2: 254, Status: game.RedWonMatch}
+	web.arena.Database.CreateMatch(&match)
+	matchResult := model.BuildTestMatchResult(match.Id, 1)
+	matchResult.RedScore.SetRobotElementCount(1, "leave", 1)
+	web.arena.Database.CreateMatchResult(matchResult)
+
+	recorder := web.getHttpResponse("/reports/csv/teams")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "HasConnected,MatchesPlayed,ContributedPoints\n")
+	assert.Contains(t, recorder.Body.String(), "\"\",false,1,3\n")
+	assert.Contains(t, recorder.Body.String(), "\"\",false,0,0\n")
+
+	recorder = web.getHttpResponse("/reports/pdf/teams")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/pdf", recorder.Header()["Content-Type"][0])
+}
+
 func TestTeamsPdfReport(t *testing.T) {
 	web := setupTestWeb(t)
 
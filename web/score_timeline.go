@@ -0,0 +1,149 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Web handler for rendering a chart of both alliances' scores over the course of a match.
+
+package web
+
+import (
+	"fmt"
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	scoreTimelineWidth        = 1200
+	scoreTimelineHeight       = 200
+	scoreTimelineMarginLeft   = 50
+	scoreTimelineMarginRight  = 20
+	scoreTimelineMarginTop    = 15
+	scoreTimelineMarginBottom = 30
+)
+
+type scoreTimelineTick struct {
+	Position float64
+	Label    string
+}
+
+// Generates an SVG chart of the score timeline of the match given in the query string, which is either a match ID or
+// "current" or "saved" for the match currently loaded into the arena or the one whose results were last committed.
+func (web *Web) scoreTimelineSvgApiHandler(w http.ResponseWriter, r *http.Request) {
+	var timeline []game.ScoreTimelineSample
+	switch matchId := r.URL.Query().Get("matchId"); matchId {
+	case "current":
+		timeline = game.FinalizeScoreTimeline(
+			web.arena.ScoreTimeline, web.arena.RedScoreSummary(), web.arena.BlueScoreSummary(),
+		)
+	case "saved":
+		timeline = web.arena.SavedMatchResult.ScoreTimeline
+	default:
+		id, err := strconv.Atoi(matchId)
+		if err != nil {
+			handleWebErr(w, fmt.Errorf("Invalid match ID '%s'.", matchId))
+			return
+		}
+		matchResult, err := web.arena.Database.GetMatchResultForMatch(id)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if matchResult != nil {
+			timeline = matchResult.ScoreTimeline
+		}
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	if err := web.generateScoreTimelineSvg(w, timeline); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+func (web *Web) generateScoreTimelineSvg(w io.Writer, timeline []game.ScoreTimelineSample) error {
+	plotWidth := float64(scoreTimelineWidth - scoreTimelineMarginLeft - scoreTimelineMarginRight)
+	plotHeight := float64(scoreTimelineHeight - scoreTimelineMarginTop - scoreTimelineMarginBottom)
+
+	// Scale the axes to fit the length of the match and the highest score, rounded up to a whole number of ticks.
+	maxTimeSec, maxScore := 0.0, 0
+	for _, sample := range timeline {
+		maxTimeSec = math.Max(maxTimeSec, sample.MatchTimeSec)
+		maxScore = max(maxScore, sample.Red.Score, sample.Blue.Score)
+	}
+	timeStep := getChartTickStep(maxTimeSec, 10)
+	maxTimeSec = math.Max(timeStep*math.Ceil(maxTimeSec/timeStep), timeStep)
+	scoreStep := getChartTickStep(float64(maxScore), 4)
+	maxScoreValue := math.Max(scoreStep*math.Ceil(float64(maxScore)/scoreStep), scoreStep)
+	getX := func(matchTimeSec float64) float64 {
+		return scoreTimelineMarginLeft + matchTimeSec/maxTimeSec*plotWidth
+	}
+	getY := func(score int) float64 {
+		return scoreTimelineMarginTop + plotHeight - float64(score)/maxScoreValue*plotHeight
+	}
+
+	var timeTicks, scoreTicks []scoreTimelineTick
+	for timeSec := 0.0; timeSec <= maxTimeSec; timeSec += timeStep {
+		timeTicks = append(timeTicks, scoreTimelineTick{getX(timeSec), strconv.Itoa(int(timeSec))})
+	}
+	for score := 0.0; score <= maxScoreValue; score += scoreStep {
+		scoreTicks = append(scoreTicks, scoreTimelineTick{getY(int(score)), strconv.Itoa(int(score))})
+	}
+
+	// Draw each alliance's score as a step line, since it only changes at the sampled points.
+	var redPoints, bluePoints []string
+	for i, sample := range timeline {
+		x := getX(sample.MatchTimeSec)
+		if i > 0 {
+			redPoints = append(redPoints, fmt.Sprintf("%.1f,%.1f", x, getY(timeline[i-1].Red.Score)))
+			bluePoints = append(bluePoints, fmt.Sprintf("%.1f,%.1f", x, getY(timeline[i-1].Blue.Score)))
+		}
+		redPoints = append(redPoints, fmt.Sprintf("%.1f,%.1f", x, getY(sample.Red.Score)))
+		bluePoints = append(bluePoints, fmt.Sprintf("%.1f,%.1f", x, getY(sample.Blue.Score)))
+	}
+
+	template, err := web.parseFiles("templates/score_timeline.svg")
+	if err != nil {
+		return err
+	}
+	data := struct {
+		Width      int
+		Height     int
+		PlotLeft   float64
+		PlotRight  float64
+		PlotTop    float64
+		PlotBottom float64
+		TimeTicks  []scoreTimelineTick
+		ScoreTicks []scoreTimelineTick
+		RedPoints  string
+		BluePoints string
+	}{
+		scoreTimelineWidth,
+		scoreTimelineHeight,
+		scoreTimelineMarginLeft,
+		scoreTimelineMarginLeft + plotWidth,
+		scoreTimelineMarginTop,
+		scoreTimelineMarginTop + plotHeight,
+		timeTicks,
+		scoreTicks,
+		strings.Join(redPoints, " "),
+		strings.Join(bluePoints, " "),
+	}
+	return template.ExecuteTemplate(w, "scoreTimeline", data)
+}
+
+// Returns the smallest interval of 1, 2, or 5 times a power of ten that divides the given range into no more than the
+// given number of ticks.
+func getChartTickStep(valueRange float64, maxTicks int) float64 {
+	if valueRange <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(valueRange/float64(maxTicks))))
+	for _, multiple := range []float64{1, 2, 5, 10} {
+		if valueRange/(multiple*magnitude) <= float64(maxTicks) {
+			return multiple * magnitude
+		}
+	}
+	return 10 * magnitude
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package web
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestScoreTimelineSvgApi(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/api/score_timeline/svg?matchId=1")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "image/svg+xml", recorder.Header()["Content-Type"][0])
+	assert.Contains(t, recorder.Body.String(), "No score timeline recorded")
+	recorder = web.getHttpResponse("/api/score_timeline/svg?matchId=bogus")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid match ID")
+
+	// Check that the committed result keeps the timeline, finalized with any adjustments made after the match.
+	match := model.Match{
+		Type: "qualification", DisplayName: "1", Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6,
+	}
+	assert.Nil(t, web.arena.Database.CreateMatch(&match))
+	assert.Nil(t, web.arena.LoadMatch(&match))
+	web.arena.ScoreTimeline = []game.ScoreTimelineSample{
+		{MatchTimeSec: 0},
+		{MatchTimeSec: 75, Red: game.ScoreSummary{Score: 30}, Blue: game.ScoreSummary{Score: 12}},
+	}
+	web.arena.RedScore.SetElementCount("teleop", 30)
+	web.arena.BlueScore.SetElementCount("teleop", 40)
+	recorder = web.getHttpResponse("/api/score_timeline/svg?matchId=current")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "class=\"red-line\"")
+	assert.Nil(t, web.commitCurrentMatchScore())
+	matchResult, err := web.arena.Database.GetMatchResultForMatch(match.Id)
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(matchResult.ScoreTimeline)) {
+		assert.Equal(t, 75.0, matchResult.ScoreTimeline[2].MatchTimeSec)
+		assert.Equal(t, 40, matchResult.ScoreTimeline[2].Blue.Score)
+	}
+
+	recorder = web.getHttpResponse("/api/score_timeline/svg?matchId=1")
+	assert.Equal(t, 200, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "No score timeline recorded")
+	assert.Contains(t, recorder.Body.String(), "class=\"blue-line\"")
+	assert.Contains(t, recorder.Body.String(), ">40<")
+	web.arena.SavedMatchResult = matchResult
+	recorder = web.getHttpResponse("/api/score_timeline/svg?matchId=saved")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "class=\"red-line\"")
+}
+
+func TestGetChartTickStep(t *testing.T) {
+	assert.Equal(t, 1.0, getChartTickStep(0, 4))
+	assert.Equal(t, 1.0, getChartTickStep(3, 4))
+	assert.Equal(t, 20.0, getChartTickStep(150, 10))
+	assert.Equal(t, 50.0, getChartTickStep(155, 4))
+	assert.Equal(t, 100.0, getChartTickStep(400, 4))
+}
@@ -9,14 +9,22 @@ API Docs
 
 JSON Schema:
 
+Each alliance maps the IDs of the scoring elements in the current game definition to the number of times that element
+has been scored. The default game definition has the elements "auto", "teleop" and "endgame", each worth one point.
+
 {
-   “red”: {“auto”: 99, “teleop”: 99, “endgame": 99},
-   “blue”: {“auto”: 99, “teleop”: 99, “endgame": 99}
+   "red": {"auto": 99, "teleop": 99, "endgame": 99},
+   "blue": {"auto": 99, "teleop": 99, "endgame": 99},
+   "robots": {"R1": {"leave": 1}, "R2": {"leave": 0}, "R3": {"leave": 1}, "B1": {"leave": 1}, ...}
 }
 
+Elements marked as per-robot in the game definition are entered for each station under "robots" instead of under the
+alliance; the alliance totals of these are calculated automatically and also appear under "red" and "blue" in the GET
+response.
+
 GET http://10.0.100.5/api/scores
 
-Returns current score.
+Returns current score, including every element of the game definition.
 
 PUT http://10.0.100.5/api/scores
 
@@ -26,7 +34,7 @@ parts are optional. Anything missing is set to zero.
 Example:
 
 {
-   “red”: {“auto”: 10}
+   "red": {"auto": 10}
 }
 
 Red teleop and endgame are set to zero as well as all blue scores.
@@ -40,48 +48,41 @@ request body are left untouched.
 Example:
 
 {
-   “red”: {“auto”: 10},
+   "red": {"auto": 10},
    "blue": {"teleop": -5}
 }
 
 10 is added to red auto. Red teleop and endgame are left untouched.
 5 is subtracted from blue teleop. Blue auto and endgame are left untouched.
 
+Element IDs that are not part of the current game definition, per-robot elements given for an alliance, and
+alliance-level elements given for a robot are rejected with a 400 error.
+
 */
 
 package web
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/FRCTeam1987/crimson-arena/field"
 	"github.com/FRCTeam1987/crimson-arena/game"
+	"github.com/FRCTeam1987/crimson-arena/model"
 	"io/ioutil"
 	"net/http"
 )
 
-type jsonAllianceScore struct {
-	Auto    int `json:"auto"`
-	Teleop  int `json:"teleop"`
-	Endgame int `json:"endgame"`
-}
-
 type jsonScore struct {
-	Red  jsonAllianceScore `json:"red"`
-	Blue jsonAllianceScore `json:"blue"`
+	Red    map[string]int            `json:"red"`
+	Blue   map[string]int            `json:"blue"`
+	Robots map[string]map[string]int `json:"robots,omitempty"`
 }
 
 func (web *Web) getScoresHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(jsonScore{
-		Red: jsonAllianceScore{
-			Auto:    web.arena.RedScore.AutoPoints,
-			Teleop:  web.arena.RedScore.TeleopPoints,
-			Endgame: web.arena.RedScore.EndgamePoints,
-		},
-		Blue: jsonAllianceScore{
-			Auto:    web.arena.BlueScore.AutoPoints,
-			Teleop:  web.arena.BlueScore.TeleopPoints,
-			Endgame: web.arena.BlueScore.EndgamePoints,
-		},
+		Red:    getJsonAllianceScore(web.arena.RedScore),
+		Blue:   getJsonAllianceScore(web.arena.BlueScore),
+		Robots: getJsonRobotScores(web.arena.RedScore, web.arena.BlueScore, web.arena.TeamsPerAlliance),
 	})
 }
 
@@ -100,16 +101,108 @@ func (web *Web) setScoresHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	json.Unmarshal(reqBody, &scores)
 
+	for _, allianceScore := range []map[string]int{scores.Red, scores.Blue} {
+		for id := range allianceScore {
+			element := game.CurrentGame.GetScoringElement(id)
+			if element == nil {
+				http.Error(w, fmt.Sprintf("Unknown scoring element '%s'", id), http.StatusBadRequest)
+				return
+			}
+			if element.PerRobot {
+				http.Error(w, fmt.Sprintf("Scoring element '%s' must be entered per robot", id), http.StatusBadRequest)
+				return
+			}
+		}
+	}
+	for station, robotScore := range scores.Robots {
+		if getRobotIndex(station, web.arena.TeamsPerAlliance) < 0 {
+			http.Error(w, fmt.Sprintf("Unknown station '%s'", station), http.StatusBadRequest)
+			return
+		}
+		for id := range robotScore {
+			element := game.CurrentGame.GetScoringElement(id)
+			if element == nil {
+				http.Error(w, fmt.Sprintf("Unknown scoring element '%s'", id), http.StatusBadRequest)
+				return
+			}
+			if !element.PerRobot {
+				http.Error(
+					w, fmt.Sprintf("Scoring element '%s' must be entered per alliance", id), http.StatusBadRequest,
+				)
+				return
+			}
+		}
+	}
+
+	previousRedScore, previousBlueScore := web.arena.RedScore.Copy(), web.arena.BlueScore.Copy()
 	if r.Method == "PUT" {
 		web.arena.RedScore = new(game.Score)
 		web.arena.BlueScore = new(game.Score)
 	}
 
-	web.arena.RedScore.AutoPoints += scores.Red.Auto
-	web.arena.RedScore.TeleopPoints += scores.Red.Teleop
-	web.arena.RedScore.EndgamePoints += scores.Red.Endgame
-	web.arena.BlueScore.AutoPoints += scores.Blue.Auto
-	web.arena.BlueScore.TeleopPoints += scores.Blue.Teleop
-	web.arena.BlueScore.EndgamePoints += scores.Blue.Endgame
+	for id, count := range scores.Red {
+		web.arena.RedScore.SetElementCount(id, web.arena.RedScore.ElementCounts[id]+count)
+	}
+	for id, count := range scores.Blue {
+		web.arena.BlueScore.SetElementCount(id, web.arena.BlueScore.ElementCounts[id]+count)
+	}
+	for station, robotScore := range scores.Robots {
+		score := web.arena.RedScore
+		if station[0] == 'B' {
+			score = web.arena.BlueScore
+		}
+		robotIndex := getRobotIndex(station, web.arena.TeamsPerAlliance)
+		for id, count := range robotScore {
+			score.SetRobotElementCount(robotIndex, id, score.RobotElementCounts[robotIndex][id]+count)
+		}
+	}
+	err = web.arena.RecordScoreChange(model.ApiScoreEventSource, r.RemoteAddr, previousRedScore, previousBlueScore)
 	web.arena.RealtimeScoreNotifier.Notify()
+	web.arena.NotifyPendingResultEdited()
+	if err != nil {
+		handleWebErr(w, err)
+	}
+}
+
+// Returns the count of every scoring element in the current game definition for the given alliance score.
+func getJsonAllianceScore(score *game.Score) map[string]int {
+	allianceScore := make(map[string]int)
+	for _, element := range game.CurrentGame.ScoringElements {
+		allianceScore[element.Id] = score.ElementCounts[element.Id]
+	}
+	return allianceScore
+}
+
+// Returns the counts of every per-robot scoring element in the current game definition for each station in use, or nil
+// if the game doesn't have any per-robot elements.
+func getJsonRobotScores(redScore, blueScore *game.Score, teamsPerAlliance int) map[string]map[string]int {
+	stations := model.AllianceStationNames(teamsPerAlliance)
+	var robotScores map[string]map[string]int
+	for _, element := range game.CurrentGame.ScoringElements {
+		if !element.PerRobot {
+			continue
+		}
+		if robotScores == nil {
+			robotScores = make(map[string]map[string]int)
+			for _, station := range stations {
+				robotScores[station] = make(map[string]int)
+			}
+		}
+		for i := 0; i < teamsPerAlliance; i++ {
+			robotScores[stations[i]][element.Id] = redScore.RobotElementCounts[i][element.Id]
+			robotScores[stations[i+teamsPerAlliance]][element.Id] = blueScore.RobotElementCounts[i][element.Id]
+		}
+	}
+	return robotScores
+}
+
+// Returns the zero-based index within its alliance of the given station (e.g. "R1"), or -1 if it isn't a station in use
+// with the given number of teams per alliance.
+func getRobotIndex(station string, teamsPerAlliance int) int {
+	for i, robotStation := range model.AllianceStationNames(teamsPerAlliance) {
+		if station == robotStation {
+			return i % teamsPerAlliance
+		}
+	}
+	return -1
 }
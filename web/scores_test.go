@@ -15,26 +15,22 @@ import (
 func TestGetScores(t *testing.T) {
 	web := setupTestWeb(t)
 
-	score1 := game.TestScore1()
-	score2 := game.TestScore2()
-	web.arena.RedScore.AutoPoints = score1.AutoPoints
-	web.arena.RedScore.TeleopPoints = score1.TeleopPoints
-	web.arena.RedScore.EndgamePoints = score1.EndgamePoints
-	web.arena.BlueScore.AutoPoints = score2.AutoPoints
-	web.arena.BlueScore.TeleopPoints = score2.TeleopPoints
-	web.arena.BlueScore.EndgamePoints = score2.EndgamePoints
+	web.arena.RedScore = game.TestScore1()
+	web.arena.BlueScore = game.TestScore2()
 
 	recorder := web.getHttpResponse("/api/scores")
 	assert.Equal(t, 200, recorder.Code)
 
 	var reqScores jsonScore
 	json.Unmarshal(recorder.Body.Bytes(), &reqScores)
-	assert.Equal(t, score1.AutoPoints, reqScores.Red.Auto)
-	assert.Equal(t, score1.TeleopPoints, reqScores.Red.Teleop)
-	assert.Equal(t, score1.EndgamePoints, reqScores.Red.Endgame)
-	assert.Equal(t, score2.AutoPoints, reqScores.Blue.Auto)
-	assert.Equal(t, score2.TeleopPoints, reqScores.Blue.Teleop)
-	assert.Equal(t, score2.EndgamePoints, reqScores.Blue.Endgame)
+	assert.Equal(t, map[string]int{"auto": 45, "teleop": 80, "endgame": 30}, reqScores.Red)
+	assert.Equal(t, map[string]int{"auto": 15, "teleop": 40, "endgame": 25}, reqScores.Blue)
+
+	// Elements that haven't been scored yet should still be listed.
+	web.arena.BlueScore = new(game.Score)
+	recorder = web.getHttpResponse("/api/scores")
+	json.Unmarshal(recorder.Body.Bytes(), &reqScores)
+	assert.Equal(t, map[string]int{"auto": 0, "teleop": 0, "endgame": 0}, reqScores.Blue)
 }
 
 func TestPatchScores(t *testing.T) {
@@ -46,37 +42,28 @@ func TestPatchScores(t *testing.T) {
 	assert.Equal(t, 400, recorder.Code)
 	assert.Equal(t, "Score cannot be updated in this match state\n", recorder.Body.String())
 
-	score1 := game.TestScore1()
-	score2 := game.TestScore2()
-	web.arena.RedScore.AutoPoints = score1.AutoPoints
-	web.arena.RedScore.TeleopPoints = score1.TeleopPoints
-	web.arena.RedScore.EndgamePoints = score1.EndgamePoints
-	web.arena.BlueScore.AutoPoints = score2.AutoPoints
-	web.arena.BlueScore.TeleopPoints = score2.TeleopPoints
-	web.arena.BlueScore.EndgamePoints = score2.EndgamePoints
+	web.arena.RedScore = game.TestScore1()
+	web.arena.BlueScore = game.TestScore2()
 
 	web.arena.MatchState = field.PostMatch
 	recorder = web.patchHttpResponse("/api/scores",
 		"{\"red\":{\"auto\":5,\"teleop\":10,\"endgame\":15}}")
 	assert.Equal(t, 200, recorder.Code)
 
-	assert.Equal(t, score1.AutoPoints+5, web.arena.RedScore.AutoPoints)
-	assert.Equal(t, score1.TeleopPoints+10, web.arena.RedScore.TeleopPoints)
-	assert.Equal(t, score1.EndgamePoints+15, web.arena.RedScore.EndgamePoints)
-	assert.Equal(t, score2.AutoPoints, web.arena.BlueScore.AutoPoints)
-	assert.Equal(t, score2.TeleopPoints, web.arena.BlueScore.TeleopPoints)
-	assert.Equal(t, score2.EndgamePoints, web.arena.BlueScore.EndgamePoints)
+	assert.Equal(t, map[string]int{"auto": 50, "teleop": 90, "endgame": 45}, web.arena.RedScore.ElementCounts)
+	assert.Equal(t, game.TestScore2(), web.arena.BlueScore)
 
 	recorder = web.patchHttpResponse("/api/scores",
 		"{\"blue\":{\"auto\":-5,\"teleop\":-10,\"endgame\":-15}}")
 	assert.Equal(t, 200, recorder.Code)
 
-	assert.Equal(t, score1.AutoPoints+5, web.arena.RedScore.AutoPoints)
-	assert.Equal(t, score1.TeleopPoints+10, web.arena.RedScore.TeleopPoints)
-	assert.Equal(t, score1.EndgamePoints+15, web.arena.RedScore.EndgamePoints)
-	assert.Equal(t, score2.AutoPoints-5, web.arena.BlueScore.AutoPoints)
-	assert.Equal(t, score2.TeleopPoints-10, web.arena.BlueScore.TeleopPoints)
-	assert.Equal(t, score2.EndgamePoints-15, web.arena.BlueScore.EndgamePoints)
+	assert.Equal(t, map[string]int{"auto": 50, "teleop": 90, "endgame": 45}, web.arena.RedScore.ElementCounts)
+	assert.Equal(t, map[string]int{"auto": 10, "teleop": 30, "endgame": 10}, web.arena.BlueScore.ElementCounts)
+
+	recorder = web.patchHttpResponse("/api/scores", "{\"blue\":{\"bogus\":1}}")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Equal(t, "Unknown scoring element 'bogus'\n", recorder.Body.String())
+	assert.Equal(t, map[string]int{"auto": 10, "teleop": 30, "endgame": 10}, web.arena.BlueScore.ElementCounts)
 }
 
 func TestPutScores(t *testing.T) {
@@ -88,35 +75,61 @@ func TestPutScores(t *testing.T) {
 	assert.Equal(t, 400, recorder.Code)
 	assert.Equal(t, "Score cannot be updated in this match state\n", recorder.Body.String())
 
-	score1 := game.TestScore1()
-	score2 := game.TestScore2()
-	web.arena.RedScore.AutoPoints = score1.AutoPoints
-	web.arena.RedScore.TeleopPoints = score1.TeleopPoints
-	web.arena.RedScore.EndgamePoints = score1.EndgamePoints
-	web.arena.BlueScore.AutoPoints = score2.AutoPoints
-	web.arena.BlueScore.TeleopPoints = score2.TeleopPoints
-	web.arena.BlueScore.EndgamePoints = score2.EndgamePoints
+	web.arena.RedScore = game.TestScore1()
+	web.arena.BlueScore = game.TestScore2()
 
 	web.arena.MatchState = field.PostMatch
 	recorder = web.putHttpResponse("/api/scores",
 		"{\"red\":{\"auto\":5,\"teleop\":10,\"endgame\":15}}")
 	assert.Equal(t, 200, recorder.Code)
 
-	assert.Equal(t, 5, web.arena.RedScore.AutoPoints)
-	assert.Equal(t, 10, web.arena.RedScore.TeleopPoints)
-	assert.Equal(t, 15, web.arena.RedScore.EndgamePoints)
-	assert.Equal(t, 0, web.arena.BlueScore.AutoPoints)
-	assert.Equal(t, 0, web.arena.BlueScore.TeleopPoints)
-	assert.Equal(t, 0, web.arena.BlueScore.EndgamePoints)
+	assert.Equal(t, map[string]int{"auto": 5, "teleop": 10, "endgame": 15}, web.arena.RedScore.ElementCounts)
+	assert.Equal(t, 0, web.arena.BlueScore.Summarize(web.arena.RedScore).Score)
 
 	recorder = web.putHttpResponse("/api/scores",
 		"{\"blue\":{\"auto\":5,\"teleop\":10,\"endgame\":15}}")
 	assert.Equal(t, 200, recorder.Code)
 
-	assert.Equal(t, 0, web.arena.RedScore.AutoPoints)
-	assert.Equal(t, 0, web.arena.RedScore.TeleopPoints)
-	assert.Equal(t, 0, web.arena.RedScore.EndgamePoints)
-	assert.Equal(t, 5, web.arena.BlueScore.AutoPoints)
-	assert.Equal(t, 10, web.arena.BlueScore.TeleopPoints)
-	assert.Equal(t, 15, web.arena.BlueScore.EndgamePoints)
+	assert.Equal(t, 0, web.arena.RedScore.Summarize(web.arena.BlueScore).Score)
+	assert.Equal(t, map[string]int{"auto": 5, "teleop": 10, "endgame": 15}, web.arena.BlueScore.ElementCounts)
+}
+
+func TestRobotScores(t *testing.T) {
+	web := setupTestWeb(t)
+	defer func(definition *game.GameDefinition) { game.CurrentGame = definition }(game.CurrentGame)
+	definition := *game.CurrentGame
+	definition.ScoringElements = append(
+		append([]game.ScoringElement(nil), definition.ScoringElements...),
+		game.ScoringElement{Id: "leave", Name: "Leave", Period: game.AutoScoringPeriod, Points: 3, PerRobot: true},
+	)
+	game.CurrentGame = &definition
+
+	web.arena.MatchState = field.AutoPeriod
+	recorder := web.patchHttpResponse("/api/scores", "{\"robots\":{\"R1\":{\"leave\":1},\"R3\":{\"leave\":1},"+
+		"\"B2\":{\"leave\":1}}}")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, 2, web.arena.RedScore.ElementCounts["leave"])
+	assert.Equal(t, 1, web.arena.BlueScore.RobotElementCounts[1]["leave"])
+
+	recorder = web.getHttpResponse("/api/scores")
+	var reqScores jsonScore
+	json.Unmarshal(recorder.Body.Bytes(), &reqScores)
+	assert.Equal(t, 2, reqScores.Red["leave"])
+	assert.Equal(t, map[string]int{"leave": 1}, reqScores.Robots["R3"])
+	assert.Equal(t, map[string]int{"leave": 0}, reqScores.Robots["B1"])
+
+	recorder = web.patchHttpResponse("/api/scores", "{\"red\":{\"leave\":1}}")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Equal(t, "Scoring element 'leave' must be entered per robot\n", recorder.Body.String())
+	recorder = web.patchHttpResponse("/api/scores", "{\"robots\":{\"R1\":{\"auto\":1}}}")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Equal(t, "Scoring element 'auto' must be entered per alliance\n", recorder.Body.String())
+	recorder = web.patchHttpResponse("/api/scores", "{\"robots\":{\"R4\":{\"leave\":1}}}")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Equal(t, "Unknown station 'R4'\n", recorder.Body.String())
+
+	recorder = web.putHttpResponse("/api/scores", "{\"robots\":{\"B3\":{\"leave\":1}}}")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, 0, web.arena.RedScore.ElementCounts["leave"])
+	assert.Equal(t, 1, web.arena.BlueScore.ElementCounts["leave"])
 }
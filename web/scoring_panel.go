@@ -0,0 +1,162 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Web handlers for the per-alliance realtime scoring panels.
+
+package web
+
+import (
+	"fmt"
+	"github.com/FRCTeam1987/crimson-arena/field"
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"github.com/FRCTeam1987/crimson-arena/websocket"
+	"github.com/gorilla/mux"
+	"github.com/mitchellh/mapstructure"
+	"io"
+	"log"
+	"net/http"
+)
+
+// Renders the scoring interface which enables input of scores in real-time.
+func (web *Web) scoringPanelHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	alliance := mux.Vars(r)["alliance"]
+	if alliance != "red" && alliance != "blue" {
+		handleWebErr(w, fmt.Errorf("Invalid alliance '%s'.", alliance))
+		return
+	}
+
+	template, err := web.parseFiles("templates/scoring_panel.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Alliance       string
+		GameDefinition *game.GameDefinition
+	}{web.arena.EventSettings, alliance, game.CurrentGame}
+	err = template.ExecuteTemplate(w, "base_no_navbar", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// The websocket endpoint for the scoring interface client to send score increments and receive status updates.
+func (web *Web) scoringPanelWebsocketHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	alliance := mux.Vars(r)["alliance"]
+	if alliance != "red" && alliance != "blue" {
+		handleWebErr(w, fmt.Errorf("Invalid alliance '%s'.", alliance))
+		return
+	}
+
+	ws, err := websocket.NewWebsocket(w, r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	defer ws.Close()
+	web.arena.ScoringPanelRegistry.RegisterPanel(alliance, ws)
+	web.arena.ScoringStatusNotifier.Notify()
+	defer web.arena.ScoringStatusNotifier.Notify()
+	defer web.arena.ScoringPanelRegistry.UnregisterPanel(alliance, ws)
+
+	// Subscribe the websocket to the notifiers whose messages will be passed on to the client, in a separate goroutine.
+	go ws.HandleNotifiers(web.arena.MatchTimingNotifier, web.arena.MatchLoadNotifier, web.arena.MatchTimeNotifier,
+		web.arena.RealtimeScoreNotifier, web.arena.ReloadDisplaysNotifier)
+
+	// Loop, waiting for commands and responding to them, until the client closes the connection.
+	for {
+		command, data, err := ws.Read()
+		if err != nil {
+			if err == io.EOF {
+				// Client has closed the connection; nothing to do here.
+				return
+			}
+			log.Println(err)
+			return
+		}
+
+		switch command {
+		case "score":
+			args := struct {
+				ElementId string
+				// Station position (1-4) of the robot for per-robot elements; zero for alliance-level elements.
+				Position int
+				Delta    int
+			}{}
+			if err = mapstructure.Decode(data, &args); err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			previousRedScore, previousBlueScore := web.arena.RedScore.Copy(), web.arena.BlueScore.Copy()
+			err = web.applyScoringPanelIncrement(alliance, ws, args.ElementId, args.Position, args.Delta)
+			if err != nil {
+				ws.WriteError(err.Error())
+				continue
+			}
+			err = web.arena.RecordScoreChange(
+				model.PanelScoreEventSource, alliance+" scoring panel", previousRedScore, previousBlueScore,
+			)
+			if err != nil {
+				ws.WriteError(err.Error())
+			}
+			web.arena.RealtimeScoreNotifier.Notify()
+			web.arena.NotifyPendingResultEdited()
+		case "commitMatch":
+			if web.arena.MatchState != field.PostMatch {
+				// Don't allow committing the score until the match is over.
+				ws.WriteError("Cannot commit score: Match is not over.")
+				continue
+			}
+			web.arena.ScoringPanelRegistry.SetScoreCommitted(alliance, ws)
+			web.arena.ScoringStatusNotifier.Notify()
+		default:
+			ws.WriteError(fmt.Sprintf("Invalid message type '%s'.", command))
+		}
+	}
+}
+
+// Adds the given amount to the count of the given scoring element in the alliance's realtime score, without letting
+// the count go below zero.
+func (web *Web) applyScoringPanelIncrement(
+	alliance string, ws *websocket.Websocket, elementId string, position, delta int,
+) error {
+	if web.arena.MatchState == field.PreMatch || web.arena.MatchState == field.TimeoutActive ||
+		web.arena.MatchState == field.PostTimeout {
+		return fmt.Errorf("Score cannot be updated in this match state.")
+	}
+	if web.arena.ScoringPanelRegistry.IsScoreCommitted(alliance, ws) {
+		return fmt.Errorf("Score has already been committed for this match.")
+	}
+	element := game.CurrentGame.GetScoringElement(elementId)
+	if element == nil {
+		return fmt.Errorf("Unknown scoring element '%s'.", elementId)
+	}
+
+	score := web.arena.RedScore
+	if alliance == "blue" {
+		score = web.arena.BlueScore
+	}
+	if element.PerRobot {
+		if position < 1 || position > web.arena.TeamsPerAlliance {
+			return fmt.Errorf("Scoring element '%s' requires a robot position.", elementId)
+		}
+		count := max(score.RobotElementCounts[position-1][elementId]+delta, 0)
+		score.SetRobotElementCount(position-1, elementId, count)
+	} else {
+		if position != 0 {
+			return fmt.Errorf("Scoring element '%s' is scored for the whole alliance.", elementId)
+		}
+		score.SetElementCount(elementId, max(score.ElementCounts[elementId]+delta, 0))
+	}
+	return nil
+}
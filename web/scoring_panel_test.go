@@ -0,0 +1,116 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package web
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/field"
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"github.com/FRCTeam1987/crimson-arena/websocket"
+	gorillawebsocket "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestScoringPanel(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/panels/scoring/blue")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Scoring Panel - Untitled Event")
+	assert.Contains(t, recorder.Body.String(), "data-element=\"teleop\" data-position=\"0\"")
+	recorder = web.getHttpResponse("/panels/scoring/purple")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid alliance")
+}
+
+func TestScoringPanelWebsocket(t *testing.T) {
+	web := setupTestWeb(t)
+	defer func(definition *game.GameDefinition) { game.CurrentGame = definition }(game.CurrentGame)
+	definition := *game.CurrentGame
+	definition.ScoringElements = append(
+		append([]game.ScoringElement(nil), definition.ScoringElements...),
+		game.ScoringElement{Id: "leave", Name: "Leave", Period: game.AutoScoringPeriod, Points: 3, PerRobot: true},
+	)
+	game.CurrentGame = &definition
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	redConn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/panels/scoring/red/websocket", nil)
+	assert.Nil(t, err)
+	defer redConn.Close()
+	redWs := websocket.NewTestWebsocket(redConn)
+	blueConn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/panels/scoring/blue/websocket", nil)
+	assert.Nil(t, err)
+	defer blueConn.Close()
+	blueWs := websocket.NewTestWebsocket(blueConn)
+
+	// Should get a few status updates right after connection.
+	for _, ws := range []*websocket.Websocket{redWs, blueWs} {
+		readWebsocketType(t, ws, "matchTiming")
+		readWebsocketType(t, ws, "matchLoad")
+		readWebsocketType(t, ws, "matchTime")
+		readWebsocketType(t, ws, "realtimeScore")
+	}
+	assert.Equal(t, 1, web.arena.ScoringPanelRegistry.GetNumPanels("red"))
+	assert.Equal(t, 1, web.arena.ScoringPanelRegistry.GetNumPanels("blue"))
+
+	// Scores can't be entered before the match starts.
+	redWs.Write("score", map[string]interface{}{"ElementId": "auto", "Delta": 1})
+	assert.Contains(t, readWebsocketError(t, redWs), "cannot be updated in this match state")
+
+	web.arena.MatchState = field.AutoPeriod
+	redWs.Write("score", map[string]interface{}{"ElementId": "auto", "Delta": 1})
+	redWs.Write("score", map[string]interface{}{"ElementId": "auto", "Delta": 1})
+	redWs.Write("score", map[string]interface{}{"ElementId": "leave", "Position": 3, "Delta": 1})
+	blueWs.Write("score", map[string]interface{}{"ElementId": "teleop", "Delta": -1})
+	for i := 0; i < 4; i++ {
+		readWebsocketType(t, redWs, "realtimeScore")
+		readWebsocketType(t, blueWs, "realtimeScore")
+	}
+	assert.Equal(t, 2, web.arena.RedScore.ElementCounts["auto"])
+	assert.Equal(t, 1, web.arena.RedScore.RobotElementCounts[2]["leave"])
+	assert.Equal(t, 0, web.arena.BlueScore.ElementCounts["teleop"])
+	assert.Equal(t, 0, web.arena.BlueScore.ElementCounts["auto"])
+
+	redWs.Write("score", map[string]interface{}{"ElementId": "leave", "Delta": 1})
+	assert.Contains(t, readWebsocketError(t, redWs), "requires a robot position")
+	redWs.Write("score", map[string]interface{}{"ElementId": "auto", "Position": 1, "Delta": 1})
+	assert.Contains(t, readWebsocketError(t, redWs), "scored for the whole alliance")
+	redWs.Write("score", map[string]interface{}{"ElementId": "bogus", "Delta": 1})
+	assert.Contains(t, readWebsocketError(t, redWs), "Unknown scoring element")
+
+	// Check that the fourth robot can only be scored when alliances have four teams.
+	redWs.Write("score", map[string]interface{}{"ElementId": "leave", "Position": 4, "Delta": 1})
+	assert.Contains(t, readWebsocketError(t, redWs), "requires a robot position")
+	web.arena.TeamsPerAlliance = 4
+	redWs.Write("score", map[string]interface{}{"ElementId": "leave", "Position": 4, "Delta": 1})
+	readWebsocketType(t, redWs, "realtimeScore")
+	readWebsocketType(t, blueWs, "realtimeScore")
+	assert.Equal(t, 1, web.arena.RedScore.RobotElementCounts[3]["leave"])
+	assert.Equal(t, 2, web.arena.RedScore.ElementCounts["leave"])
+	web.arena.TeamsPerAlliance = 3
+
+	// Check that the match can't be committed until both panels have committed their scores.
+	redWs.Write("commitMatch", nil)
+	assert.Contains(t, readWebsocketError(t, redWs), "Match is not over")
+	web.arena.MatchState = field.PostMatch
+	redWs.Write("commitMatch", nil)
+	redWs.Write("score", map[string]interface{}{"ElementId": "auto", "Delta": 1})
+	assert.Contains(t, readWebsocketError(t, redWs), "already been committed")
+	assert.Equal(t, 1, web.arena.ScoringPanelRegistry.GetNumScoreCommitted("red"))
+	assert.False(t, web.arena.ScoringPanelRegistry.AllScoresCommitted())
+
+	matchPlayConn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/match_play/websocket", nil)
+	assert.Nil(t, err)
+	defer matchPlayConn.Close()
+	matchPlayWs := websocket.NewTestWebsocket(matchPlayConn)
+	readWebsocketMultiple(t, matchPlayWs, 10)
+	matchPlayWs.Write("commitResults", nil)
+	assert.Contains(t, readWebsocketError(t, matchPlayWs), "not all scoring panels have committed")
+
+	blueWs.Write("commitMatch", nil)
+	blueWs.Write("nonexistenttype", nil)
+	assert.Contains(t, readWebsocketError(t, blueWs), "Invalid message type")
+	assert.True(t, web.arena.ScoringPanelRegistry.AllScoresCommitted())
+	readWebsocketType(t, matchPlayWs, "scoringStatus")
+}
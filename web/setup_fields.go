@@ -0,0 +1,93 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Web routes for configuring the fields run from this server when there is more than one.
+
+package web
+
+import (
+	"fmt"
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Shows the field configuration page.
+func (web *Web) fieldsGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	template, err := web.parseFiles("templates/setup_fields.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	fields, err := web.arena.Database.GetAllFields()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Append a blank field to the end that can be used to add a new one.
+	fields = append(fields, model.Field{})
+
+	data := struct {
+		*model.EventSettings
+		Fields []model.Field
+	}{web.arena.EventSettings, fields}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Saves the new or modified field to the database, or deletes it.
+func (web *Web) fieldsPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	fieldId, _ := strconv.Atoi(r.PostFormValue("id"))
+	if r.PostFormValue("action") == "delete" {
+		if err := web.arena.Database.DeleteField(fieldId); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	} else {
+		field := model.Field{
+			Id:              fieldId,
+			Name:            strings.TrimSpace(r.PostFormValue("name")),
+			ServerIpAddress: strings.TrimSpace(r.PostFormValue("serverIpAddress")),
+			ApAddress:       r.PostFormValue("apAddress"),
+			ApUsername:      r.PostFormValue("apUsername"),
+			ApPassword:      r.PostFormValue("apPassword"),
+			SwitchAddress:   r.PostFormValue("switchAddress"),
+			SwitchPassword:  r.PostFormValue("switchPassword"),
+			PlcAddress:      r.PostFormValue("plcAddress"),
+		}
+		field.ApTeamChannel, _ = strconv.Atoi(r.PostFormValue("apTeamChannel"))
+		if field.Name == "" {
+			handleWebErr(w, fmt.Errorf("field must have a name"))
+			return
+		}
+		if field.ServerIpAddress == "" {
+			handleWebErr(w, fmt.Errorf("field must have a server IP address"))
+			return
+		}
+
+		var err error
+		if field.Id == 0 {
+			err = web.arena.Database.CreateField(&field)
+		} else {
+			err = web.arena.Database.UpdateField(&field)
+		}
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
+	http.Redirect(w, r, "/setup/fields", 303)
+}
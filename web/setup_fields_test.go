@@ -0,0 +1,102 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package web
+
+import (
+	"context"
+	"github.com/FRCTeam1987/crimson-arena/field"
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetupFields(t *testing.T) {
+	web := setupTestWeb(t)
+
+	web.arena.Database.CreateField(&model.Field{Name: "Field 1", ServerIpAddress: "10.0.100.5"})
+	web.arena.Database.CreateField(&model.Field{Name: "Field 2", ServerIpAddress: "10.0.200.5"})
+
+	recorder := web.getHttpResponse("/setup/fields")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "value=\"Field 1\"")
+	assert.Contains(t, recorder.Body.String(), "value=\"10.0.200.5\"")
+
+	recorder = web.postHttpResponse("/setup/fields", "action=delete&id=1")
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.getHttpResponse("/setup/fields")
+	assert.NotContains(t, recorder.Body.String(), "value=\"Field 1\"")
+
+	recorder = web.postHttpResponse(
+		"/setup/fields", "id=2&name=Practice+Field&serverIpAddress=10.0.200.6&plcAddress=10.0.200.10",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	fieldConfig, _ := web.arena.Database.GetFieldById(2)
+	if assert.NotNil(t, fieldConfig) {
+		assert.Equal(t, "Practice Field", fieldConfig.Name)
+		assert.Equal(t, "10.0.200.6", fieldConfig.ServerIpAddress)
+		assert.Equal(t, "10.0.200.10", fieldConfig.PlcAddress)
+	}
+
+	recorder = web.postHttpResponse("/setup/fields", "name=&serverIpAddress=10.0.200.7")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "must have a name")
+	recorder = web.postHttpResponse("/setup/fields", "name=Field+3")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "must have a server IP address")
+}
+
+func TestFieldSelection(t *testing.T) {
+	web := setupTestWeb(t)
+
+	web.arena.Database.CreateField(&model.Field{Name: "Field 1", ServerIpAddress: "10.0.100.5"})
+	web.arena.Database.CreateField(&model.Field{Name: "Field 2", ServerIpAddress: "10.0.200.5"})
+	web.arena.Database.CreateMatch(&model.Match{Type: "qualification", DisplayName: "101", FieldId: 1})
+	web.arena.Database.CreateMatch(&model.Match{Type: "qualification", DisplayName: "102", FieldId: 2})
+	web.arena.Database.CreateMatch(&model.Match{Type: "qualification", DisplayName: "103"})
+	assert.Nil(t, web.arena.Database.Close())
+	arenas, err := field.NewArenas(web.arena.Database.Path)
+	assert.Nil(t, err)
+	web = NewWeb(arenas...)
+
+	// Check that requests go to the first field by default.
+	recorder := web.getHttpResponse("/match_play?matchType=qualification")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Q101")
+	assert.NotContains(t, recorder.Body.String(), "Q102")
+	assert.Contains(t, recorder.Body.String(), "Q103")
+	assert.Contains(t, recorder.Body.String(), "href=\"?field=2\"")
+
+	// Check that the field can be picked by query parameter and is remembered for subsequent requests.
+	recorder = web.getHttpResponse("/match_play?matchType=qualification&field=2")
+	assert.Equal(t, 200, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "Q101")
+	assert.Contains(t, recorder.Body.String(), "Q102")
+	assert.Contains(t, recorder.Body.String(), "Q103")
+	assert.Contains(t, recorder.Header().Get("Set-Cookie"), "field=2")
+	recorder = web.getHttpResponseWithHeaders(
+		"/match_play?matchType=qualification", map[string]string{"Cookie": "field=2"},
+	)
+	assert.Contains(t, recorder.Body.String(), "Q102")
+	recorder = web.getHttpResponse("/match_play?matchType=qualification&field=5")
+	assert.Contains(t, recorder.Body.String(), "Q101")
+
+	// Check that a request without a field, as from an SCC, goes to the field whose network address it was made to.
+	recorder = httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/match_play?matchType=qualification", nil)
+	localAddr := &net.TCPAddr{IP: net.IPv4(10, 0, 200, 5), Port: 8080}
+	req = req.WithContext(context.WithValue(req.Context(), http.LocalAddrContextKey, localAddr))
+	web.newHandler().ServeHTTP(recorder, req)
+	assert.Contains(t, recorder.Body.String(), "Q102")
+	assert.NotContains(t, recorder.Body.String(), "Q101")
+
+	// Check that settings changes are applied to every field's arena.
+	recorder = web.postHttpResponse(
+		"/setup/settings?field=2", "name=Chezy+Champs&elimType=single&numElimAlliances=8&teamsPerAlliance=3",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "Chezy Champs", arenas[0].EventSettings.Name)
+	assert.Equal(t, "Chezy Champs", arenas[1].EventSettings.Name)
+}
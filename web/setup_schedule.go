@@ -76,16 +76,25 @@ func (web *Web) scheduleGeneratePostHandler(w http.ResponseWriter, r *http.Reque
 			"generating the schedule.")
 		return
 	}
-	if len(teams) < 6 {
-		web.renderSchedule(w, r, fmt.Sprintf("There are only %d teams. There must be at least 6 teams to generate "+
-			"a schedule.", len(teams)))
+	teamsPerAlliance := web.arena.EventSettings.TeamsPerAlliance
+	if len(teams) < 2*teamsPerAlliance {
+		web.renderSchedule(w, r, fmt.Sprintf("There are only %d teams. There must be at least %d teams to generate "+
+			"a schedule.", len(teams), 2*teamsPerAlliance))
 		return
 	}
-	matches, err := tournament.BuildRandomSchedule(teams, scheduleBlocks, r.PostFormValue("matchType"))
+	matches, err := tournament.BuildRandomSchedule(
+		teams, scheduleBlocks, r.PostFormValue("matchType"), teamsPerAlliance,
+	)
 	if err != nil {
 		web.renderSchedule(w, r, fmt.Sprintf("Error generating schedule: %s.", err.Error()))
 		return
 	}
+	fields, err := web.arena.Database.GetAllFields()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	tournament.AssignFields(matches, fields)
 	cachedMatches[matchType] = matches
 
 	// Determine each team's first match.
@@ -97,12 +106,9 @@ func (web *Web) scheduleGeneratePostHandler(w http.ResponseWriter, r *http.Reque
 				teamFirstMatches[team] = match.DisplayName
 			}
 		}
-		checkTeam(match.Red1)
-		checkTeam(match.Red2)
-		checkTeam(match.Red3)
-		checkTeam(match.Blue1)
-		checkTeam(match.Blue2)
-		checkTeam(match.Blue3)
+		for _, teamId := range match.TeamIds(teamsPerAlliance) {
+			checkTeam(teamId)
+		}
 	}
 	cachedTeamFirstMatches[matchType] = teamFirstMatches
 
@@ -194,6 +200,15 @@ func (web *Web) renderSchedule(w http.ResponseWriter, r *http.Request, errorMess
 		handleWebErr(w, err)
 		return
 	}
+	fields, err := web.arena.Database.GetAllFields()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	fieldNames := make(map[int]string, len(fields))
+	for _, field := range fields {
+		fieldNames[field.Id] = field.Name
+	}
 	template, err := web.parseFiles("templates/setup_schedule.html", "templates/base.html")
 	if err != nil {
 		handleWebErr(w, err)
@@ -206,9 +221,10 @@ func (web *Web) renderSchedule(w http.ResponseWriter, r *http.Request, errorMess
 		NumTeams         int
 		Matches          []model.Match
 		TeamFirstMatches map[int]string
+		FieldNames       map[int]string
 		ErrorMessage     string
 	}{web.arena.EventSettings, matchType, scheduleBlocks, len(teams), cachedMatches[matchType],
-		cachedTeamFirstMatches[matchType], errorMessage}
+		cachedTeamFirstMatches[matchType], fieldNames, errorMessage}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
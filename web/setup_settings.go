@@ -7,7 +7,10 @@ package web
 
 import (
 	"fmt"
+	"github.com/FRCTeam1987/crimson-arena/field"
+	"github.com/FRCTeam1987/crimson-arena/game"
 	"github.com/FRCTeam1987/crimson-arena/model"
+	"github.com/FRCTeam1987/crimson-arena/network"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -54,6 +57,13 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	eventSettings.NumElimAlliances = numAlliances
+	eventSettings.TeamsPerAlliance, _ = strconv.Atoi(r.PostFormValue("teamsPerAlliance"))
+	if eventSettings.TeamsPerAlliance < 1 || eventSettings.TeamsPerAlliance > game.MaxTeamsPerAlliance {
+		web.renderSettings(
+			w, r, fmt.Sprintf("Number of teams per alliance must be between 1 and %d.", game.MaxTeamsPerAlliance),
+		)
+		return
+	}
 	eventSettings.SelectionRound2Order = r.PostFormValue("selectionRound2Order")
 	eventSettings.SelectionRound3Order = r.PostFormValue("selectionRound3Order")
 	eventSettings.TBADownloadEnabled = r.PostFormValue("TBADownloadEnabled") == "on"
@@ -81,25 +91,115 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	eventSettings.PauseDurationSec, _ = strconv.Atoi(r.PostFormValue("pauseDurationSec"))
 	eventSettings.TeleopDurationSec, _ = strconv.Atoi(r.PostFormValue("teleopDurationSec"))
 	eventSettings.WarningRemainingDurationSec, _ = strconv.Atoi(r.PostFormValue("warningRemainingDurationSec"))
+	eventSettings.GameDefinitionFile = strings.TrimSpace(r.PostFormValue("gameDefinitionFile"))
+	eventSettings.RankingTiebreakers = strings.TrimSpace(r.PostFormValue("rankingTiebreakers"))
+	eventSettings.PlayoffTiebreakers = strings.TrimSpace(r.PostFormValue("playoffTiebreakers"))
+	eventSettings.RequireHeadRefApproval = r.PostFormValue("requireHeadRefApproval") == "on"
+	eventSettings.NumMatchesBeforeReplay, _ = strconv.Atoi(r.PostFormValue("numMatchesBeforeReplay"))
+	eventSettings.ScoringPanelsPerAlliance, _ = strconv.Atoi(r.PostFormValue("scoringPanelsPerAlliance"))
+	if eventSettings.ScoringPanelsPerAlliance < 0 {
+		web.renderSettings(w, r, "Number of required scoring panels must not be negative.")
+		return
+	}
+	eventSettings.DsAlertBatteryVoltage, _ = strconv.ParseFloat(r.PostFormValue("dsAlertBatteryVoltage"), 64)
+	eventSettings.DsAlertTripTimeMs, _ = strconv.Atoi(r.PostFormValue("dsAlertTripTimeMs"))
+	eventSettings.DsHistoryDurationSec, _ = strconv.Atoi(r.PostFormValue("dsHistoryDurationSec"))
+	if eventSettings.DsAlertBatteryVoltage < 0 || eventSettings.DsAlertTripTimeMs < 0 ||
+		eventSettings.DsHistoryDurationSec < 0 {
+		web.renderSettings(w, r, "Driver station alert thresholds and history length must not be negative.")
+		return
+	}
+
+	eventSettings.TimingProfileIds = make(map[string]int)
+	for _, matchType := range model.TimingProfileMatchTypes {
+		timingProfileId, _ := strconv.Atoi(r.PostFormValue("timingProfileId_" + matchType))
+		if timingProfileId == 0 {
+			continue
+		}
+		timingProfile, err := web.arena.Database.GetTimingProfileById(timingProfileId)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if timingProfile == nil {
+			web.renderSettings(w, r, fmt.Sprintf("Invalid timing profile for %s matches.", matchType))
+			return
+		}
+		eventSettings.TimingProfileIds[matchType] = timingProfileId
+	}
+
+	eventSettings.ReadinessRuleModes = make(map[string]model.ReadinessRuleMode)
+	for _, rule := range field.ReadinessRules {
+		mode := model.ReadinessRuleMode(r.PostFormValue("readinessRuleMode_" + rule.Id))
+		if mode == "" || mode == model.ReadinessRuleRequired {
+			continue
+		}
+		if mode != model.ReadinessRuleOverridable && mode != model.ReadinessRuleDisabled {
+			web.renderSettings(w, r, fmt.Sprintf("Invalid mode for the '%s' readiness check.", rule.Name))
+			return
+		}
+		if rule.IsSafety {
+			web.renderSettings(w, r, fmt.Sprintf("The '%s' readiness check must always be required.", rule.Name))
+			return
+		}
+		eventSettings.ReadinessRuleModes[rule.Id] = mode
+	}
+
+	if eventSettings.NetworkSecurityEnabled && eventSettings.TeamsPerAlliance > network.MaxAccessPointTeamsPerAlliance {
+		// Only a second access point, which gives each alliance its own, has enough team networks; fields each have a
+		// single access point.
+		fields, err := web.arena.Database.GetAllFields()
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if eventSettings.Ap2TeamChannel == 0 || len(fields) > 0 {
+			web.renderSettings(
+				w,
+				r,
+				fmt.Sprintf(
+					"A single access point only supports up to %d teams per alliance; configure a second access point "+
+						"or disable network security to use more.",
+					network.MaxAccessPointTeamsPerAlliance,
+				),
+			)
+			return
+		}
+	}
 
 	if eventSettings.Ap2TeamChannel != 0 && eventSettings.Ap2TeamChannel == eventSettings.ApTeamChannel {
 		web.renderSettings(w, r, "Cannot use same channel for both access points.")
 		return
 	}
 
-	err := web.arena.Database.UpdateEventSettings(eventSettings)
+	gameDefinition, err := eventSettings.LoadGameDefinition()
 	if err != nil {
-		handleWebErr(w, err)
+		web.renderSettings(w, r, fmt.Sprintf("Failed to load game definition: %v", err))
+		return
+	}
+	if _, err = game.ParseTiebreakers(eventSettings.RankingTiebreakers, gameDefinition); err != nil {
+		web.renderSettings(w, r, fmt.Sprintf("Invalid ranking tiebreakers: %v", err))
+		return
+	}
+	if _, err = game.ParsePlayoffTiebreakers(eventSettings.PlayoffTiebreakers, gameDefinition); err != nil {
+		web.renderSettings(w, r, fmt.Sprintf("Invalid playoff tiebreakers: %v", err))
 		return
 	}
 
-	// Refresh the arena in case any of the settings changed.
-	err = web.arena.LoadSettings()
+	err = web.arena.Database.UpdateEventSettings(eventSettings)
 	if err != nil {
 		handleWebErr(w, err)
 		return
 	}
 
+	// Refresh the arenas in case any of the settings changed.
+	for _, arena := range web.arenas() {
+		if err = arena.LoadSettings(); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
 	if eventSettings.AdminPassword != previousAdminPassword {
 		// Delete any existing user sessions to force a logout.
 		if err := web.arena.Database.TruncateUserSessions(); err != nil {
@@ -181,15 +281,17 @@ func (web *Web) restoreDbHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
-	web.arena.Database, err = model.OpenDatabase(web.arena.Database.Path)
+	database, err := model.OpenDatabase(web.arena.Database.Path)
 	if err != nil {
 		handleWebErr(w, err)
 		return
 	}
-	err = web.arena.LoadSettings()
-	if err != nil {
-		handleWebErr(w, err)
-		return
+	for _, arena := range web.arenas() {
+		arena.Database = database
+		if err = arena.LoadSettings(); err != nil {
+			handleWebErr(w, err)
+			return
+		}
 	}
 
 	http.Redirect(w, r, "/setup/settings", 303)
@@ -218,6 +320,16 @@ func (web *Web) clearDbHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
+	err = web.arena.Database.TruncateMatchReplays()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	err = web.arena.Database.TruncateScoreEvents()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
 	err = web.arena.Database.TruncateRankings()
 	if err != nil {
 		handleWebErr(w, err)
@@ -228,7 +340,9 @@ func (web *Web) clearDbHandler(w http.ResponseWriter, r *http.Request) {
 		handleWebErr(w, err)
 		return
 	}
-	web.arena.AllianceSelectionAlliances = []model.Alliance{}
+	for _, arena := range web.arenas() {
+		arena.AllianceSelectionAlliances = []model.Alliance{}
+	}
 	cachedRankedTeams = []*RankedTeam{}
 
 	http.Redirect(w, r, "/setup/settings", 303)
@@ -240,10 +354,26 @@ func (web *Web) renderSettings(w http.ResponseWriter, r *http.Request, errorMess
 		handleWebErr(w, err)
 		return
 	}
+	timingProfiles, err := web.arena.Database.GetAllTimingProfiles()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
 	data := struct {
 		*model.EventSettings
-		ErrorMessage string
-	}{web.arena.EventSettings, errorMessage}
+		ErrorMessage            string
+		TimingProfiles          []model.TimingProfile
+		TimingProfileMatchTypes []string
+		ReadinessRules          []field.ReadinessRule
+		ReadinessRuleModes      []model.ReadinessRuleMode
+	}{
+		web.arena.EventSettings,
+		errorMessage,
+		timingProfiles,
+		model.TimingProfileMatchTypes,
+		field.ReadinessRules,
+		model.ReadinessRuleModes,
+	}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
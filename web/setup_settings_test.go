@@ -28,7 +28,9 @@ func TestSetupSettings(t *testing.T) {
 
 	// Change the settings and check the response.
 	recorder = web.postHttpResponse("/setup/settings", "name=Chezy Champs&code=CC&elimType=single&numElimAlliances=16&"+
-		"tbaPublishingEnabled=on&tbaEventCode=2014cc&tbaSecretId=secretId&tbaSecret=tbasec")
+		"tbaPublishingEnabled=on&tbaEventCode=2014cc&tbaSecretId=secretId&tbaSecret=tbasec&requireHeadRefApproval=on&"+
+		"numMatchesBeforeReplay=3&teamsPerAlliance=2&scoringPanelsPerAlliance=1&dsAlertBatteryVoltage=6.5&"+
+		"dsAlertTripTimeMs=80")
 	assert.Equal(t, 303, recorder.Code)
 	recorder = web.getHttpResponse("/setup/settings")
 	assert.Contains(t, recorder.Body.String(), "Chezy Champs")
@@ -37,12 +39,37 @@ func TestSetupSettings(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), "2014cc")
 	assert.Contains(t, recorder.Body.String(), "secretId")
 	assert.Contains(t, recorder.Body.String(), "tbasec")
+	assert.True(t, web.arena.EventSettings.RequireHeadRefApproval)
+	assert.Equal(t, 3, web.arena.EventSettings.NumMatchesBeforeReplay)
+	assert.Equal(t, 1, web.arena.EventSettings.ScoringPanelsPerAlliance)
+	assert.Equal(t, 1, web.arena.ScoringPanelRegistry.GetNumRequiredPanels())
+	assert.Equal(t, 2, web.arena.EventSettings.TeamsPerAlliance)
+	assert.Equal(t, 2, web.arena.TeamsPerAlliance)
+	assert.Equal(t, 6.5, web.arena.EventSettings.DsAlertBatteryVoltage)
+	assert.Equal(t, 80, web.arena.EventSettings.DsAlertTripTimeMs)
+	assert.Equal(t, 60, web.arena.EventSettings.DsHistoryDurationSec)
+
+	// Check that four-team alliances are refused when a single access point is in use, since it lacks the networks.
+	recorder = web.postHttpResponse(
+		"/setup/settings", "elimType=single&numElimAlliances=8&teamsPerAlliance=4&networkSecurityEnabled=on",
+	)
+	assert.Contains(t, recorder.Body.String(), "only supports up to 3 teams per alliance")
+	recorder = web.postHttpResponse("/setup/settings", "elimType=single&numElimAlliances=8&teamsPerAlliance=4")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, 4, web.arena.TeamsPerAlliance)
+	recorder = web.postHttpResponse(
+		"/setup/settings",
+		"elimType=single&numElimAlliances=8&teamsPerAlliance=4&networkSecurityEnabled=on&apTeamChannel=157&"+
+			"ap2TeamChannel=36",
+	)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	assert.True(t, web.arena.EventSettings.NetworkSecurityEnabled)
 }
 
 func TestSetupSettingsDoubleElimination(t *testing.T) {
 	web := setupTestWeb(t)
 
-	recorder := web.postHttpResponse("/setup/settings", "elimType=double&numElimAlliances=3")
+	recorder := web.postHttpResponse("/setup/settings", "elimType=double&numElimAlliances=3&teamsPerAlliance=3")
 	assert.Equal(t, 303, recorder.Code)
 	assert.Equal(t, "double", web.arena.EventSettings.ElimType)
 	assert.Equal(t, 8, web.arena.EventSettings.NumElimAlliances)
@@ -54,6 +81,51 @@ func TestSetupSettingsInvalidValues(t *testing.T) {
 	// Invalid number of alliances.
 	recorder := web.postHttpResponse("/setup/settings", "numAlliances=1")
 	assert.Contains(t, recorder.Body.String(), "must be between 2 and 16")
+
+	// Invalid number of teams per alliance.
+	recorder = web.postHttpResponse("/setup/settings", "numElimAlliances=8&teamsPerAlliance=5")
+	assert.Contains(t, recorder.Body.String(), "must be between 1 and 4")
+}
+
+func TestSetupSettingsGameDefinition(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.postHttpResponse("/setup/settings", "elimType=single&numElimAlliances=8&teamsPerAlliance=3&"+
+		"gameDefinitionFile=games/nonexistent.yaml")
+	assert.Contains(t, recorder.Body.String(), "Failed to load game definition")
+	assert.Equal(t, "Generic", game.CurrentGame.Name)
+
+	recorder = web.postHttpResponse("/setup/settings", "elimType=single&numElimAlliances=8&teamsPerAlliance=3&"+
+		"gameDefinitionFile=games/reefscape_2025.yaml")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "games/reefscape_2025.yaml", web.arena.EventSettings.GameDefinitionFile)
+	assert.Equal(t, "REEFSCAPE", game.CurrentGame.Name)
+
+	// The score entry inputs should reflect the loaded game definition.
+	recorder = web.getHttpResponse("/match_play")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "data-element=\"deepCage\"")
+
+	recorder = web.postHttpResponse(
+		"/setup/settings", "elimType=single&numElimAlliances=8&teamsPerAlliance=3&gameDefinitionFile=",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, game.DefaultGameDefinition(), game.CurrentGame)
+}
+
+func TestSetupSettingsRankingTiebreakers(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.postHttpResponse("/setup/settings", "elimType=single&numElimAlliances=8&teamsPerAlliance=3&"+
+		"rankingTiebreakers=rankingPoints,deepCage")
+	assert.Contains(t, recorder.Body.String(), "Invalid ranking tiebreakers")
+
+	// Element tiebreakers are validated against the game definition being saved alongside them.
+	recorder = web.postHttpResponse("/setup/settings", "elimType=single&numElimAlliances=8&teamsPerAlliance=3&"+
+		"gameDefinitionFile=games/reefscape_2025.yaml&rankingTiebreakers=rankingPoints,+total:deepCage,+headToHead")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "rankingPoints, total:deepCage, headToHead", web.arena.EventSettings.RankingTiebreakers)
+	game.CurrentGame = game.DefaultGameDefinition()
 }
 
 func TestSetupSettingsClearDb(t *testing.T) {
@@ -125,3 +197,26 @@ func (web *Web) postFileHttpResponse(path string, paramName string, file *bytes.
 	web.newHandler().ServeHTTP(recorder, req)
 	return recorder
 }
+
+func TestSetupSettingsReadinessRules(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/settings")
+	assert.Contains(t, recorder.Body.String(), "readinessRuleMode_sccConnected")
+	recorder = web.postHttpResponse("/setup/settings", "elimType=single&numElimAlliances=8&teamsPerAlliance=3&"+
+		"readinessRuleMode_sccConnected=overridable&readinessRuleMode_plcHealthy=disabled&"+
+		"readinessRuleMode_robotsLinked=required")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(
+		t,
+		map[string]model.ReadinessRuleMode{"sccConnected": "overridable", "plcHealthy": "disabled"},
+		web.arena.EventSettings.ReadinessRuleModes,
+	)
+
+	recorder = web.postHttpResponse("/setup/settings", "elimType=single&numElimAlliances=8&teamsPerAlliance=3&"+
+		"readinessRuleMode_sccConnected=sometimes")
+	assert.Contains(t, recorder.Body.String(), "Invalid mode for the 'Alliance SCCs connected' readiness check")
+	recorder = web.postHttpResponse("/setup/settings", "elimType=single&numElimAlliances=8&teamsPerAlliance=3&"+
+		"readinessRuleMode_estops=disabled")
+	assert.Contains(t, recorder.Body.String(), "must always be required")
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+//
+// Web routes for managing the named sets of match durations that can be assigned to match types.
+
+package web
+
+import (
+	"fmt"
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Shows the timing profile configuration page.
+func (web *Web) timingProfilesGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	template, err := web.parseFiles("templates/setup_timing_profiles.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	timingProfiles, err := web.arena.Database.GetAllTimingProfiles()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Append a blank profile to the end, pre-filled with the event's durations, that can be used to add a new one.
+	settings := web.arena.EventSettings
+	timingProfiles = append(
+		timingProfiles,
+		model.TimingProfile{
+			WarmupDurationSec:           settings.WarmupDurationSec,
+			AutoDurationSec:             settings.AutoDurationSec,
+			PauseDurationSec:            settings.PauseDurationSec,
+			TeleopDurationSec:           settings.TeleopDurationSec,
+			WarningRemainingDurationSec: settings.WarningRemainingDurationSec,
+		},
+	)
+
+	data := struct {
+		*model.EventSettings
+		TimingProfiles []model.TimingProfile
+		GamePeriods    []game.MatchPeriod
+	}{web.arena.EventSettings, timingProfiles, game.CurrentGame.Periods}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Saves the new or modified timing profile to the database, or deletes it.
+func (web *Web) timingProfilesPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	timingProfileId, _ := strconv.Atoi(r.PostFormValue("id"))
+	if r.PostFormValue("action") == "delete" {
+		if err := web.arena.Database.DeleteTimingProfile(timingProfileId); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	} else {
+		timingProfile := model.TimingProfile{Id: timingProfileId, Name: strings.TrimSpace(r.PostFormValue("name"))}
+		timingProfile.WarmupDurationSec, _ = strconv.Atoi(r.PostFormValue("warmupDurationSec"))
+		timingProfile.AutoDurationSec, _ = strconv.Atoi(r.PostFormValue("autoDurationSec"))
+		timingProfile.PauseDurationSec, _ = strconv.Atoi(r.PostFormValue("pauseDurationSec"))
+		timingProfile.TeleopDurationSec, _ = strconv.Atoi(r.PostFormValue("teleopDurationSec"))
+		timingProfile.WarningRemainingDurationSec, _ = strconv.Atoi(r.PostFormValue("warningRemainingDurationSec"))
+		periodNames := r.PostForm["periodName"]
+		periodDurationSecs := r.PostForm["periodDurationSec"]
+		if len(periodNames) > 0 {
+			timingProfile.PeriodDurationSecs = make(map[string]int)
+		}
+		for i, periodName := range periodNames {
+			if i >= len(periodDurationSecs) {
+				break
+			}
+			durationSec, err := strconv.Atoi(periodDurationSecs[i])
+			if err != nil || durationSec < 0 {
+				handleWebErr(w, fmt.Errorf("invalid duration for period %s", periodName))
+				return
+			}
+			timingProfile.PeriodDurationSecs[periodName] = durationSec
+		}
+		if timingProfile.Name == "" {
+			handleWebErr(w, fmt.Errorf("timing profile must have a name"))
+			return
+		}
+		if timingProfile.WarmupDurationSec < 0 || timingProfile.AutoDurationSec < 0 ||
+			timingProfile.PauseDurationSec < 0 || timingProfile.TeleopDurationSec < 0 ||
+			timingProfile.WarningRemainingDurationSec < 0 {
+			handleWebErr(w, fmt.Errorf("timing profile durations must not be negative"))
+			return
+		}
+
+		var err error
+		if timingProfile.Id == 0 {
+			err = web.arena.Database.CreateTimingProfile(&timingProfile)
+		} else {
+			err = web.arena.Database.UpdateTimingProfile(&timingProfile)
+		}
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
+	http.Redirect(w, r, "/setup/timing_profiles", 303)
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 Team 1987. All Rights Reserved.
+
+package web
+
+import (
+	"github.com/FRCTeam1987/crimson-arena/game"
+	"github.com/FRCTeam1987/crimson-arena/model"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetupTimingProfiles(t *testing.T) {
+	web := setupTestWeb(t)
+
+	web.arena.Database.CreateTimingProfile(&model.TimingProfile{
+		Name:                        "Practice",
+		AutoDurationSec:             10,
+		PauseDurationSec:            2,
+		TeleopDurationSec:           100,
+		WarningRemainingDurationSec: 20,
+	})
+	web.arena.Database.CreateTimingProfile(&model.TimingProfile{
+		Name:                        "Playoff",
+		AutoDurationSec:             15,
+		PauseDurationSec:            3,
+		TeleopDurationSec:           135,
+		WarningRemainingDurationSec: 20,
+	})
+
+	recorder := web.getHttpResponse("/setup/timing_profiles")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "value=\"Practice\"")
+	assert.Contains(t, recorder.Body.String(), "value=\"Playoff\"")
+
+	recorder = web.postHttpResponse("/setup/timing_profiles", "action=delete&id=1")
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.getHttpResponse("/setup/timing_profiles")
+	assert.Equal(t, 200, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "value=\"Practice\"")
+
+	recorder = web.postHttpResponse(
+		"/setup/timing_profiles", "id=2&name=Finals&autoDurationSec=15&teleopDurationSec=150",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	timingProfile, _ := web.arena.Database.GetTimingProfileById(2)
+	if assert.NotNil(t, timingProfile) {
+		assert.Equal(t, "Finals", timingProfile.Name)
+		assert.Equal(t, 150, timingProfile.TeleopDurationSec)
+	}
+
+	recorder = web.postHttpResponse("/setup/timing_profiles", "name=Bad&autoDurationSec=-1")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "must not be negative")
+	recorder = web.postHttpResponse("/setup/timing_profiles", "name=&autoDurationSec=15")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "must have a name")
+
+	// Check that a profile can be assigned to a match type on the settings page.
+	recorder = web.postHttpResponse(
+		"/setup/settings", "elimType=single&numElimAlliances=8&teamsPerAlliance=3&timingProfileId_elimination=2",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, map[string]int{"elimination": 2}, web.arena.EventSettings.TimingProfileIds)
+	recorder = web.getHttpResponse("/setup/settings")
+	assert.Contains(t, recorder.Body.String(), "<option value=\"2\" selected>")
+	recorder = web.postHttpResponse(
+		"/setup/settings", "elimType=single&numElimAlliances=8&teamsPerAlliance=3&timingProfileId_practice=5",
+	)
+	assert.Contains(t, recorder.Body.String(), "Invalid timing profile for practice matches.")
+}
+
+func TestSetupTimingProfilesWithGamePeriods(t *testing.T) {
+	web := setupTestWeb(t)
+	defer func(definition *game.GameDefinition) { game.CurrentGame = definition }(game.CurrentGame)
+	web.arena.Database.CreateTimingProfile(&model.TimingProfile{Name: "Practice", AutoDurationSec: 10})
+
+	yamlPath := filepath.Join(t.TempDir(), "game.yaml")
+	os.WriteFile(yamlPath, []byte("name: Test\n"+
+		"scoringElements:\n  - {id: cube, name: Cube, period: teleop, points: 5}\n"+
+		"periods:\n"+
+		"  - {name: Sandstorm, durationSec: 15, enabled: true, auto: true}\n"+
+		"  - {name: Teleop, durationSec: 135, enabled: true}\n"), 0644)
+
+	// Check that a profile can be assigned alongside a game definition that defines its own periods.
+	recorder := web.postHttpResponse(
+		"/setup/settings",
+		"elimType=single&numElimAlliances=8&teamsPerAlliance=3&timingProfileId_practice=1&gameDefinitionFile="+
+			url.QueryEscape(yamlPath),
+	)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, 2, len(game.CurrentGame.Periods))
+	eventSettings, _ := web.arena.Database.GetEventSettings()
+	assert.Equal(t, 1, eventSettings.TimingProfileIds["practice"])
+
+	// Check that the profile's form lists the game's periods, with their default durations until overridden.
+	recorder = web.getHttpResponse("/setup/timing_profiles")
+	assert.Contains(t, recorder.Body.String(), "Sandstorm Period Duration")
+	assert.Contains(t, recorder.Body.String(), "value=\"135\"")
+
+	// Check that the profile overrides the durations of the named periods.
+	recorder = web.postHttpResponse(
+		"/setup/timing_profiles",
+		"id=1&name=Practice&autoDurationSec=12&periodName=Sandstorm&periodDurationSec=10&periodName=Teleop&"+
+			"periodDurationSec=100",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	timingProfile, _ := web.arena.Database.GetTimingProfileById(1)
+	assert.Equal(t, map[string]int{"Sandstorm": 10, "Teleop": 100}, timingProfile.PeriodDurationSecs)
+	timing := timingProfile.Apply(game.MatchTiming)
+	timing.UpdatePeriods(game.CurrentGame)
+	if assert.Equal(t, 2, len(timing.Periods)) {
+		assert.Equal(t, 10, timing.Periods[0].DurationSec)
+		assert.Equal(t, 100, timing.Periods[1].DurationSec)
+	}
+
+	recorder = web.postHttpResponse(
+		"/setup/timing_profiles", "id=1&name=Practice&periodName=Sandstorm&periodDurationSec=-1",
+	)
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "invalid duration for period Sandstorm")
+	recorder = web.postHttpResponse("/setup/timing_profiles", "action=delete&id=1")
+	assert.Equal(t, 303, recorder.Code)
+}
@@ -11,23 +11,39 @@ import (
 	"github.com/FRCTeam1987/crimson-arena/model"
 	"github.com/gorilla/mux"
 	"log"
+	"net"
 	"net/http"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"text/template"
 )
 
 const (
 	sessionTokenCookie = "session_token"
+	fieldCookie        = "field"
 	adminUser          = "admin"
 )
 
 type Web struct {
 	arena           *field.Arena
 	templateHelpers template.FuncMap
+	// Instances of the web interface for each field run from this server, including this one, in field order.
+	fieldWebs []*Web
 }
 
-func NewWeb(arena *field.Arena) *Web {
+// Creates an instance of the web interface for each of the given arenas, one per field, and returns the one for the
+// first field. Requests are routed to the instance for the field they are for.
+func NewWeb(arenas ...*field.Arena) *Web {
+	fieldWebs := make([]*Web, len(arenas))
+	for i, arena := range arenas {
+		fieldWebs[i] = newFieldWeb(arena)
+		fieldWebs[i].fieldWebs = fieldWebs
+	}
+	return fieldWebs[0]
+}
+
+func newFieldWeb(arena *field.Arena) *Web {
 	web := &Web{arena: arena}
 
 	// Helper functions that can be used inside templates.
@@ -53,6 +69,9 @@ func NewWeb(arena *field.Arena) *Web {
 		"multiply": func(a, b int) int {
 			return a * b
 		},
+		"divide": func(a, b int) int {
+			return a / b
+		},
 		"seq": func(count int) []int {
 			seq := make([]int, count)
 			for i := 0; i < count; i++ {
@@ -60,9 +79,36 @@ func NewWeb(arena *field.Arena) *Web {
 			}
 			return seq
 		},
+		// Returns the sequence from count down to 1, for listing red stations in their right-to-left order.
+		"reverseSeq": func(count int) []int {
+			seq := make([]int, count)
+			for i := 0; i < count; i++ {
+				seq[i] = count - i
+			}
+			return seq
+		},
 		"toUpper": func(str string) string {
 			return strings.ToUpper(str)
 		},
+		// Returns the fields run from this server, or nil if there is only a single field.
+		"fields": func() []*model.Field {
+			var fields []*model.Field
+			for _, fieldWeb := range web.fieldWebs {
+				if fieldWeb.arena.Field != nil {
+					fields = append(fields, fieldWeb.arena.Field)
+				}
+			}
+			return fields
+		},
+		"teamsPerAlliance": func() int {
+			return web.arena.TeamsPerAlliance
+		},
+		"fieldName": func() string {
+			if web.arena.Field == nil {
+				return ""
+			}
+			return web.arena.Field.Name
+		},
 	}
 
 	return web
@@ -103,8 +149,75 @@ func addNoCacheHeader(handler http.Handler) http.Handler {
 	})
 }
 
-// Sets up the mapping between URLs and handlers.
+// Returns a handler that routes each request to the instance of the web interface for the field it is for.
 func (web *Web) newHandler() http.Handler {
+	if len(web.fieldWebs) < 2 {
+		return web.newFieldHandler()
+	}
+	fieldHandlers := make(map[*Web]http.Handler, len(web.fieldWebs))
+	for _, fieldWeb := range web.fieldWebs {
+		fieldHandlers[fieldWeb] = fieldWeb.newFieldHandler()
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fieldHandlers[web.getFieldWeb(w, r)].ServeHTTP(w, r)
+	})
+}
+
+// Returns the instance of the web interface for the field given by the request's "field" query parameter or, failing
+// that, the field last selected from the same browser, so that pages linking back to themselves stay on the same
+// field. Requests without either, such as those from the SCCs, go to the field whose network address they were made
+// to, and otherwise to the first field.
+func (web *Web) getFieldWeb(w http.ResponseWriter, r *http.Request) *Web {
+	fieldId := r.URL.Query().Get("field")
+	if fieldId != "" {
+		http.SetCookie(w, &http.Cookie{Name: fieldCookie, Value: fieldId, Path: "/"})
+	} else if cookie, err := r.Cookie(fieldCookie); err == nil {
+		fieldId = cookie.Value
+	}
+	for _, fieldWeb := range web.fieldWebs {
+		if strconv.Itoa(fieldWeb.arena.FieldId()) == fieldId {
+			return fieldWeb
+		}
+	}
+	if localAddr, ok := r.Context().Value(http.LocalAddrContextKey).(net.Addr); ok {
+		if host, _, err := net.SplitHostPort(localAddr.String()); err == nil {
+			for _, fieldWeb := range web.fieldWebs {
+				if fieldWeb.arena.ServerIpAddress() == host {
+					return fieldWeb
+				}
+			}
+		}
+	}
+	return web.fieldWebs[0]
+}
+
+// Returns the arenas of all the fields run from this server, for changes that need to be applied to all of them.
+func (web *Web) arenas() []*field.Arena {
+	arenas := make([]*field.Arena, len(web.fieldWebs))
+	for i, fieldWeb := range web.fieldWebs {
+		arenas[i] = fieldWeb.arena
+	}
+	return arenas
+}
+
+// Rebuilds the in-memory playoff bracket of each other field's arena from the database after this one's has changed.
+func (web *Web) updateOtherPlayoffBrackets() error {
+	for _, arena := range web.arenas() {
+		if arena == web.arena {
+			continue
+		}
+		if err := arena.CreatePlayoffBracket(); err != nil {
+			return err
+		}
+		if err := arena.UpdatePlayoffBracket(nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sets up the mapping between URLs and handlers for this instance's field.
+func (web *Web) newFieldHandler() http.Handler {
 	router := mux.NewRouter()
 	router.HandleFunc("/", web.indexHandler).Methods("GET")
 	router.HandleFunc("/alliance_selection", web.allianceSelectionGetHandler).Methods("GET")
@@ -116,8 +229,10 @@ func (web *Web) newHandler() http.Handler {
 	router.HandleFunc("/api/alliances", web.alliancesApiHandler).Methods("GET")
 	router.HandleFunc("/api/arena/websocket", web.arenaWebsocketApiHandler).Methods("GET")
 	router.HandleFunc("/api/bracket/svg", web.bracketSvgApiHandler).Methods("GET")
+	router.HandleFunc("/api/driver_stations/history", web.driverStationHistoryApiHandler).Methods("GET")
 	router.HandleFunc("/api/matches/{type}", web.matchesApiHandler).Methods("GET")
 	router.HandleFunc("/api/rankings", web.rankingsApiHandler).Methods("GET")
+	router.HandleFunc("/api/score_timeline/svg", web.scoreTimelineSvgApiHandler).Methods("GET")
 	router.HandleFunc("/api/scores", web.getScoresHandler).Methods("GET")
 	router.HandleFunc("/api/scores", web.setScoresHandler).Methods("PATCH", "PUT")
 	router.HandleFunc("/api/sponsor_slides", web.sponsorSlidesApiHandler).Methods("GET")
@@ -147,16 +262,30 @@ func (web *Web) newHandler() http.Handler {
 	router.HandleFunc("/match_play/{matchId}/load", web.matchPlayLoadHandler).Methods("GET")
 	router.HandleFunc("/match_play/{matchId}/show_result", web.matchPlayShowResultHandler).Methods("GET")
 	router.HandleFunc("/match_play/clear_result", web.matchPlayClearResultHandler).Methods("GET")
+	router.HandleFunc("/match_play/recovery/discard", web.matchPlayRecoveryDiscardHandler).Methods("POST")
+	router.HandleFunc("/match_play/recovery/restore", web.matchPlayRecoveryRestoreHandler).Methods("POST")
 	router.HandleFunc("/match_play/websocket", web.matchPlayWebsocketHandler).Methods("GET")
 	router.HandleFunc("/match_review", web.matchReviewHandler).Methods("GET")
+	router.HandleFunc("/operator_actions", web.operatorActionsGetHandler).Methods("GET")
 	router.HandleFunc("/match_review/{matchId}/edit", web.matchReviewEditGetHandler).Methods("GET")
 	router.HandleFunc("/match_review/{matchId}/edit", web.matchReviewEditPostHandler).Methods("POST")
+	router.HandleFunc("/match_review/{matchId}/plays", web.matchReviewPlaysGetHandler).Methods("GET")
+	router.HandleFunc("/match_review/{matchId}/plays", web.matchReviewPlaysPostHandler).Methods("POST")
+	router.HandleFunc("/match_review/{matchId}/replay", web.matchReviewReplayPostHandler).Methods("POST")
+	router.HandleFunc("/match_review/{matchId}/score_log", web.matchReviewScoreLogHandler).Methods("GET")
+	router.HandleFunc("/match_review/{matchId}/unscore", web.matchReviewUnscorePostHandler).Methods("POST")
 	router.HandleFunc("/panels/lights", web.lightsPanelHandler).Methods("GET")
 	router.HandleFunc("/panels/lights/websocket", web.lightsPanelWebsocketHandler).Methods("GET")
+	router.HandleFunc("/panels/referee", web.refereePanelHandler).Methods("GET")
+	router.HandleFunc("/panels/referee/websocket", web.refereePanelWebsocketHandler).Methods("GET")
+	router.HandleFunc("/panels/scoring/{alliance}", web.scoringPanelHandler).Methods("GET")
+	router.HandleFunc("/panels/scoring/{alliance}/websocket", web.scoringPanelWebsocketHandler).Methods("GET")
 	router.HandleFunc("/reports/csv/rankings", web.rankingsCsvReportHandler).Methods("GET")
 	router.HandleFunc("/reports/pdf/rankings", web.rankingsPdfReportHandler).Methods("GET")
 	router.HandleFunc("/reports/csv/backups", web.backupTeamsCsvReportHandler).Methods("GET")
 	router.HandleFunc("/reports/csv/rankings", web.rankingsCsvReportHandler).Methods("GET")
+	router.HandleFunc("/reports/csv/replays", web.replaysCsvReportHandler).Methods("GET")
+	router.HandleFunc("/reports/csv/operator_actions", web.operatorActionsCsvReportHandler).Methods("GET")
 	router.HandleFunc("/reports/csv/schedule/{type}", web.scheduleCsvReportHandler).Methods("GET")
 	router.HandleFunc("/reports/csv/teams", web.teamsCsvReportHandler).Methods("GET")
 	router.HandleFunc("/reports/csv/wpa_keys", web.wpaKeysCsvReportHandler).Methods("GET")
@@ -166,6 +295,7 @@ func (web *Web) newHandler() http.Handler {
 	router.HandleFunc("/reports/pdf/bracket", web.bracketPdfReportHandler).Methods("GET")
 	router.HandleFunc("/reports/pdf/coupons", web.couponsPdfReportHandler).Methods("GET")
 	router.HandleFunc("/reports/pdf/rankings", web.rankingsPdfReportHandler).Methods("GET")
+	router.HandleFunc("/reports/pdf/replays", web.replaysPdfReportHandler).Methods("GET")
 	router.HandleFunc("/reports/pdf/schedule/{type}", web.schedulePdfReportHandler).Methods("GET")
 	router.HandleFunc("/reports/pdf/teams", web.teamsPdfReportHandler).Methods("GET")
 	router.HandleFunc("/setup/awards", web.awardsGetHandler).Methods("GET")
@@ -178,6 +308,8 @@ func (web *Web) newHandler() http.Handler {
 	router.HandleFunc("/setup/displays/websocket", web.displaysWebsocketHandler).Methods("GET")
 	router.HandleFunc("/setup/field_testing", web.fieldTestingGetHandler).Methods("GET")
 	router.HandleFunc("/setup/field_testing/websocket", web.fieldTestingWebsocketHandler).Methods("GET")
+	router.HandleFunc("/setup/fields", web.fieldsGetHandler).Methods("GET")
+	router.HandleFunc("/setup/fields", web.fieldsPostHandler).Methods("POST")
 	router.HandleFunc("/setup/lower_thirds", web.lowerThirdsGetHandler).Methods("GET")
 	router.HandleFunc("/setup/lower_thirds/websocket", web.lowerThirdsWebsocketHandler).Methods("GET")
 	router.HandleFunc("/setup/scc", web.sccGetHandler).Methods("GET")
@@ -199,6 +331,8 @@ func (web *Web) newHandler() http.Handler {
 	router.HandleFunc("/setup/teams/generate_wpa_keys", web.teamsGenerateWpaKeysHandler).Methods("GET")
 	router.HandleFunc("/setup/teams/publish", web.teamsPublishHandler).Methods("POST")
 	router.HandleFunc("/setup/teams/refresh", web.teamsRefreshHandler).Methods("GET")
+	router.HandleFunc("/setup/timing_profiles", web.timingProfilesGetHandler).Methods("GET")
+	router.HandleFunc("/setup/timing_profiles", web.timingProfilesPostHandler).Methods("POST")
 	return router
 }
 